@@ -0,0 +1,332 @@
+// Cloud Billing export, read from BigQuery or from a local CSV or Parquet dump of the export table
+
+package gcp
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"google.golang.org/api/bigquery/v2"
+)
+
+// BillingExportDays is the number of days of billing data read from the export
+const BillingExportDays = 30
+
+// ResourceCost is the billed cost of a resource over the last BillingExportDays days
+type ResourceCost struct {
+	Cost float64
+	// RuntimeHours is the number of hours the resource has been billed for
+	RuntimeHours int64
+}
+
+type BillingExport struct {
+	// Table is the BigQuery detailed usage cost export table, formatted as project.dataset.table
+	Table string
+	// File is a local CSV or Parquet (with the .parquet extension) dump of the detailed usage cost export table
+	File string
+
+	bigqueryService *bigquery.Service
+	GCP
+}
+
+func NewBillingExport(scopes []string, table, file string) *BillingExport {
+	return &BillingExport{
+		Table: table,
+		File:  file,
+		GCP:   NewGCP(scopes),
+	}
+}
+
+func (b *BillingExport) InitializeClient(ctx context.Context) error {
+
+	err := b.GCP.GetCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	if b.Table == "" {
+		return nil
+	}
+
+	bigqueryService, err := bigquery.NewService(
+		ctx,
+		b.GCP.clientOption(BigQueryAPI),
+	)
+	if err != nil {
+		return err
+	}
+
+	b.bigqueryService = bigqueryService
+
+	return nil
+}
+
+func (b *BillingExport) CloseClient() error {
+	return nil
+}
+
+// GetResourceCosts returns the Compute Engine costs (after credits) of the project's resources,
+// keyed by both the resource id and the ResourceCostKey of the resource type and name
+func (b *BillingExport) GetResourceCosts(ctx context.Context) (map[string]ResourceCost, error) {
+	if b.Table != "" {
+		return b.queryResourceCosts(ctx)
+	}
+	if b.File != "" {
+		return b.readResourceCosts()
+	}
+	return nil, errors.New("no billing export table or file provided")
+}
+
+func (b *BillingExport) queryResourceCosts(ctx context.Context) (map[string]ResourceCost, error) {
+	useLegacySql := false
+	query := fmt.Sprintf(
+		"SELECT resource.global_name, resource.name, "+
+			"SUM(cost) + SUM(IFNULL((SELECT SUM(c.amount) FROM UNNEST(credits) c), 0)), "+
+			"COUNT(DISTINCT usage_start_time) "+
+			"FROM `%s` "+
+			"WHERE project.id = @project AND service.description = 'Compute Engine' "+
+			"AND usage_start_time >= TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL %d DAY) "+
+			"GROUP BY 1, 2",
+		b.Table, BillingExportDays,
+	)
+
+	response, err := b.bigqueryService.Jobs.Query(b.ProjectID, &bigquery.QueryRequest{
+		Query:        query,
+		UseLegacySql: &useLegacySql,
+		QueryParameters: []*bigquery.QueryParameter{
+			{
+				Name:           "project",
+				ParameterType:  &bigquery.QueryParameterType{Type: "STRING"},
+				ParameterValue: &bigquery.QueryParameterValue{Value: b.ProjectID},
+			},
+		},
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	costs := make(map[string]ResourceCost)
+	addRows := func(rows []*bigquery.TableRow) {
+		for _, row := range rows {
+			if len(row.F) < 4 {
+				continue
+			}
+			globalName, _ := row.F[0].V.(string)
+			name, _ := row.F[1].V.(string)
+			cost, err := strconv.ParseFloat(fmt.Sprint(row.F[2].V), 64)
+			if globalName == "" || err != nil {
+				continue
+			}
+			hours, _ := strconv.ParseInt(fmt.Sprint(row.F[3].V), 10, 64)
+			addResourceCost(costs, globalName, name, cost, hours)
+		}
+	}
+	addRows(response.Rows)
+
+	jobId := response.JobReference.JobId
+	location := response.JobReference.Location
+	complete, pageToken := response.JobComplete, response.PageToken
+	for !complete || pageToken != "" {
+		results, err := b.bigqueryService.Jobs.GetQueryResults(b.ProjectID, jobId).
+			Location(location).PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+		if results.JobComplete {
+			addRows(results.Rows)
+		}
+		complete, pageToken = results.JobComplete, results.PageToken
+	}
+
+	return costs, nil
+}
+
+// readResourceCosts reads a CSV or Parquet dump of the billing export. A CSV dump must have the flattened
+// resource.global_name, cost, credits (optional) and usage_start_time columns, a Parquet dump the nested
+// columns of the export table.
+func (b *BillingExport) readResourceCosts() (map[string]ResourceCost, error) {
+	f, err := os.Open(b.File)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(b.File), ".parquet") {
+		stat, err := f.Stat()
+		if err != nil {
+			return nil, err
+		}
+		return ParseBillingExportParquet(f, stat.Size())
+	}
+	return ParseBillingExportCsv(f)
+}
+
+// billingResources sums up the cost and billed hours of the Compute Engine resources of the billing export rows
+type billingResources map[string]*billingResource
+
+type billingResource struct {
+	globalName string
+	name       string
+	cost       float64
+	hours      map[string]bool
+}
+
+func (r billingResources) add(service, globalName, name string, cost, credits float64, usageStartTime string) {
+	if (service != "" && service != "Compute Engine") || globalName == "" {
+		return
+	}
+	res, ok := r[globalName]
+	if !ok {
+		res = &billingResource{globalName: globalName, name: name, hours: make(map[string]bool)}
+		r[globalName] = res
+	}
+	res.cost += cost + credits
+	if usageStartTime != "" {
+		res.hours[usageStartTime] = true
+	}
+}
+
+func (r billingResources) costs() map[string]ResourceCost {
+	costs := make(map[string]ResourceCost)
+	for _, res := range r {
+		addResourceCost(costs, res.globalName, res.name, res.cost, int64(len(res.hours)))
+	}
+	return costs
+}
+
+// ParseBillingExportCsv sums up the cost and billed hours of every resource in a CSV dump of the billing export
+func ParseBillingExportCsv(r io.Reader) (map[string]ResourceCost, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, h := range header {
+		columns[strings.ReplaceAll(strings.ToLower(strings.TrimSpace(h)), ".", "_")] = i
+	}
+	column := func(record []string, names ...string) string {
+		for _, name := range names {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return record[i]
+			}
+		}
+		return ""
+	}
+	if _, ok := columns["cost"]; !ok {
+		return nil, errors.New("billing export file has no cost column")
+	}
+
+	resources := make(billingResources)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		globalName := column(record, "resource_global_name", "global_name")
+		cost, err := strconv.ParseFloat(column(record, "cost"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cost for %s: %s", globalName, err.Error())
+		}
+		var credits float64
+		if c := column(record, "credits", "credits_amount"); c != "" {
+			credits, _ = strconv.ParseFloat(c, 64)
+		}
+		resources.add(column(record, "service_description"), globalName, column(record, "resource_name", "name"), cost, credits, column(record, "usage_start_time"))
+	}
+
+	return resources.costs(), nil
+}
+
+// billingExportRow holds the columns of the detailed usage cost export table read from a Parquet dump
+type billingExportRow struct {
+	Service struct {
+		Description string `parquet:"description,optional"`
+	} `parquet:"service,optional"`
+	Resource struct {
+		Name       string `parquet:"name,optional"`
+		GlobalName string `parquet:"global_name,optional"`
+	} `parquet:"resource,optional"`
+	Cost    float64 `parquet:"cost,optional"`
+	Credits []struct {
+		Amount float64 `parquet:"amount,optional"`
+	} `parquet:"credits,list"`
+	UsageStartTime time.Time `parquet:"usage_start_time,timestamp(microsecond),optional"`
+}
+
+// ParseBillingExportParquet sums up the cost and billed hours of every resource in a Parquet dump of the billing export
+func ParseBillingExportParquet(r io.ReaderAt, size int64) (map[string]ResourceCost, error) {
+	file, err := parquet.OpenFile(r, size)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := file.Schema().Lookup("cost"); !ok {
+		return nil, errors.New("billing export file has no cost column")
+	}
+
+	reader := parquet.NewGenericReader[billingExportRow](file)
+	defer reader.Close()
+
+	resources := make(billingResources)
+	rows := make([]billingExportRow, 1024)
+	for {
+		n, err := reader.Read(rows)
+		for _, row := range rows[:n] {
+			var credits float64
+			for _, c := range row.Credits {
+				credits += c.Amount
+			}
+			var usageStartTime string
+			if !row.UsageStartTime.IsZero() {
+				usageStartTime = row.UsageStartTime.UTC().Format(time.RFC3339)
+			}
+			resources.add(row.Service.Description, row.Resource.GlobalName, row.Resource.Name, row.Cost, credits, usageStartTime)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resources.costs(), nil
+}
+
+// addResourceCost keys the cost by the id at the end of the resource global name
+// (//compute.googleapis.com/projects/<project>/zones/<zone>/instances/<id>), and by the resource type of the
+// global name and the resource name, so an instance and a disk sharing a name are told apart
+func addResourceCost(costs map[string]ResourceCost, globalName, name string, cost float64, hours int64) {
+	add := func(key string) {
+		rc := costs[key]
+		rc.Cost += cost
+		rc.RuntimeHours = max(rc.RuntimeHours, hours)
+		costs[key] = rc
+	}
+	parts := strings.Split(globalName, "/")
+	id := parts[len(parts)-1]
+	add(id)
+	if len(parts) > 1 && name != "" && name != id {
+		add(ResourceCostKey(parts[len(parts)-2], name))
+	}
+}
+
+// ResourceCostKey is the key of the cost of a resource by its type (the collection in its global name, e.g.
+// instances or disks) and its name
+func ResourceCostKey(resourceType, name string) string {
+	return resourceType + "/" + name
+}
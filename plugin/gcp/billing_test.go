@@ -0,0 +1,82 @@
+package gcp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestParseBillingExportCsv(t *testing.T) {
+	dump := `service.description,resource.name,resource.global_name,cost,credits,usage_start_time
+Compute Engine,vm-1,//compute.googleapis.com/projects/p/zones/us-central1-a/instances/1234,1.5,-0.5,2024-05-01 00:00:00 UTC
+Compute Engine,vm-1,//compute.googleapis.com/projects/p/zones/us-central1-a/instances/1234,2.0,,2024-05-01 01:00:00 UTC
+Compute Engine,disk-1,//compute.googleapis.com/projects/p/zones/us-central1-a/disks/5678,0.25,,2024-05-01 00:00:00 UTC
+Cloud Storage,bucket-1,//storage.googleapis.com/projects/_/buckets/bucket-1,10,,2024-05-01 00:00:00 UTC
+`
+
+	costs, err := ParseBillingExportCsv(strings.NewReader(dump))
+	if err != nil {
+		t.Errorf("[%s]: %s", t.Name(), err.Error())
+		return
+	}
+
+	if cost := costs["1234"]; cost.Cost != 3.0 || cost.RuntimeHours != 2 {
+		t.Errorf("[%s]: unexpected instance cost %+v", t.Name(), cost)
+	}
+	if cost := costs["5678"]; cost.Cost != 0.25 || cost.RuntimeHours != 1 {
+		t.Errorf("[%s]: unexpected disk cost %+v", t.Name(), cost)
+	}
+	if _, ok := costs["bucket-1"]; ok {
+		t.Errorf("[%s]: non compute engine costs should be skipped", t.Name())
+	}
+	if _, ok := costs["vm-1"]; ok {
+		t.Errorf("[%s]: costs should not be keyed by the bare resource name", t.Name())
+	}
+}
+
+func TestParseBillingExportParquet(t *testing.T) {
+	row := func(service, name, globalName string, cost float64, credits []float64, hour int) billingExportRow {
+		r := billingExportRow{Cost: cost, UsageStartTime: time.Date(2024, 5, 1, hour, 0, 0, 0, time.UTC)}
+		r.Service.Description = service
+		r.Resource.Name = name
+		r.Resource.GlobalName = globalName
+		for _, c := range credits {
+			r.Credits = append(r.Credits, struct {
+				Amount float64 `parquet:"amount,optional"`
+			}{Amount: c})
+		}
+		return r
+	}
+
+	var buf bytes.Buffer
+	err := parquet.Write(&buf, []billingExportRow{
+		row("Compute Engine", "vm-1", "//compute.googleapis.com/projects/p/zones/us-central1-a/instances/1234", 1.5, []float64{-0.25, -0.25}, 0),
+		row("Compute Engine", "vm-1", "//compute.googleapis.com/projects/p/zones/us-central1-a/instances/1234", 2.0, nil, 1),
+		row("Compute Engine", "vm-1", "//compute.googleapis.com/projects/p/zones/us-central1-a/disks/5678", 0.25, nil, 0),
+		row("Cloud Storage", "bucket-1", "//storage.googleapis.com/projects/_/buckets/bucket-1", 10, nil, 0),
+	})
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+
+	costs, err := ParseBillingExportParquet(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+
+	if cost := costs["1234"]; cost.Cost != 3.0 || cost.RuntimeHours != 2 {
+		t.Errorf("[%s]: unexpected instance cost %+v", t.Name(), cost)
+	}
+	if cost := costs[ResourceCostKey("instances", "vm-1")]; cost.Cost != 3.0 {
+		t.Errorf("[%s]: unexpected instance cost by name %+v", t.Name(), cost)
+	}
+	if cost := costs[ResourceCostKey("disks", "vm-1")]; cost.Cost != 0.25 || cost.RuntimeHours != 1 {
+		t.Errorf("[%s]: unexpected disk cost by name %+v", t.Name(), cost)
+	}
+	if _, ok := costs["bucket-1"]; ok {
+		t.Errorf("[%s]: non compute engine costs should be skipped", t.Name())
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"errors"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/iterator"
-	"google.golang.org/api/option"
 	"log"
 )
 
@@ -38,7 +37,7 @@ func (c *Compute) InitializeClient(ctx context.Context) error {
 
 	instancesClient, err := computeApi.NewInstancesRESTClient(
 		ctx,
-		option.WithCredentials(c.GCP.credentials),
+		c.GCP.clientOption(ComputeAPI),
 	)
 	if err != nil {
 		return err
@@ -46,7 +45,7 @@ func (c *Compute) InitializeClient(ctx context.Context) error {
 
 	machineTypeClient, err := computeApi.NewMachineTypesRESTClient(
 		ctx,
-		option.WithCredentials(c.GCP.credentials),
+		c.GCP.clientOption(ComputeAPI),
 	)
 	if err != nil {
 		return err
@@ -54,7 +53,7 @@ func (c *Compute) InitializeClient(ctx context.Context) error {
 
 	computeService, err := compute.NewService(
 		ctx,
-		option.WithCredentials(c.GCP.credentials),
+		c.GCP.clientOption(ComputeAPI),
 	)
 	if err != nil {
 		return err
@@ -158,6 +157,26 @@ func (c *Compute) GetDiskDetails(ctx context.Context, zone, diskName string) (*c
 	return disk, nil
 }
 
+// GetAllDisks returns the zonal and regional disks of the project indexed by their self-link
+func (c *Compute) GetAllDisks(ctx context.Context) (map[string]*compute.Disk, error) {
+
+	allDisks := make(map[string]*compute.Disk)
+
+	err := c.computeService.Disks.AggregatedList(c.ProjectID).Pages(ctx, func(list *compute.DiskAggregatedList) error {
+		for _, scoped := range list.Items {
+			for _, disk := range scoped.Disks {
+				allDisks[disk.SelfLink] = disk
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allDisks, nil
+}
+
 func (c *Compute) GetMemory(ctx context.Context, instanceMachineType string, zone string) (*int32, error) {
 
 	request := &computepb.GetMachineTypeRequest{
@@ -176,3 +195,33 @@ func (c *Compute) GetMemory(ctx context.Context, instanceMachineType string, zon
 	return &memory, nil
 
 }
+
+// GetMachineType returns the vCPUs and memory of a predefined or custom machine type in the zone
+func (c *Compute) GetMachineType(ctx context.Context, instanceMachineType string, zone string) (*computepb.MachineType, error) {
+
+	request := &computepb.GetMachineTypeRequest{
+		Project:     c.ProjectID,
+		MachineType: instanceMachineType,
+		Zone:        zone,
+	}
+
+	return c.machineTypeClient.Get(ctx, request)
+}
+
+// GetAllCommitments returns the committed use discounts purchased in every region of the project
+func (c *Compute) GetAllCommitments(ctx context.Context) ([]*compute.Commitment, error) {
+
+	var allCommitments []*compute.Commitment
+
+	err := c.computeService.RegionCommitments.AggregatedList(c.ProjectID).Pages(ctx, func(list *compute.CommitmentAggregatedList) error {
+		for _, scoped := range list.Items {
+			allCommitments = append(allCommitments, scoped.Commitments...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allCommitments, nil
+}
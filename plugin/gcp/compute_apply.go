@@ -0,0 +1,483 @@
+// Applying rightsizing recommendations to Compute Engine Instances, needs the compute scope
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/api/compute/v1"
+
+	util "github.com/opengovern/plugin-gcp/utils"
+)
+
+// DiskTypeChange moves an attached disk to a new disk of another type, restored from a snapshot. The
+// provisioned IOPS and throughput only apply to pd-extreme and hyperdisk types, zero leaves the default.
+type DiskTypeChange struct {
+	Name                  string `json:"name"`
+	Type                  string `json:"type"`
+	SizeGb                int64  `json:"size_gb"`
+	ProvisionedIops       int64  `json:"provisioned_iops,omitempty"`
+	ProvisionedThroughput int64  `json:"provisioned_throughput,omitempty"`
+}
+
+// DiskProvisioningChange changes the provisioned IOPS and throughput of a pd-extreme or hyperdisk disk in
+// place, zero keeps the current value
+type DiskProvisioningChange struct {
+	Name                  string `json:"name"`
+	ProvisionedIops       int64  `json:"provisioned_iops,omitempty"`
+	ProvisionedThroughput int64  `json:"provisioned_throughput,omitempty"`
+}
+
+// RightsizingPlan is the change applied to an instance, an empty machine type keeps the current one
+type RightsizingPlan struct {
+	Zone         string                   `json:"zone"`
+	Instance     string                   `json:"instance"`
+	MachineType  string                   `json:"machine_type"`
+	Disks        []DiskTypeChange         `json:"disks"`
+	Provisioning []DiskProvisioningChange `json:"provisioning,omitempty"`
+}
+
+// DiskSwap is a disk replaced by a new disk, the old disk and its snapshot are kept for rollback
+type DiskSwap struct {
+	DeviceName string `json:"device_name"`
+	Boot       bool   `json:"boot"`
+	OldDisk    string `json:"old_disk"`
+	NewDisk    string `json:"new_disk"`
+	Snapshot   string `json:"snapshot"`
+	// Attached is set once the new disk is attached in place of the old disk
+	Attached bool `json:"attached"`
+}
+
+// RollbackRecord is what is needed to undo an applied plan, the steps are the ones completed
+// (or planned in a dry run) and the rollback commands revert them. A failed apply is rolled back
+// automatically, the rollback commands are only left when that fails too.
+type RollbackRecord struct {
+	Project             string          `json:"project"`
+	Zone                string          `json:"zone"`
+	Instance            string          `json:"instance"`
+	DryRun              bool            `json:"dry_run"`
+	StartedAt           time.Time       `json:"started_at"`
+	Plan                RightsizingPlan `json:"plan"`
+	PreviousMachineType string          `json:"previous_machine_type"`
+	PreviousStatus      string          `json:"previous_status"`
+	Steps               []string        `json:"steps"`
+	DiskSwaps           []DiskSwap      `json:"disk_swaps"`
+	// PreviousProvisioning are the provisioned IOPS and throughput of the disks changed in place
+	PreviousProvisioning []DiskProvisioningChange `json:"previous_provisioning,omitempty"`
+	Error                string                   `json:"error,omitempty"`
+	RolledBack           bool                     `json:"rolled_back"`
+	RollbackError        string                   `json:"rollback_error,omitempty"`
+	RollbackCommands     []string                 `json:"rollback_commands"`
+}
+
+// ApplyRightsizing changes the provisioned IOPS and throughput of the disks in place, stops the instance, changes
+// its machine type and disk types, starts it again if it was running and verifies the result. When a step fails
+// the completed steps are rolled back and the instance is started again if it was running. Nothing is changed
+// in a dry run, the returned record holds the planned steps.
+func (c *Compute) ApplyRightsizing(ctx context.Context, plan RightsizingPlan, dryRun bool) (*RollbackRecord, error) {
+	record := &RollbackRecord{
+		Project:   c.ProjectID,
+		Zone:      plan.Zone,
+		Instance:  plan.Instance,
+		DryRun:    dryRun,
+		StartedAt: time.Now(),
+		Plan:      plan,
+	}
+	err := c.applyRightsizing(ctx, plan, record)
+	if err != nil {
+		record.Error = err.Error()
+		if !dryRun && len(record.Steps) > 0 {
+			rollbackErr := c.rollback(ctx, record)
+			if rollbackErr != nil {
+				record.RollbackError = rollbackErr.Error()
+			} else {
+				record.RolledBack = true
+			}
+		}
+	}
+	if !record.RolledBack {
+		record.RollbackCommands = rollbackCommands(record)
+	}
+	if err != nil {
+		return record, err
+	}
+	return record, nil
+}
+
+func (c *Compute) applyRightsizing(ctx context.Context, plan RightsizingPlan, record *RollbackRecord) error {
+	instances := c.computeService.Instances
+
+	instance, err := instances.Get(c.ProjectID, plan.Zone, plan.Instance).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	record.PreviousMachineType = util.TrimmedString(instance.MachineType, "/")
+	record.PreviousStatus = instance.Status
+
+	step := c.stepper(ctx, record, true)
+
+	// the provisioned IOPS and throughput change online, before the instance is stopped
+	for _, change := range plan.Provisioning {
+		err = c.changeDiskProvisioning(ctx, change, record, step)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the instance is only stopped to change its machine type or disk types
+	if (plan.MachineType == "" || plan.MachineType == record.PreviousMachineType) && len(plan.Disks) == 0 {
+		return nil
+	}
+
+	if instance.Status != "TERMINATED" {
+		err = step("stop", func() (*compute.Operation, error) {
+			return instances.Stop(c.ProjectID, plan.Zone, plan.Instance).Context(ctx).Do()
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if plan.MachineType != "" && plan.MachineType != record.PreviousMachineType {
+		err = step(fmt.Sprintf("set-machine-type %s", plan.MachineType), func() (*compute.Operation, error) {
+			return instances.SetMachineType(c.ProjectID, plan.Zone, plan.Instance, &compute.InstancesSetMachineTypeRequest{
+				MachineType: fmt.Sprintf("zones/%s/machineTypes/%s", plan.Zone, plan.MachineType),
+			}).Context(ctx).Do()
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, change := range plan.Disks {
+		err = c.changeDiskType(ctx, instance, change, record, step)
+		if err != nil {
+			return err
+		}
+	}
+
+	if instance.Status != "RUNNING" {
+		return nil
+	}
+
+	err = step("start", func() (*compute.Operation, error) {
+		return instances.Start(c.ProjectID, plan.Zone, plan.Instance).Context(ctx).Do()
+	})
+	if err != nil || record.DryRun {
+		return err
+	}
+
+	instance, err = instances.Get(c.ProjectID, plan.Zone, plan.Instance).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	if instance.Status != "RUNNING" {
+		return fmt.Errorf("verify: instance is %s after start", instance.Status)
+	}
+	if plan.MachineType != "" && util.TrimmedString(instance.MachineType, "/") != plan.MachineType {
+		return fmt.Errorf("verify: machine type is %s instead of %s", util.TrimmedString(instance.MachineType, "/"), plan.MachineType)
+	}
+	record.Steps = append(record.Steps, "verify")
+
+	return nil
+}
+
+// changeDiskType snapshots the disk, creates a disk of the new type from the snapshot and swaps
+// it in place of the old disk under the same device name
+func (c *Compute) changeDiskType(ctx context.Context, instance *compute.Instance, change DiskTypeChange, record *RollbackRecord,
+	step func(name string, call func() (*compute.Operation, error)) error) error {
+
+	var attached *compute.AttachedDisk
+	for _, d := range instance.Disks {
+		if util.TrimmedString(d.Source, "/") == change.Name {
+			attached = d
+		}
+	}
+	if attached == nil {
+		return fmt.Errorf("disk %s is not attached to %s", change.Name, instance.Name)
+	}
+
+	zone := record.Zone
+	swap := DiskSwap{
+		DeviceName: attached.DeviceName,
+		Boot:       attached.Boot,
+		OldDisk:    change.Name,
+		NewDisk:    ComputeResourceName(fmt.Sprintf("%s-%s", change.Name, change.Type)),
+		Snapshot:   ComputeResourceName(fmt.Sprintf("%s-%d", change.Name, record.StartedAt.Unix())),
+	}
+
+	err := step(fmt.Sprintf("create-snapshot %s", swap.Snapshot), func() (*compute.Operation, error) {
+		return c.computeService.Disks.CreateSnapshot(c.ProjectID, zone, change.Name, &compute.Snapshot{
+			Name: swap.Snapshot,
+		}).Context(ctx).Do()
+	})
+	if err != nil {
+		return err
+	}
+
+	err = step(fmt.Sprintf("create-disk %s", swap.NewDisk), func() (*compute.Operation, error) {
+		return c.computeService.Disks.Insert(c.ProjectID, zone, &compute.Disk{
+			Name:                  swap.NewDisk,
+			Type:                  fmt.Sprintf("zones/%s/diskTypes/%s", zone, change.Type),
+			SizeGb:                change.SizeGb,
+			ProvisionedIops:       change.ProvisionedIops,
+			ProvisionedThroughput: change.ProvisionedThroughput,
+			SourceSnapshot:        fmt.Sprintf("global/snapshots/%s", swap.Snapshot),
+		}).Context(ctx).Do()
+	})
+	if err != nil {
+		return err
+	}
+
+	err = step(fmt.Sprintf("detach-disk %s", change.Name), func() (*compute.Operation, error) {
+		return c.computeService.Instances.DetachDisk(c.ProjectID, zone, instance.Name, attached.DeviceName).Context(ctx).Do()
+	})
+	if err != nil {
+		return err
+	}
+	// from here on the old disk is detached and has to be attached back on rollback
+	record.DiskSwaps = append(record.DiskSwaps, swap)
+
+	err = step(fmt.Sprintf("attach-disk %s", swap.NewDisk), func() (*compute.Operation, error) {
+		return c.computeService.Instances.AttachDisk(c.ProjectID, zone, instance.Name, &compute.AttachedDisk{
+			Source:     fmt.Sprintf("projects/%s/zones/%s/disks/%s", c.ProjectID, zone, swap.NewDisk),
+			DeviceName: attached.DeviceName,
+			Boot:       attached.Boot,
+		}).Context(ctx).Do()
+	})
+	if err != nil {
+		return err
+	}
+	record.DiskSwaps[len(record.DiskSwaps)-1].Attached = true
+	return nil
+}
+
+// changeDiskProvisioning updates the provisioned IOPS and throughput of a disk, keeping the previous values
+// in the record for rollback
+func (c *Compute) changeDiskProvisioning(ctx context.Context, change DiskProvisioningChange, record *RollbackRecord,
+	step func(name string, call func() (*compute.Operation, error)) error) error {
+
+	disk, err := c.computeService.Disks.Get(c.ProjectID, record.Zone, change.Name).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	previous, next := DiskProvisioningChange{Name: change.Name}, DiskProvisioningChange{Name: change.Name}
+	if change.ProvisionedIops != 0 && change.ProvisionedIops != disk.ProvisionedIops {
+		previous.ProvisionedIops, next.ProvisionedIops = disk.ProvisionedIops, change.ProvisionedIops
+	}
+	if change.ProvisionedThroughput != 0 && change.ProvisionedThroughput != disk.ProvisionedThroughput {
+		previous.ProvisionedThroughput, next.ProvisionedThroughput = disk.ProvisionedThroughput, change.ProvisionedThroughput
+	}
+	update, paths := next.update()
+	if len(paths) == 0 {
+		return nil
+	}
+
+	err = step(fmt.Sprintf("update-disk %s", change.Name), func() (*compute.Operation, error) {
+		return c.computeService.Disks.Update(c.ProjectID, record.Zone, change.Name, update).
+			Paths(paths...).Context(ctx).Do()
+	})
+	if err != nil {
+		return err
+	}
+	if !record.DryRun {
+		record.PreviousProvisioning = append(record.PreviousProvisioning, previous)
+	}
+	return nil
+}
+
+// stepper returns the function running a step of the record, the completed steps are added to the record
+// when track is set. Nothing is run in a dry run.
+func (c *Compute) stepper(ctx context.Context, record *RollbackRecord, track bool) func(name string, call func() (*compute.Operation, error)) error {
+	return func(name string, call func() (*compute.Operation, error)) error {
+		if record.DryRun {
+			record.Steps = append(record.Steps, name)
+			return nil
+		}
+		op, err := call()
+		if err != nil {
+			return fmt.Errorf("%s: %s", name, err.Error())
+		}
+		err = c.waitZoneOperation(ctx, record.Zone, op)
+		if err != nil {
+			return fmt.Errorf("%s: %s", name, err.Error())
+		}
+		if track {
+			record.Steps = append(record.Steps, name)
+		}
+		return nil
+	}
+}
+
+// rollback reverts the completed steps of a failed apply: the instance is stopped, its machine type, disks
+// and provisioning are restored and it is started again if it was running
+func (c *Compute) rollback(ctx context.Context, record *RollbackRecord) error {
+	instances := c.computeService.Instances
+	step := c.stepper(ctx, record, false)
+
+	instance, err := instances.Get(c.ProjectID, record.Zone, record.Instance).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	if instance.Status != "TERMINATED" && (record.changedMachineType() || len(record.DiskSwaps) > 0) {
+		err = step("stop", func() (*compute.Operation, error) {
+			return instances.Stop(c.ProjectID, record.Zone, record.Instance).Context(ctx).Do()
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if record.changedMachineType() && util.TrimmedString(instance.MachineType, "/") != record.PreviousMachineType {
+		err = step(fmt.Sprintf("set-machine-type %s", record.PreviousMachineType), func() (*compute.Operation, error) {
+			return instances.SetMachineType(c.ProjectID, record.Zone, record.Instance, &compute.InstancesSetMachineTypeRequest{
+				MachineType: fmt.Sprintf("zones/%s/machineTypes/%s", record.Zone, record.PreviousMachineType),
+			}).Context(ctx).Do()
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, swap := range record.DiskSwaps {
+		if swap.Attached {
+			err = step(fmt.Sprintf("detach-disk %s", swap.NewDisk), func() (*compute.Operation, error) {
+				return instances.DetachDisk(c.ProjectID, record.Zone, record.Instance, swap.DeviceName).Context(ctx).Do()
+			})
+			if err != nil {
+				return err
+			}
+		}
+		err = step(fmt.Sprintf("attach-disk %s", swap.OldDisk), func() (*compute.Operation, error) {
+			return instances.AttachDisk(c.ProjectID, record.Zone, record.Instance, &compute.AttachedDisk{
+				Source:     fmt.Sprintf("projects/%s/zones/%s/disks/%s", c.ProjectID, record.Zone, swap.OldDisk),
+				DeviceName: swap.DeviceName,
+				Boot:       swap.Boot,
+			}).Context(ctx).Do()
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, previous := range record.PreviousProvisioning {
+		update, paths := previous.update()
+		err = step(fmt.Sprintf("update-disk %s", previous.Name), func() (*compute.Operation, error) {
+			return c.computeService.Disks.Update(c.ProjectID, record.Zone, previous.Name, update).
+				Paths(paths...).Context(ctx).Do()
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if record.PreviousStatus != "RUNNING" {
+		return nil
+	}
+	instance, err = instances.Get(c.ProjectID, record.Zone, record.Instance).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	if instance.Status == "RUNNING" {
+		return nil
+	}
+	return step("start", func() (*compute.Operation, error) {
+		return instances.Start(c.ProjectID, record.Zone, record.Instance).Context(ctx).Do()
+	})
+}
+
+// changedMachineType tells whether the machine type was changed by a completed step
+func (r *RollbackRecord) changedMachineType() bool {
+	for _, s := range r.Steps {
+		if strings.HasPrefix(s, "set-machine-type ") {
+			return true
+		}
+	}
+	return false
+}
+
+// update is the disk update setting the non-zero provisioned values of the change
+func (p DiskProvisioningChange) update() (*compute.Disk, []string) {
+	update := &compute.Disk{}
+	var paths []string
+	if p.ProvisionedIops != 0 {
+		update.ProvisionedIops = p.ProvisionedIops
+		paths = append(paths, "provisionedIops")
+	}
+	if p.ProvisionedThroughput != 0 {
+		update.ProvisionedThroughput = p.ProvisionedThroughput
+		paths = append(paths, "provisionedThroughput")
+	}
+	return update, paths
+}
+
+func (c *Compute) waitZoneOperation(ctx context.Context, zone string, op *compute.Operation) error {
+	var err error
+	for op.Status != "DONE" {
+		op, err = c.computeService.ZoneOperations.Wait(c.ProjectID, zone, op.Name).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+	}
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		return fmt.Errorf("%s: %s", op.Error.Errors[0].Code, op.Error.Errors[0].Message)
+	}
+	return nil
+}
+
+// rollbackCommands are the gcloud commands reverting the completed steps of the record
+func rollbackCommands(record *RollbackRecord) []string {
+	if record.DryRun || len(record.Steps) == 0 {
+		return nil
+	}
+	target := fmt.Sprintf("--project=%s --zone=%s", record.Project, record.Zone)
+
+	var commands []string
+	for _, previous := range record.PreviousProvisioning {
+		update := fmt.Sprintf("gcloud compute disks update %s %s", previous.Name, target)
+		if previous.ProvisionedIops != 0 {
+			update += fmt.Sprintf(" --provisioned-iops=%d", previous.ProvisionedIops)
+		}
+		if previous.ProvisionedThroughput != 0 {
+			update += fmt.Sprintf(" --provisioned-throughput=%d", previous.ProvisionedThroughput)
+		}
+		commands = append(commands, update)
+	}
+	if !slices.Contains(record.Steps, "stop") {
+		return commands
+	}
+
+	commands = append(commands, fmt.Sprintf("gcloud compute instances stop %s %s", record.Instance, target))
+	if record.changedMachineType() {
+		commands = append(commands, fmt.Sprintf("gcloud compute instances set-machine-type %s %s --machine-type=%s",
+			record.Instance, target, record.PreviousMachineType))
+	}
+	for _, swap := range record.DiskSwaps {
+		if swap.Attached {
+			commands = append(commands, fmt.Sprintf("gcloud compute instances detach-disk %s %s --device-name=%s",
+				record.Instance, target, swap.DeviceName))
+		}
+		attach := fmt.Sprintf("gcloud compute instances attach-disk %s %s --disk=%s --device-name=%s",
+			record.Instance, target, swap.OldDisk, swap.DeviceName)
+		if swap.Boot {
+			attach += " --boot"
+		}
+		commands = append(commands, attach)
+	}
+	if record.PreviousStatus == "RUNNING" {
+		commands = append(commands, fmt.Sprintf("gcloud compute instances start %s %s", record.Instance, target))
+	}
+	return commands
+}
+
+// ComputeResourceName keeps a generated name within the 63 characters allowed for compute resources
+func ComputeResourceName(name string) string {
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-")
+	}
+	return name
+}
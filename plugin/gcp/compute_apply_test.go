@@ -0,0 +1,269 @@
+package gcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+)
+
+// fakeComputeApi serves the Compute Engine API calls made while applying a rightsizing plan,
+// every mutating call returns an operation that is done when waited on
+type fakeComputeApi struct {
+	lock     sync.Mutex
+	instance *compute.Instance
+	disk     *compute.Disk
+	calls    []string
+	// fail is the call failing once
+	fail string
+}
+
+func (f *fakeComputeApi) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/compute/v1/projects/test-project/zones/us-central1-a/")
+	f.calls = append(f.calls, r.Method+" "+path)
+
+	var response any
+	switch {
+	case f.fail != "" && r.Method+" "+path == f.fail:
+		f.fail = ""
+		http.Error(w, `{"error": {"code": 500, "message": "failed"}}`, http.StatusInternalServerError)
+		return
+	case r.Method == http.MethodGet && path == "instances/vm-1":
+		response = f.instance
+	case strings.HasPrefix(path, "operations/"):
+		response = &compute.Operation{Name: "op", Status: "DONE"}
+	case path == "instances/vm-1/stop":
+		f.instance.Status = "TERMINATED"
+		response = &compute.Operation{Name: "op", Status: "RUNNING"}
+	case path == "instances/vm-1/start":
+		f.instance.Status = "RUNNING"
+		response = &compute.Operation{Name: "op", Status: "RUNNING"}
+	case path == "instances/vm-1/setMachineType":
+		var request compute.InstancesSetMachineTypeRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		f.instance.MachineType = request.MachineType
+		response = &compute.Operation{Name: "op", Status: "RUNNING"}
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "disks/") && f.disk != nil:
+		response = f.disk
+	case r.Method == http.MethodPatch && strings.HasPrefix(path, "disks/") && f.disk != nil:
+		var request compute.Disk
+		json.NewDecoder(r.Body).Decode(&request)
+		if request.ProvisionedIops != 0 {
+			f.disk.ProvisionedIops = request.ProvisionedIops
+		}
+		if request.ProvisionedThroughput != 0 {
+			f.disk.ProvisionedThroughput = request.ProvisionedThroughput
+		}
+		response = &compute.Operation{Name: "op", Status: "RUNNING"}
+	case r.Method == http.MethodPost:
+		response = &compute.Operation{Name: "op", Status: "RUNNING"}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+func newFakeCompute(t *testing.T, instance *compute.Instance) (*Compute, *fakeComputeApi) {
+	fake := &fakeComputeApi{instance: instance}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	computeService, err := compute.NewService(ctx,
+		option.WithEndpoint(server.URL+"/compute/v1/"),
+		option.WithoutAuthentication(),
+	)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+
+	return &Compute{
+		computeService: computeService,
+		GCP:            GCP{ProjectID: "test-project"},
+	}, fake
+}
+
+func testInstance() *compute.Instance {
+	return &compute.Instance{
+		Name:        "vm-1",
+		Status:      "RUNNING",
+		MachineType: "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/machineTypes/n2-standard-8",
+		Disks: []*compute.AttachedDisk{
+			{
+				Source:     "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/disks/vm-1",
+				DeviceName: "persistent-disk-0",
+				Boot:       true,
+			},
+		},
+	}
+}
+
+func TestApplyRightsizing(t *testing.T) {
+	c, fake := newFakeCompute(t, testInstance())
+
+	record, err := c.ApplyRightsizing(ctx, RightsizingPlan{
+		Zone:        "us-central1-a",
+		Instance:    "vm-1",
+		MachineType: "n2-standard-4",
+		Disks:       []DiskTypeChange{{Name: "vm-1", Type: "pd-balanced", SizeGb: 50}},
+	}, false)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+
+	expectedSteps := []string{"stop", "set-machine-type n2-standard-4", "create-snapshot", "create-disk vm-1-pd-balanced",
+		"detach-disk vm-1", "attach-disk vm-1-pd-balanced", "start", "verify"}
+	if len(record.Steps) != len(expectedSteps) {
+		t.Fatalf("[%s]: unexpected steps %v", t.Name(), record.Steps)
+	}
+	for i, s := range expectedSteps {
+		if !strings.HasPrefix(record.Steps[i], s) {
+			t.Errorf("[%s]: step %d is %s, expected %s", t.Name(), i, record.Steps[i], s)
+		}
+	}
+
+	if record.PreviousMachineType != "n2-standard-8" || record.PreviousStatus != "RUNNING" {
+		t.Errorf("[%s]: unexpected previous state %s %s", t.Name(), record.PreviousMachineType, record.PreviousStatus)
+	}
+	if len(record.DiskSwaps) != 1 || record.DiskSwaps[0].DeviceName != "persistent-disk-0" || !record.DiskSwaps[0].Boot {
+		t.Errorf("[%s]: unexpected disk swaps %+v", t.Name(), record.DiskSwaps)
+	}
+	if len(record.RollbackCommands) == 0 ||
+		!strings.Contains(strings.Join(record.RollbackCommands, "\n"), "--machine-type=n2-standard-8") {
+		t.Errorf("[%s]: unexpected rollback commands %v", t.Name(), record.RollbackCommands)
+	}
+
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	if !strings.HasSuffix(fake.instance.MachineType, "n2-standard-4") || fake.instance.Status != "RUNNING" {
+		t.Errorf("[%s]: instance is %s %s", t.Name(), fake.instance.MachineType, fake.instance.Status)
+	}
+}
+
+func TestApplyRightsizingDryRun(t *testing.T) {
+	c, fake := newFakeCompute(t, testInstance())
+
+	record, err := c.ApplyRightsizing(ctx, RightsizingPlan{
+		Zone:        "us-central1-a",
+		Instance:    "vm-1",
+		MachineType: "n2-standard-4",
+	}, true)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+
+	if strings.Join(record.Steps, ",") != "stop,set-machine-type n2-standard-4,start" {
+		t.Errorf("[%s]: unexpected planned steps %v", t.Name(), record.Steps)
+	}
+	if len(record.RollbackCommands) != 0 {
+		t.Errorf("[%s]: dry run should not need a rollback", t.Name())
+	}
+
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	for _, call := range fake.calls {
+		if !strings.HasPrefix(call, http.MethodGet) {
+			t.Errorf("[%s]: dry run made a mutating call %s", t.Name(), call)
+		}
+	}
+}
+
+func TestApplyRightsizingRollback(t *testing.T) {
+	c, fake := newFakeCompute(t, testInstance())
+	fake.fail = "POST instances/vm-1/attachDisk"
+
+	record, err := c.ApplyRightsizing(ctx, RightsizingPlan{
+		Zone:        "us-central1-a",
+		Instance:    "vm-1",
+		MachineType: "n2-standard-4",
+		Disks:       []DiskTypeChange{{Name: "vm-1", Type: "pd-balanced", SizeGb: 50}},
+	}, false)
+	if err == nil || !strings.HasPrefix(err.Error(), "attach-disk vm-1-pd-balanced") {
+		t.Fatalf("[%s]: unexpected error %v", t.Name(), err)
+	}
+
+	if !record.RolledBack || record.RollbackError != "" || len(record.RollbackCommands) != 0 {
+		t.Errorf("[%s]: not rolled back: %s %v", t.Name(), record.RollbackError, record.RollbackCommands)
+	}
+	if len(record.DiskSwaps) != 1 || record.DiskSwaps[0].Attached {
+		t.Errorf("[%s]: unexpected disk swaps %+v", t.Name(), record.DiskSwaps)
+	}
+
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	calls := strings.Join(fake.calls, "\n")
+	expectedCalls := []string{"POST instances/vm-1/detachDisk", "POST instances/vm-1/setMachineType", "POST instances/vm-1/attachDisk",
+		"POST instances/vm-1/start"}
+	for _, call := range expectedCalls {
+		if !strings.Contains(calls, call) {
+			t.Errorf("[%s]: %s was not called", t.Name(), call)
+		}
+	}
+	if strings.Count(calls, "POST instances/vm-1/detachDisk") != 1 {
+		t.Errorf("[%s]: the new disk was detached although it was never attached", t.Name())
+	}
+	if !strings.HasSuffix(fake.instance.MachineType, "n2-standard-8") || fake.instance.Status != "RUNNING" {
+		t.Errorf("[%s]: instance is %s %s", t.Name(), fake.instance.MachineType, fake.instance.Status)
+	}
+}
+
+func TestApplyRightsizingStartsAfterFailedStep(t *testing.T) {
+	c, fake := newFakeCompute(t, testInstance())
+	fake.fail = "POST instances/vm-1/setMachineType"
+
+	record, err := c.ApplyRightsizing(ctx, RightsizingPlan{
+		Zone:        "us-central1-a",
+		Instance:    "vm-1",
+		MachineType: "n2-standard-4",
+	}, false)
+	if err == nil {
+		t.Fatalf("[%s]: expected an error", t.Name())
+	}
+	if !record.RolledBack {
+		t.Errorf("[%s]: not rolled back: %s", t.Name(), record.RollbackError)
+	}
+
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	if fake.instance.Status != "RUNNING" {
+		t.Errorf("[%s]: instance was left %s", t.Name(), fake.instance.Status)
+	}
+}
+
+func TestApplyRightsizingProvisioning(t *testing.T) {
+	c, fake := newFakeCompute(t, testInstance())
+	fake.disk = &compute.Disk{Name: "data-1", ProvisionedIops: 10000, ProvisionedThroughput: 400}
+
+	record, err := c.ApplyRightsizing(ctx, RightsizingPlan{
+		Zone:         "us-central1-a",
+		Instance:     "vm-1",
+		Provisioning: []DiskProvisioningChange{{Name: "data-1", ProvisionedIops: 5000, ProvisionedThroughput: 400}},
+	}, false)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+
+	if strings.Join(record.Steps, ",") != "update-disk data-1" {
+		t.Errorf("[%s]: unexpected steps %v", t.Name(), record.Steps)
+	}
+	if len(record.PreviousProvisioning) != 1 || record.PreviousProvisioning[0] != (DiskProvisioningChange{Name: "data-1", ProvisionedIops: 10000}) {
+		t.Errorf("[%s]: unexpected previous provisioning %+v", t.Name(), record.PreviousProvisioning)
+	}
+	if !strings.Contains(strings.Join(record.RollbackCommands, "\n"), "gcloud compute disks update data-1 --project=test-project --zone=us-central1-a --provisioned-iops=10000") {
+		t.Errorf("[%s]: unexpected rollback commands %v", t.Name(), record.RollbackCommands)
+	}
+
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	if fake.disk.ProvisionedIops != 5000 || fake.disk.ProvisionedThroughput != 400 {
+		t.Errorf("[%s]: disk is provisioned %d IOPS %d MBps", t.Name(), fake.disk.ProvisionedIops, fake.disk.ProvisionedThroughput)
+	}
+}
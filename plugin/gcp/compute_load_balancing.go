@@ -0,0 +1,207 @@
+// Google Cloud Load Balancing resources, served by the Compute Engine API
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/compute/v1"
+
+	util "github.com/opengovern/plugin-gcp/utils"
+)
+
+func (c *Compute) GetAllForwardingRules(ctx context.Context) ([]*compute.ForwardingRule, error) {
+
+	var allRules []*compute.ForwardingRule
+
+	err := c.computeService.ForwardingRules.AggregatedList(c.ProjectID).Pages(ctx, func(list *compute.ForwardingRuleAggregatedList) error {
+		for _, scoped := range list.Items {
+			allRules = append(allRules, scoped.ForwardingRules...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allRules, nil
+}
+
+func (c *Compute) GetAllBackendServices(ctx context.Context) ([]*compute.BackendService, error) {
+
+	var allServices []*compute.BackendService
+
+	err := c.computeService.BackendServices.AggregatedList(c.ProjectID).Pages(ctx, func(list *compute.BackendServiceAggregatedList) error {
+		for _, scoped := range list.Items {
+			allServices = append(allServices, scoped.BackendServices...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allServices, nil
+}
+
+func (c *Compute) GetAllUrlMaps(ctx context.Context) ([]*compute.UrlMap, error) {
+
+	var allUrlMaps []*compute.UrlMap
+
+	err := c.computeService.UrlMaps.AggregatedList(c.ProjectID).Pages(ctx, func(list *compute.UrlMapsAggregatedList) error {
+		for _, scoped := range list.Items {
+			allUrlMaps = append(allUrlMaps, scoped.UrlMaps...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allUrlMaps, nil
+}
+
+// GetAllTargetProxies returns the self link of every target proxy mapped to what it forwards to,
+// which is a url map for http(s) proxies and a backend service for tcp and ssl proxies
+func (c *Compute) GetAllTargetProxies(ctx context.Context) (map[string]string, error) {
+
+	proxies := make(map[string]string)
+
+	err := c.computeService.TargetHttpProxies.AggregatedList(c.ProjectID).Pages(ctx, func(list *compute.TargetHttpProxyAggregatedList) error {
+		for _, scoped := range list.Items {
+			for _, proxy := range scoped.TargetHttpProxies {
+				proxies[proxy.SelfLink] = proxy.UrlMap
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.computeService.TargetHttpsProxies.AggregatedList(c.ProjectID).Pages(ctx, func(list *compute.TargetHttpsProxyAggregatedList) error {
+		for _, scoped := range list.Items {
+			for _, proxy := range scoped.TargetHttpsProxies {
+				proxies[proxy.SelfLink] = proxy.UrlMap
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.computeService.TargetTcpProxies.AggregatedList(c.ProjectID).Pages(ctx, func(list *compute.TargetTcpProxyAggregatedList) error {
+		for _, scoped := range list.Items {
+			for _, proxy := range scoped.TargetTcpProxies {
+				proxies[proxy.SelfLink] = proxy.Service
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.computeService.TargetSslProxies.List(c.ProjectID).Pages(ctx, func(list *compute.TargetSslProxyList) error {
+		for _, proxy := range list.Items {
+			proxies[proxy.SelfLink] = proxy.Service
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proxies, nil
+}
+
+// GetBackendHealth returns the health of the endpoints of a backend group (instance group or NEG) of the backend service
+func (c *Compute) GetBackendHealth(ctx context.Context, backendService *compute.BackendService, group string) ([]*compute.HealthStatus, error) {
+	ref := &compute.ResourceGroupReference{
+		Group: group,
+	}
+
+	var health *compute.BackendServiceGroupHealth
+	var err error
+	if backendService.Region == "" {
+		health, err = c.computeService.BackendServices.GetHealth(c.ProjectID, backendService.Name, ref).Context(ctx).Do()
+	} else {
+		region := util.TrimmedString(backendService.Region, "/")
+		health, err = c.computeService.RegionBackendServices.GetHealth(c.ProjectID, region, backendService.Name, ref).Context(ctx).Do()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return health.HealthStatus, nil
+}
+
+// GetAllTargetPools returns the target pools of network load balancers, which forward to instances directly
+func (c *Compute) GetAllTargetPools(ctx context.Context) ([]*compute.TargetPool, error) {
+
+	var allPools []*compute.TargetPool
+
+	err := c.computeService.TargetPools.AggregatedList(c.ProjectID).Pages(ctx, func(list *compute.TargetPoolAggregatedList) error {
+		for _, scoped := range list.Items {
+			allPools = append(allPools, scoped.TargetPools...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allPools, nil
+}
+
+// GetAllTargetInstances returns the target instances forwarding rules can send their traffic to
+func (c *Compute) GetAllTargetInstances(ctx context.Context) ([]*compute.TargetInstance, error) {
+
+	var allTargets []*compute.TargetInstance
+
+	err := c.computeService.TargetInstances.AggregatedList(c.ProjectID).Pages(ctx, func(list *compute.TargetInstanceAggregatedList) error {
+		for _, scoped := range list.Items {
+			allTargets = append(allTargets, scoped.TargetInstances...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allTargets, nil
+}
+
+// GetTargetPoolHealth returns the health of an instance of the target pool
+func (c *Compute) GetTargetPoolHealth(ctx context.Context, pool *compute.TargetPool, instance string) ([]*compute.HealthStatus, error) {
+	ref := &compute.InstanceReference{
+		Instance: instance,
+	}
+
+	region := util.TrimmedString(pool.Region, "/")
+	health, err := c.computeService.TargetPools.GetHealth(c.ProjectID, region, pool.Name, ref).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return health.HealthStatus, nil
+}
+
+// GetInstanceStatus returns the status (e.g. RUNNING) of the instance of a target instance, which has no health check
+func (c *Compute) GetInstanceStatus(ctx context.Context, instance string) (string, error) {
+	// instance is in form of .../projects/{project}/zones/{zone}/instances/{instance}
+	parts := strings.Split(instance, "/")
+	if len(parts) < 4 {
+		return "", fmt.Errorf("invalid instance %s", instance)
+	}
+
+	i, err := c.computeService.Instances.Get(c.ProjectID, parts[len(parts)-3], parts[len(parts)-1]).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+
+	return i.Status, nil
+}
@@ -86,3 +86,29 @@ func TestGetMemory(t *testing.T) {
 
 	log.Printf("Memory : %d", &memory)
 }
+
+func TestGetAllDisks(t *testing.T) {
+
+	log.Printf("running %s", t.Name())
+	compute := NewCompute(
+		[]string{
+			"https://www.googleapis.com/auth/compute.readonly",
+		},
+	)
+	err := compute.InitializeClient(ctx)
+	if err != nil {
+		t.Errorf("[%s]: %s", t.Name(), err.Error())
+		return
+	}
+	defer compute.CloseClient()
+
+	disks, err := compute.GetAllDisks(ctx)
+	if err != nil {
+		t.Errorf("[%s]: %s", t.Name(), err.Error())
+		return
+	}
+
+	for selfLink, disk := range disks {
+		log.Printf("%s: %s %d GB", selfLink, disk.Type, disk.SizeGb)
+	}
+}
@@ -3,8 +3,11 @@ package gcp
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
 )
 
 type GCP struct {
@@ -38,6 +41,20 @@ func (g *GCP) GetCredentials(ctx context.Context) error {
 	return nil
 }
 
+// clientOption authenticates the REST client of the api with the credentials, its requests share the
+// rate limit of the api and throttled ones are retried
+func (g *GCP) clientOption(api string) option.ClientOption {
+	return option.WithHTTPClient(&http.Client{
+		Transport: &rateLimitedTransport{
+			api: api,
+			base: &oauth2.Transport{
+				Source: g.credentials.TokenSource,
+				Base:   http.DefaultTransport,
+			},
+		},
+	})
+}
+
 func (g *GCP) Identify() map[string]string {
 
 	identification := map[string]string{
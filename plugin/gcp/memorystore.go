@@ -0,0 +1,63 @@
+// Google Cloud Memorystore for Redis Instances
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/redis/v1"
+)
+
+type Memorystore struct {
+	redisService *redis.Service
+	GCP
+}
+
+func NewMemorystore(scopes []string) *Memorystore {
+	return &Memorystore{
+		GCP: NewGCP(scopes),
+	}
+}
+
+func (m *Memorystore) InitializeClient(ctx context.Context) error {
+
+	err := m.GCP.GetCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	redisService, err := redis.NewService(
+		ctx,
+		m.GCP.clientOption(RedisAPI),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.redisService = redisService
+
+	return nil
+}
+
+func (m *Memorystore) CloseClient() error {
+	return nil
+}
+
+// GetAllInstances lists the redis instances of the project across all locations
+func (m *Memorystore) GetAllInstances(ctx context.Context) ([]*redis.Instance, error) {
+
+	var allInstances []*redis.Instance
+
+	parent := fmt.Sprintf("projects/%s/locations/-", m.ProjectID)
+
+	err := m.redisService.Projects.Locations.Instances.List(parent).Pages(ctx, func(resp *redis.ListInstancesResponse) error {
+		allInstances = append(allInstances, resp.Instances...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allInstances, nil
+}
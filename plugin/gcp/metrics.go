@@ -5,6 +5,8 @@ import (
 	"fmt"
 	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
 	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
@@ -13,6 +15,8 @@ import (
 
 type CloudMonitoring struct {
 	client *monitoring.MetricClient
+	// Cache keeps the fetched time series on disk, every time series is fetched in full when it is nil
+	Cache *MetricsCache
 	GCP
 }
 
@@ -28,7 +32,10 @@ func (c *CloudMonitoring) InitializeClient(ctx context.Context) error {
 		return err
 	}
 
-	metricClient, err := monitoring.NewMetricClient(ctx)
+	metricClient, err := monitoring.NewMetricClient(
+		ctx,
+		option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(rateLimitInterceptor(MonitoringAPI))),
+	)
 	if err != nil {
 		return err
 	}
@@ -63,6 +70,15 @@ func (c *CloudMonitoring) NewTimeSeriesRequest(
 }
 
 func (c *CloudMonitoring) GetMetric(ctx context.Context, request *monitoringpb.ListTimeSeriesRequest) ([]*golang2.DataPoint, error) {
+	if c.Cache != nil {
+		return c.Cache.Get(request, func(request *monitoringpb.ListTimeSeriesRequest) ([]*golang2.DataPoint, error) {
+			return c.listTimeSeries(ctx, request)
+		})
+	}
+	return c.listTimeSeries(ctx, request)
+}
+
+func (c *CloudMonitoring) listTimeSeries(ctx context.Context, request *monitoringpb.ListTimeSeriesRequest) ([]*golang2.DataPoint, error) {
 	var dps []*golang2.DataPoint
 
 	it := c.client.ListTimeSeries(ctx, request)
@@ -83,6 +99,38 @@ func (c *CloudMonitoring) GetMetric(ctx context.Context, request *monitoringpb.L
 
 }
 
+// GetMetricByLabel returns the datapoints of the requested time series grouped by the value of the given metric label
+func (c *CloudMonitoring) GetMetricByLabel(ctx context.Context, request *monitoringpb.ListTimeSeriesRequest, label string) (map[string][]*golang2.DataPoint, error) {
+	if c.Cache != nil {
+		return c.Cache.GetByLabel(request, label, func(request *monitoringpb.ListTimeSeriesRequest) (map[string][]*golang2.DataPoint, error) {
+			return c.listTimeSeriesByLabel(ctx, request, label)
+		})
+	}
+	return c.listTimeSeriesByLabel(ctx, request, label)
+}
+
+func (c *CloudMonitoring) listTimeSeriesByLabel(ctx context.Context, request *monitoringpb.ListTimeSeriesRequest, label string) (map[string][]*golang2.DataPoint, error) {
+	dps := make(map[string][]*golang2.DataPoint)
+
+	it := c.client.ListTimeSeries(ctx, request)
+	for {
+		resp, err := it.Next()
+
+		if err != nil {
+			if err == iterator.Done {
+				break
+			} else {
+				return nil, err
+			}
+		}
+		value := resp.GetMetric().GetLabels()[label]
+		dps[value] = append(dps[value], convertDatapoints(resp)...)
+	}
+
+	return dps, nil
+
+}
+
 func convertDatapoints(resp *monitoringpb.TimeSeries) []*golang2.DataPoint {
 	var dps []*golang2.DataPoint
 	for _, dp := range resp.GetPoints() {
@@ -0,0 +1,273 @@
+// On-disk cache of Cloud Monitoring time series, so repeated scans only fetch the datapoints
+// added since the previous scan
+
+package gcp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// DefaultMetricsCacheTTL is how long cached datapoints are reused before the whole series is fetched again
+const DefaultMetricsCacheTTL = 7 * 24 * time.Hour
+
+// metricsCacheOverlap is the least of the cached tail fetched again, the latest datapoints may still change
+// as Cloud Monitoring ingests late samples. At least metricsCacheOverlapPeriods alignment periods are fetched again.
+const (
+	metricsCacheOverlap        = 10 * time.Minute
+	metricsCacheOverlapPeriods = 3
+)
+
+type MetricsCache struct {
+	Dir string
+	TTL time.Duration
+
+	lock sync.Mutex
+}
+
+// NewMetricsCache caches the time series in dir, in the user's cache directory when dir is empty
+func NewMetricsCache(dir string, ttl time.Duration) (*MetricsCache, error) {
+	if dir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(cacheDir, "kaytu", "plugin-gcp", "metrics")
+	}
+	if ttl <= 0 {
+		ttl = DefaultMetricsCacheTTL
+	}
+	return &MetricsCache{
+		Dir: dir,
+		TTL: ttl,
+	}, nil
+}
+
+type cachedDataPoint struct {
+	// Label is the value of the metric label the datapoints are grouped by
+	Label     string  `json:"label,omitempty"`
+	StartTime int64   `json:"start_time"`
+	EndTime   int64   `json:"end_time"`
+	Value     float64 `json:"value"`
+}
+
+// metricsCacheEntry is a time series, identified by the project, the filter (metric type and resource id),
+// the aggregation interval of its request and the metric label its datapoints are grouped by
+type metricsCacheEntry struct {
+	Project     string            `json:"project"`
+	Filter      string            `json:"filter"`
+	Aggregation string            `json:"aggregation"`
+	Label       string            `json:"label,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	EndTime     int64             `json:"end_time"`
+	Points      []cachedDataPoint `json:"points"`
+}
+
+func (c *MetricsCache) path(request *monitoringpb.ListTimeSeriesRequest, label string) string {
+	key := request.GetFilter() + "\n" + aggregationKey(request)
+	if label != "" {
+		key += "\nlabel=" + label
+	}
+	hash := sha256.Sum256([]byte(key))
+	project := strings.TrimPrefix(request.GetName(), "projects/")
+	return filepath.Join(c.Dir, project, hex.EncodeToString(hash[:])+".json")
+}
+
+func aggregationKey(request *monitoringpb.ListTimeSeriesRequest) string {
+	if request.GetAggregation() == nil {
+		return ""
+	}
+	return prototext.MarshalOptions{}.Format(request.GetAggregation())
+}
+
+// load returns the cached series of the request, nil when it is not cached or has expired
+func (c *MetricsCache) load(request *monitoringpb.ListTimeSeriesRequest, label string) *metricsCacheEntry {
+	content, err := os.ReadFile(c.path(request, label))
+	if err != nil {
+		return nil
+	}
+	var entry metricsCacheEntry
+	err = json.Unmarshal(content, &entry)
+	if err != nil || time.Since(entry.CreatedAt) > c.TTL ||
+		entry.Filter != request.GetFilter() || entry.Aggregation != aggregationKey(request) || entry.Label != label {
+		return nil
+	}
+	return &entry
+}
+
+func (c *MetricsCache) store(entry *metricsCacheEntry, request *monitoringpb.ListTimeSeriesRequest) error {
+	path := c.path(request, entry.Label)
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	// write and rename so a concurrent reader never sees a partial file
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, content, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Get returns the datapoints of the request from the cache, fetching only the part of the interval that
+// is not cached yet along with an overlap of the cached tail. The interval is aligned to the alignment period
+// so the aligned datapoints of every scan fall on the same times, and the datapoints outside of it are dropped
+// from the cache.
+func (c *MetricsCache) Get(request *monitoringpb.ListTimeSeriesRequest,
+	fetch func(request *monitoringpb.ListTimeSeriesRequest) ([]*golang2.DataPoint, error)) ([]*golang2.DataPoint, error) {
+	points, err := c.get(request, "", func(request *monitoringpb.ListTimeSeriesRequest) ([]cachedDataPoint, error) {
+		dps, err := fetch(request)
+		if err != nil {
+			return nil, err
+		}
+		return cachedDataPoints("", dps), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	dps := make([]*golang2.DataPoint, 0, len(points))
+	for _, p := range points {
+		dps = append(dps, p.dataPoint())
+	}
+	return dps, nil
+}
+
+// GetByLabel is Get for the datapoints of the request grouped by the value of the metric label, they are
+// cached apart from the ungrouped datapoints of the same request
+func (c *MetricsCache) GetByLabel(request *monitoringpb.ListTimeSeriesRequest, label string,
+	fetch func(request *monitoringpb.ListTimeSeriesRequest) (map[string][]*golang2.DataPoint, error)) (map[string][]*golang2.DataPoint, error) {
+	points, err := c.get(request, label, func(request *monitoringpb.ListTimeSeriesRequest) ([]cachedDataPoint, error) {
+		grouped, err := fetch(request)
+		if err != nil {
+			return nil, err
+		}
+		var points []cachedDataPoint
+		for value, dps := range grouped {
+			points = append(points, cachedDataPoints(value, dps)...)
+		}
+		return points, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	grouped := make(map[string][]*golang2.DataPoint)
+	for _, p := range points {
+		grouped[p.Label] = append(grouped[p.Label], p.dataPoint())
+	}
+	return grouped, nil
+}
+
+func cachedDataPoints(label string, dps []*golang2.DataPoint) []cachedDataPoint {
+	points := make([]cachedDataPoint, 0, len(dps))
+	for _, dp := range dps {
+		points = append(points, cachedDataPoint{
+			Label:     label,
+			StartTime: dp.GetStartTime().GetValue(),
+			EndTime:   dp.GetEndTime().GetValue(),
+			Value:     dp.GetValue(),
+		})
+	}
+	return points
+}
+
+func (p cachedDataPoint) dataPoint() *golang2.DataPoint {
+	return &golang2.DataPoint{
+		StartTime: wrapperspb.Int64(p.StartTime),
+		EndTime:   wrapperspb.Int64(p.EndTime),
+		Value:     p.Value,
+	}
+}
+
+// get is Get for the cached datapoints of the request grouped by the label, ungrouped without a label
+func (c *MetricsCache) get(request *monitoringpb.ListTimeSeriesRequest, label string,
+	fetch func(request *monitoringpb.ListTimeSeriesRequest) ([]cachedDataPoint, error)) ([]cachedDataPoint, error) {
+
+	period := request.GetAggregation().GetAlignmentPeriod().GetSeconds()
+	align := func(t int64) int64 {
+		if period <= 0 {
+			return t
+		}
+		return t - t%period
+	}
+	start, end := align(request.GetInterval().GetStartTime().GetSeconds()), align(request.GetInterval().GetEndTime().GetSeconds())
+
+	fetchRequest := proto.Clone(request).(*monitoringpb.ListTimeSeriesRequest)
+	fetchRequest.Interval.StartTime = timestamppb.New(time.Unix(start, 0))
+	fetchRequest.Interval.EndTime = timestamppb.New(time.Unix(end, 0))
+
+	c.lock.Lock()
+	entry := c.load(request, label)
+	c.lock.Unlock()
+
+	if entry != nil && entry.EndTime > start && entry.EndTime <= end {
+		overlap := max(int64(metricsCacheOverlap.Seconds()), metricsCacheOverlapPeriods*period)
+		fetchRequest.Interval.StartTime = timestamppb.New(time.Unix(max(align(entry.EndTime-overlap), start), 0))
+	} else {
+		entry = &metricsCacheEntry{
+			Project:     strings.TrimPrefix(request.GetName(), "projects/"),
+			Filter:      request.GetFilter(),
+			Aggregation: aggregationKey(request),
+			Label:       label,
+			CreatedAt:   time.Now(),
+		}
+	}
+
+	var fetched []cachedDataPoint
+	if fetchRequest.GetInterval().GetStartTime().GetSeconds() < end {
+		var err error
+		fetched, err = fetch(fetchRequest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the cached datapoints before the requested interval are dropped and the fetched ones replace the cached
+	// ones of the same start time. The request may cover several time series so fetched datapoints of the same
+	// time are all kept.
+	fetchStart := fetchRequest.GetInterval().GetStartTime().GetSeconds()
+	fetchedStarts := make(map[int64]bool)
+	for _, p := range fetched {
+		fetchedStarts[p.StartTime] = true
+	}
+	var points []cachedDataPoint
+	for _, p := range entry.Points {
+		if p.StartTime >= start && p.EndTime <= fetchStart && !fetchedStarts[p.StartTime] {
+			points = append(points, p)
+		}
+	}
+	points = append(points, fetched...)
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].StartTime < points[j].StartTime
+	})
+	entry.Points = points
+	entry.EndTime = end
+
+	c.lock.Lock()
+	err := c.store(entry, request)
+	c.lock.Unlock()
+	if err != nil {
+		// the datapoints are fetched again on the next scan
+		log.Printf("failed to cache metrics: %s", err.Error())
+	}
+	return entry.Points, nil
+}
@@ -0,0 +1,200 @@
+package gcp
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// fakeSeries returns a datapoint per minute of the requested interval and counts the fetched datapoints,
+// the values are offset by revision to tell the fetches apart
+type fakeSeries struct {
+	fetched  int
+	revision float64
+}
+
+func (f *fakeSeries) fetch(request *monitoringpb.ListTimeSeriesRequest) ([]*golang2.DataPoint, error) {
+	var dps []*golang2.DataPoint
+	start, end := request.GetInterval().GetStartTime().GetSeconds(), request.GetInterval().GetEndTime().GetSeconds()
+	for t := start; t+60 <= end; t += 60 {
+		dps = append(dps, &golang2.DataPoint{
+			StartTime: wrapperspb.Int64(t),
+			EndTime:   wrapperspb.Int64(t + 60),
+			Value:     float64(t) + f.revision,
+		})
+	}
+	f.fetched += len(dps)
+	return dps, nil
+}
+
+func cacheRequest(start, end int64) *monitoringpb.ListTimeSeriesRequest {
+	return &monitoringpb.ListTimeSeriesRequest{
+		Name:   "projects/test-project",
+		Filter: `metric.type="compute.googleapis.com/instance/cpu/utilization" AND resource.labels.instance_id="1"`,
+		Interval: &monitoringpb.TimeInterval{
+			StartTime: timestamppb.New(time.Unix(start, 0)),
+			EndTime:   timestamppb.New(time.Unix(end, 0)),
+		},
+		Aggregation: &monitoringpb.Aggregation{
+			AlignmentPeriod:  &durationpb.Duration{Seconds: 60},
+			PerSeriesAligner: monitoringpb.Aggregation_ALIGN_MEAN,
+		},
+	}
+}
+
+func TestMetricsCache(t *testing.T) {
+	cache, err := NewMetricsCache(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	series := &fakeSeries{}
+
+	dps, err := cache.Get(cacheRequest(0, 3600), series.fetch)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	if len(dps) != 60 || series.fetched != 60 {
+		t.Fatalf("[%s]: got %d datapoints, fetched %d", t.Name(), len(dps), series.fetched)
+	}
+
+	// the interval moved by 2 minutes, only the tail and the 10 minutes before it are fetched and the head is dropped
+	series.revision = 0.5
+	dps, err = cache.Get(cacheRequest(120, 3720), series.fetch)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	if len(dps) != 60 || series.fetched != 72 {
+		t.Fatalf("[%s]: got %d datapoints, fetched %d", t.Name(), len(dps), series.fetched)
+	}
+	if dps[0].GetStartTime().GetValue() != 120 || dps[59].GetStartTime().GetValue() != 3660 {
+		t.Errorf("[%s]: unexpected interval %d - %d", t.Name(), dps[0].GetStartTime().GetValue(), dps[59].GetStartTime().GetValue())
+	}
+	// the overlap replaced the cached datapoints, without duplicates
+	for i, dp := range dps {
+		revision := 0.0
+		if dp.GetStartTime().GetValue() >= 3000 {
+			revision = 0.5
+		}
+		if dp.GetStartTime().GetValue() != int64(120+60*i) || dp.GetValue() != float64(dp.GetStartTime().GetValue())+revision {
+			t.Fatalf("[%s]: unexpected datapoint %d at %d: %v", t.Name(), i, dp.GetStartTime().GetValue(), dp.GetValue())
+		}
+	}
+
+	// a different aggregation is a different series
+	request := cacheRequest(120, 3720)
+	request.Aggregation.PerSeriesAligner = monitoringpb.Aggregation_ALIGN_MAX
+	_, err = cache.Get(request, series.fetch)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	if series.fetched != 132 {
+		t.Errorf("[%s]: fetched %d datapoints, expected the whole series", t.Name(), series.fetched)
+	}
+}
+
+func TestMetricsCacheAlignment(t *testing.T) {
+	cache, err := NewMetricsCache(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	series := &fakeSeries{}
+
+	// the interval is aligned to the minute alignment period
+	dps, err := cache.Get(cacheRequest(30, 3630), series.fetch)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	if len(dps) != 60 || dps[0].GetStartTime().GetValue() != 0 || dps[59].GetEndTime().GetValue() != 3600 {
+		t.Fatalf("[%s]: got %d datapoints from %d", t.Name(), len(dps), dps[0].GetStartTime().GetValue())
+	}
+
+	// the overlap of a longer alignment period is a few periods
+	request := cacheRequest(0, 36000)
+	request.Aggregation.AlignmentPeriod = &durationpb.Duration{Seconds: 3600}
+	var fetchStarts []int64
+	fetch := func(request *monitoringpb.ListTimeSeriesRequest) ([]*golang2.DataPoint, error) {
+		fetchStarts = append(fetchStarts, request.GetInterval().GetStartTime().GetSeconds())
+		return series.fetch(request)
+	}
+	_, err = cache.Get(request, fetch)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	request.Interval.EndTime = timestamppb.New(time.Unix(36000+3600+1800, 0))
+	_, err = cache.Get(request, fetch)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	if len(fetchStarts) != 2 || fetchStarts[1] != 36000-3*3600 {
+		t.Errorf("[%s]: fetched from %v", t.Name(), fetchStarts)
+	}
+}
+
+func TestMetricsCacheTTL(t *testing.T) {
+	cache, err := NewMetricsCache(t.TempDir(), time.Nanosecond)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	series := &fakeSeries{}
+
+	for i := 0; i < 2; i++ {
+		_, err = cache.Get(cacheRequest(0, 600), series.fetch)
+		if err != nil {
+			t.Fatalf("[%s]: %s", t.Name(), err.Error())
+		}
+	}
+	if series.fetched != 20 {
+		t.Errorf("[%s]: fetched %d datapoints, the expired series should be fetched again", t.Name(), series.fetched)
+	}
+}
+
+func TestMetricsCacheByLabel(t *testing.T) {
+	cache, err := NewMetricsCache(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	standard, nearline := &fakeSeries{}, &fakeSeries{revision: 0.5}
+	fetch := func(request *monitoringpb.ListTimeSeriesRequest) (map[string][]*golang2.DataPoint, error) {
+		grouped := make(map[string][]*golang2.DataPoint)
+		for class, series := range map[string]*fakeSeries{"STANDARD": standard, "NEARLINE": nearline} {
+			dps, err := series.fetch(request)
+			if err != nil {
+				return nil, err
+			}
+			grouped[class] = dps
+		}
+		return grouped, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		grouped, err := cache.GetByLabel(cacheRequest(0, 3600), "storage_class", fetch)
+		if err != nil {
+			t.Fatalf("[%s]: %s", t.Name(), err.Error())
+		}
+		if len(grouped) != 2 || len(grouped["STANDARD"]) != 60 || len(grouped["NEARLINE"]) != 60 {
+			t.Fatalf("[%s]: unexpected datapoints %d %d", t.Name(), len(grouped["STANDARD"]), len(grouped["NEARLINE"]))
+		}
+		if dp := grouped["NEARLINE"][59]; dp.GetStartTime().GetValue() != 3540 || dp.GetValue() != 3540.5 {
+			t.Errorf("[%s]: unexpected datapoint at %d: %v", t.Name(), dp.GetStartTime().GetValue(), dp.GetValue())
+		}
+	}
+	// the second call is served from the cache, only the overlap of the tail is fetched again
+	if standard.fetched != 70 || nearline.fetched != 70 {
+		t.Errorf("[%s]: fetched %d and %d datapoints", t.Name(), standard.fetched, nearline.fetched)
+	}
+
+	// the ungrouped datapoints of the request are cached apart
+	series := &fakeSeries{}
+	_, err = cache.Get(cacheRequest(0, 3600), series.fetch)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	if series.fetched != 60 {
+		t.Errorf("[%s]: fetched %d datapoints, expected the whole series", t.Name(), series.fetched)
+	}
+}
@@ -0,0 +1,132 @@
+// Compute Engine list prices, read from the Cloud Billing Catalog API
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/cloudbilling/v1"
+)
+
+// ComputeEngineService is the Cloud Billing Catalog id of Compute Engine
+const ComputeEngineService = "services/6F81-5844-456A"
+
+// Usage types of the Catalog SKUs
+const (
+	UsageOnDemand    = "OnDemand"
+	UsageCommit1Yr   = "Commit1Yr"
+	UsageCommit3Yr   = "Commit3Yr"
+	UsagePreemptible = "Preemptible"
+)
+
+type Pricing struct {
+	billingService *cloudbilling.APIService
+	GCP
+
+	// the Compute Engine SKUs are listed once, the first time a price is needed
+	once sync.Once
+	skus []*cloudbilling.Sku
+	err  error
+}
+
+func NewPricing(scopes []string) *Pricing {
+	return &Pricing{
+		GCP: NewGCP(scopes),
+	}
+}
+
+func (p *Pricing) InitializeClient(ctx context.Context) error {
+
+	err := p.GCP.GetCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	billingService, err := cloudbilling.NewService(
+		ctx,
+		p.GCP.clientOption(CatalogAPI),
+	)
+	if err != nil {
+		return err
+	}
+
+	p.billingService = billingService
+
+	return nil
+}
+
+func (p *Pricing) CloseClient() error {
+	return nil
+}
+
+func (p *Pricing) computeSkus(ctx context.Context) ([]*cloudbilling.Sku, error) {
+	p.once.Do(func() {
+		p.err = p.billingService.Services.Skus.List(ComputeEngineService).CurrencyCode("USD").PageSize(5000).
+			Pages(ctx, func(resp *cloudbilling.ListSkusResponse) error {
+				p.skus = append(p.skus, resp.Skus...)
+				return nil
+			})
+	})
+	return p.skus, p.err
+}
+
+// HourlyPrice returns the USD list price per hour of a Compute Engine SKU whose description starts with the
+// prefix (e.g. "N2 Instance Core running in" or "Commitment v1: N2 Ram in"), for the usage type in the region.
+// Sole tenancy SKUs are never matched. The prices are per vCPU or per GB of memory for instance cores and ram.
+func (p *Pricing) HourlyPrice(ctx context.Context, prefix, usageType, region string) (float64, error) {
+	if p == nil {
+		return 0, fmt.Errorf("no pricing client")
+	}
+	skus, err := p.computeSkus(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, sku := range skus {
+		if !strings.HasPrefix(sku.Description, prefix) || strings.Contains(sku.Description, "Sole Tenancy") {
+			continue
+		}
+		if sku.Category == nil || sku.Category.UsageType != usageType || !containsRegion(sku.ServiceRegions, region) {
+			continue
+		}
+		if price, ok := skuHourlyPrice(sku); ok {
+			return price, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no %s price for %q in %s", usageType, prefix, region)
+}
+
+func containsRegion(regions []string, region string) bool {
+	for _, r := range regions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+// skuHourlyPrice returns the price of the last tier of the current pricing of the SKU, in USD per hour. Core
+// and ram SKUs are priced per hour, or per month for commitments.
+func skuHourlyPrice(sku *cloudbilling.Sku) (float64, bool) {
+	if len(sku.PricingInfo) == 0 {
+		return 0, false
+	}
+	expression := sku.PricingInfo[len(sku.PricingInfo)-1].PricingExpression
+	if expression == nil || len(expression.TieredRates) == 0 {
+		return 0, false
+	}
+	rate := expression.TieredRates[len(expression.TieredRates)-1]
+	if rate.UnitPrice == nil {
+		return 0, false
+	}
+	price := float64(rate.UnitPrice.Units) + float64(rate.UnitPrice.Nanos)/1e9
+	switch {
+	case strings.HasPrefix(expression.UsageUnit, "GiBy.mo"), expression.UsageUnit == "mo":
+		price /= 730
+	}
+	return price, true
+}
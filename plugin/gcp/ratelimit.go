@@ -0,0 +1,330 @@
+// Rate limiting and retries of the GCP API calls, shared by all the clients of an API
+
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// APIs the calls are limited and counted by
+const (
+	ComputeAPI     = "compute"
+	MonitoringAPI  = "monitoring"
+	RecommenderAPI = "recommender"
+	BigQueryAPI    = "bigquery"
+	StorageAPI     = "storage"
+	RedisAPI       = "redis"
+	RunAPI         = "run"
+	FunctionsAPI   = "cloudfunctions"
+	CatalogAPI     = "cloudbilling"
+)
+
+// RateLimits are the requests per second allowed per API and the retries of throttled calls
+type RateLimits struct {
+	// Rates are the requests per second of the APIs, the APIs not listed use DefaultRate
+	Rates       map[string]float64
+	DefaultRate float64
+	MaxRetries  int
+	// BaseDelay is the delay before the first retry, doubled on every retry up to MaxDelay
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+var DefaultRateLimits = RateLimits{
+	Rates: map[string]float64{
+		MonitoringAPI: 50,
+	},
+	DefaultRate: 20,
+	MaxRetries:  5,
+	BaseDelay:   time.Second,
+	MaxDelay:    time.Minute,
+}
+
+// ParseRateLimits reads the rates as a default rate and/or api=rate pairs separated by commas,
+// e.g. "10" or "compute=10,monitoring=40"
+func ParseRateLimits(rates, maxRetries string) (RateLimits, error) {
+	limits := DefaultRateLimits
+	limits.Rates = make(map[string]float64)
+	for api, r := range DefaultRateLimits.Rates {
+		limits.Rates[api] = r
+	}
+
+	for _, part := range strings.Split(rates, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		api, value, found := strings.Cut(part, "=")
+		if !found {
+			api, value = "", part
+		}
+		r, err := strconv.ParseFloat(value, 64)
+		if err != nil || r <= 0 {
+			return limits, fmt.Errorf("invalid rate limit %s", part)
+		}
+		if api == "" {
+			limits.DefaultRate = r
+			limits.Rates = make(map[string]float64)
+		} else {
+			limits.Rates[api] = r
+		}
+	}
+
+	if maxRetries != "" {
+		retries, err := strconv.Atoi(maxRetries)
+		if err != nil || retries < 0 {
+			return limits, fmt.Errorf("invalid max retries %s", maxRetries)
+		}
+		limits.MaxRetries = retries
+	}
+	return limits, nil
+}
+
+// apiLimiter limits the calls of an API and counts the throttled ones
+type apiLimiter struct {
+	api     string
+	limits  RateLimits
+	limiter *rate.Limiter
+
+	calls     atomic.Int64
+	throttled atomic.Int64
+	retries   atomic.Int64
+	failed    atomic.Int64
+	waited    atomic.Int64
+}
+
+var rateLimiters = struct {
+	lock     sync.Mutex
+	limits   RateLimits
+	limiters map[string]*apiLimiter
+}{
+	limits:   DefaultRateLimits,
+	limiters: make(map[string]*apiLimiter),
+}
+
+// ConfigureRateLimits sets the rate limits of the APIs, it has to be called before the clients are created
+func ConfigureRateLimits(limits RateLimits) {
+	rateLimiters.lock.Lock()
+	defer rateLimiters.lock.Unlock()
+
+	rateLimiters.limits = limits
+	rateLimiters.limiters = make(map[string]*apiLimiter)
+}
+
+func limiterOf(api string) *apiLimiter {
+	rateLimiters.lock.Lock()
+	defer rateLimiters.lock.Unlock()
+
+	l, ok := rateLimiters.limiters[api]
+	if !ok {
+		r, ok := rateLimiters.limits.Rates[api]
+		if !ok {
+			r = rateLimiters.limits.DefaultRate
+		}
+		l = &apiLimiter{
+			api:     api,
+			limits:  rateLimiters.limits,
+			limiter: rate.NewLimiter(rate.Limit(r), max(int(r), 1)),
+		}
+		rateLimiters.limiters[api] = l
+	}
+	return l
+}
+
+// backoff is the delay before the retry, exponential with full jitter and never shorter than retryAfter
+func (l *apiLimiter) backoff(retry int, retryAfter time.Duration) time.Duration {
+	delay := l.limits.BaseDelay << retry
+	if delay <= 0 || delay > l.limits.MaxDelay {
+		delay = l.limits.MaxDelay
+	}
+	delay = time.Duration(rand.Int63n(int64(delay) + 1))
+	return max(delay, retryAfter)
+}
+
+// do runs the call within the rate limit, retrying it while it is throttled. The call returns whether
+// it was throttled and how long the API asked to wait before retrying.
+func (l *apiLimiter) do(ctx context.Context, call func() (bool, time.Duration, error)) error {
+	for retry := 0; ; retry++ {
+		start := time.Now()
+		err := l.limiter.Wait(ctx)
+		if err != nil {
+			return err
+		}
+		l.waited.Add(int64(time.Since(start)))
+		l.calls.Add(1)
+
+		throttled, retryAfter, err := call()
+		if !throttled {
+			return err
+		}
+		l.throttled.Add(1)
+		if retry >= l.limits.MaxRetries {
+			l.failed.Add(1)
+			return err
+		}
+
+		l.retries.Add(1)
+		timer := time.NewTimer(l.backoff(retry, retryAfter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// rateLimitedTransport limits the HTTP requests of a REST API and retries the throttled ones
+type rateLimitedTransport struct {
+	api  string
+	base http.RoundTripper
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	l := limiterOf(t.api)
+
+	var resp *http.Response
+	err := l.do(req.Context(), func() (bool, time.Duration, error) {
+		// the throttled response of the previous attempt is only returned when the retries are exhausted
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		attempt := req
+		if req.Body != nil && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return false, 0, err
+			}
+			attempt = req.Clone(req.Context())
+			attempt.Body = body
+		}
+
+		var err error
+		resp, err = t.base.RoundTrip(attempt)
+		if err != nil {
+			return false, 0, err
+		}
+		if !throttledResponse(resp) {
+			return false, 0, nil
+		}
+		// a request whose body can not be read again is not retried
+		if req.Body != nil && req.GetBody == nil {
+			return false, 0, nil
+		}
+		return true, retryAfterHeader(resp.Header.Get("Retry-After")), nil
+	})
+	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// throttledResponse tells whether the API rejected the request for exceeding a rate limit,
+// Compute Engine reports it as a 403 with the rateLimitExceeded reason
+func throttledResponse(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusForbidden:
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return err == nil && (bytes.Contains(body, []byte("rateLimitExceeded")) ||
+			bytes.Contains(body, []byte("userRateLimitExceeded")))
+	}
+	return false
+}
+
+// retryAfterHeader reads the Retry-After header, as seconds or as a date
+func retryAfterHeader(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
+
+// rateLimitInterceptor limits the calls of a gRPC API and retries the RESOURCE_EXHAUSTED ones
+func rateLimitInterceptor(api string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return limiterOf(api).do(ctx, func() (bool, time.Duration, error) {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			s, ok := status.FromError(err)
+			if err == nil || !ok || (s.Code() != codes.ResourceExhausted && s.Code() != codes.Unavailable) {
+				return false, 0, err
+			}
+			var retryAfter time.Duration
+			for _, detail := range s.Details() {
+				if info, ok := detail.(*errdetails.RetryInfo); ok {
+					retryAfter = info.GetRetryDelay().AsDuration()
+				}
+			}
+			return true, retryAfter, err
+		})
+	}
+}
+
+// ThrottleStats are the calls made to an API during the run
+type ThrottleStats struct {
+	API       string
+	Calls     int64
+	Throttled int64
+	Retries   int64
+	Failed    int64
+	Waited    time.Duration
+}
+
+func GetThrottleStats() []ThrottleStats {
+	rateLimiters.lock.Lock()
+	defer rateLimiters.lock.Unlock()
+
+	var stats []ThrottleStats
+	for api, l := range rateLimiters.limiters {
+		stats = append(stats, ThrottleStats{
+			API:       api,
+			Calls:     l.calls.Load(),
+			Throttled: l.throttled.Load(),
+			Retries:   l.retries.Load(),
+			Failed:    l.failed.Load(),
+			Waited:    time.Duration(l.waited.Load()),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].API < stats[j].API
+	})
+	return stats
+}
+
+func LogThrottleStats() {
+	for _, s := range GetThrottleStats() {
+		log.Printf("%s api: %d calls, %d throttled, %d retried, %d failed, %s waiting for the rate limit",
+			s.API, s.Calls, s.Throttled, s.Retries, s.Failed, s.Waited.Round(time.Millisecond))
+	}
+}
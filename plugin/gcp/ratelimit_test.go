@@ -0,0 +1,115 @@
+package gcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func testRateLimits(t *testing.T, maxRetries int) {
+	ConfigureRateLimits(RateLimits{
+		DefaultRate: 1000,
+		MaxRetries:  maxRetries,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    10 * time.Millisecond,
+	})
+	t.Cleanup(func() {
+		ConfigureRateLimits(DefaultRateLimits)
+	})
+}
+
+func TestRateLimitedTransportRetries(t *testing.T) {
+	testRateLimits(t, 3)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error": {"errors": [{"reason": "rateLimitExceeded"}]}}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &rateLimitedTransport{api: ComputeAPI, base: http.DefaultTransport}}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || requests != 3 {
+		t.Fatalf("[%s]: got status %d after %d requests", t.Name(), resp.StatusCode, requests)
+	}
+
+	stats := GetThrottleStats()
+	if len(stats) != 1 || stats[0].Calls != 3 || stats[0].Throttled != 2 || stats[0].Retries != 2 || stats[0].Failed != 0 {
+		t.Errorf("[%s]: unexpected stats %+v", t.Name(), stats)
+	}
+}
+
+func TestRateLimitedTransportExhaustedRetries(t *testing.T) {
+	testRateLimits(t, 2)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/denied" {
+			// a permission error is not retried
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error": {"errors": [{"reason": "forbidden"}]}}`))
+			return
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &rateLimitedTransport{api: MonitoringAPI, base: http.DefaultTransport}}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || requests != 3 {
+		t.Fatalf("[%s]: got status %d after %d requests", t.Name(), resp.StatusCode, requests)
+	}
+
+	resp, err = client.Get(server.URL + "/denied")
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden || requests != 4 {
+		t.Fatalf("[%s]: got status %d after %d requests", t.Name(), resp.StatusCode, requests)
+	}
+}
+
+func TestParseRateLimits(t *testing.T) {
+	limits, err := ParseRateLimits("compute=10, monitoring=40", "3")
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	if limits.Rates[ComputeAPI] != 10 || limits.Rates[MonitoringAPI] != 40 ||
+		limits.DefaultRate != DefaultRateLimits.DefaultRate || limits.MaxRetries != 3 {
+		t.Errorf("[%s]: unexpected limits %+v", t.Name(), limits)
+	}
+
+	limits, err = ParseRateLimits("5", "")
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	if limits.DefaultRate != 5 || len(limits.Rates) != 0 {
+		t.Errorf("[%s]: unexpected limits %+v", t.Name(), limits)
+	}
+
+	_, err = ParseRateLimits("compute=fast", "")
+	if err == nil {
+		t.Errorf("[%s]: expected an invalid rate error", t.Name())
+	}
+}
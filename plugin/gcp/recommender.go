@@ -0,0 +1,158 @@
+// Google Recommender API recommendations of Compute Engine Instances
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"google.golang.org/api/recommender/v1"
+
+	util "github.com/opengovern/plugin-gcp/utils"
+)
+
+const (
+	MachineTypeRecommender  = "google.compute.instance.MachineTypeRecommender"
+	IdleResourceRecommender = "google.compute.instance.IdleResourceRecommender"
+)
+
+// InstanceRecommendation is an active recommendation of the Recommender API for an instance
+type InstanceRecommendation struct {
+	Recommender string `json:"recommender"`
+	Subtype     string `json:"subtype"`
+	Zone        string `json:"zone"`
+	Instance    string `json:"instance"`
+	Description string `json:"description"`
+	Priority    string `json:"priority"`
+	// MachineType is the recommended machine type, empty when the recommendation does not change it
+	MachineType string `json:"machine_type,omitempty"`
+	// MonthlySaving is the projected saving per month in CurrencyCode
+	MonthlySaving float64 `json:"monthly_saving"`
+	CurrencyCode  string  `json:"currency_code"`
+}
+
+// Idle tells whether the recommendation is to stop or delete the instance
+func (r InstanceRecommendation) Idle() bool {
+	return r.Recommender == IdleResourceRecommender
+}
+
+type Recommender struct {
+	recommenderService *recommender.Service
+	GCP
+}
+
+func NewRecommender(scopes []string) *Recommender {
+	return &Recommender{
+		GCP: NewGCP(scopes),
+	}
+}
+
+func (r *Recommender) InitializeClient(ctx context.Context) error {
+
+	err := r.GCP.GetCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	recommenderService, err := recommender.NewService(
+		ctx,
+		r.GCP.clientOption(RecommenderAPI),
+	)
+	if err != nil {
+		return err
+	}
+
+	r.recommenderService = recommenderService
+
+	return nil
+}
+
+func (r *Recommender) CloseClient() error {
+	return nil
+}
+
+// GetInstanceRecommendations returns the active machine type and idle resource recommendations of the
+// instances in the zones, keyed by zone/instance
+func (r *Recommender) GetInstanceRecommendations(ctx context.Context, zones []string) (map[string][]InstanceRecommendation, error) {
+	recommendations := make(map[string][]InstanceRecommendation)
+
+	for _, zone := range zones {
+		for _, recommenderId := range []string{MachineTypeRecommender, IdleResourceRecommender} {
+			parent := fmt.Sprintf("projects/%s/locations/%s/recommenders/%s", r.ProjectID, zone, recommenderId)
+			err := r.recommenderService.Projects.Locations.Recommenders.Recommendations.List(parent).
+				Filter("stateInfo.state = ACTIVE").
+				Pages(ctx, func(page *recommender.GoogleCloudRecommenderV1ListRecommendationsResponse) error {
+					for _, rec := range page.Recommendations {
+						for _, instance := range instanceRecommendations(recommenderId, zone, rec) {
+							key := instance.Zone + "/" + instance.Instance
+							recommendations[key] = append(recommendations[key], instance)
+						}
+					}
+					return nil
+				})
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", parent, err.Error())
+			}
+		}
+	}
+
+	return recommendations, nil
+}
+
+// instanceRecommendations maps a recommendation to the instances it targets
+func instanceRecommendations(recommenderId, zone string, rec *recommender.GoogleCloudRecommenderV1Recommendation) []InstanceRecommendation {
+	base := InstanceRecommendation{
+		Recommender: recommenderId,
+		Subtype:     rec.RecommenderSubtype,
+		Zone:        zone,
+		Description: rec.Description,
+		Priority:    rec.Priority,
+	}
+	if rec.PrimaryImpact != nil && rec.PrimaryImpact.CostProjection != nil {
+		base.MonthlySaving, base.CurrencyCode = monthlySaving(rec.PrimaryImpact.CostProjection)
+	}
+
+	machineTypes := make(map[string]string)
+	if rec.Content != nil {
+		for _, group := range rec.Content.OperationGroups {
+			for _, op := range group.Operations {
+				value, ok := op.Value.(string)
+				if op.Action == "replace" && op.Path == "/machineType" && ok {
+					machineTypes[util.TrimmedString(op.Resource, "/")] = util.TrimmedString(value, "/")
+				}
+			}
+		}
+	}
+
+	var instances []InstanceRecommendation
+	for _, target := range rec.TargetResources {
+		if !strings.Contains(target, "/instances/") {
+			continue
+		}
+		instance := base
+		instance.Instance = util.TrimmedString(target, "/")
+		instance.MachineType = machineTypes[instance.Instance]
+		instances = append(instances, instance)
+	}
+	return instances
+}
+
+// monthlySaving converts the projected cost, negative for savings, over the projection duration to a monthly saving
+func monthlySaving(projection *recommender.GoogleCloudRecommenderV1CostProjection) (float64, string) {
+	if projection.Cost == nil {
+		return 0, ""
+	}
+	cost := float64(projection.Cost.Units) + float64(projection.Cost.Nanos)/1e9
+
+	duration, err := time.ParseDuration(projection.Duration)
+	if err != nil || duration <= 0 {
+		// projections are for 30 days unless stated otherwise
+		duration = 30 * 24 * time.Hour
+	}
+	month := 730 * time.Hour
+
+	return math.Round(-cost*month.Hours()/duration.Hours()*100) / 100, projection.Cost.CurrencyCode
+}
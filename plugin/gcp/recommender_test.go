@@ -0,0 +1,88 @@
+package gcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/recommender/v1"
+)
+
+func TestGetInstanceRecommendations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := &recommender.GoogleCloudRecommenderV1ListRecommendationsResponse{}
+		if strings.HasSuffix(r.URL.Path, MachineTypeRecommender+"/recommendations") {
+			response.Recommendations = []*recommender.GoogleCloudRecommenderV1Recommendation{
+				{
+					Description:        "Save cost by changing machine type from n2-standard-8 to n2-standard-4.",
+					RecommenderSubtype: "CHANGE_MACHINE_TYPE",
+					TargetResources:    []string{"//compute.googleapis.com/projects/test-project/zones/us-central1-a/instances/vm-1"},
+					PrimaryImpact: &recommender.GoogleCloudRecommenderV1Impact{
+						CostProjection: &recommender.GoogleCloudRecommenderV1CostProjection{
+							Cost:     &recommender.GoogleTypeMoney{CurrencyCode: "USD", Units: -120},
+							Duration: "2592000s",
+						},
+					},
+					Content: &recommender.GoogleCloudRecommenderV1RecommendationContent{
+						OperationGroups: []*recommender.GoogleCloudRecommenderV1OperationGroup{
+							{
+								Operations: []*recommender.GoogleCloudRecommenderV1Operation{
+									{
+										Action:   "replace",
+										Path:     "/machineType",
+										Resource: "//compute.googleapis.com/projects/test-project/zones/us-central1-a/instances/vm-1",
+										Value:    "zones/us-central1-a/machineTypes/n2-standard-4",
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+		} else {
+			response.Recommendations = []*recommender.GoogleCloudRecommenderV1Recommendation{
+				{
+					Description:        "Save cost by stopping Idle VM 'vm-2'.",
+					RecommenderSubtype: "STOP_VM",
+					TargetResources:    []string{"//compute.googleapis.com/projects/test-project/zones/us-central1-a/instances/vm-2"},
+				},
+			}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	recommenderService, err := recommender.NewService(ctx,
+		option.WithEndpoint(server.URL),
+		option.WithoutAuthentication(),
+	)
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+	r := &Recommender{
+		recommenderService: recommenderService,
+		GCP:                GCP{ProjectID: "test-project"},
+	}
+
+	recommendations, err := r.GetInstanceRecommendations(ctx, []string{"us-central1-a"})
+	if err != nil {
+		t.Fatalf("[%s]: %s", t.Name(), err.Error())
+	}
+
+	machineType := recommendations["us-central1-a/vm-1"]
+	if len(machineType) != 1 || machineType[0].MachineType != "n2-standard-4" || machineType[0].Idle() {
+		t.Fatalf("[%s]: unexpected recommendations of vm-1 %+v", t.Name(), machineType)
+	}
+	// 120 USD over 30 days is 121.67 USD over 730 hours
+	if machineType[0].MonthlySaving != 121.67 || machineType[0].CurrencyCode != "USD" {
+		t.Errorf("[%s]: unexpected saving %.2f %s", t.Name(), machineType[0].MonthlySaving, machineType[0].CurrencyCode)
+	}
+
+	idle := recommendations["us-central1-a/vm-2"]
+	if len(idle) != 1 || !idle[0].Idle() || idle[0].Subtype != "STOP_VM" {
+		t.Errorf("[%s]: unexpected recommendations of vm-2 %+v", t.Name(), idle)
+	}
+}
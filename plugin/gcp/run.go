@@ -0,0 +1,103 @@
+// Google Cloud Run Services and 1st gen Cloud Functions
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/cloudfunctions/v1"
+	"google.golang.org/api/run/v2"
+)
+
+type CloudRun struct {
+	runService       *run.Service
+	functionsService *cloudfunctions.Service
+	GCP
+}
+
+func NewCloudRun(scopes []string) *CloudRun {
+	return &CloudRun{
+		GCP: NewGCP(scopes),
+	}
+}
+
+func (c *CloudRun) InitializeClient(ctx context.Context) error {
+
+	err := c.GCP.GetCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	runService, err := run.NewService(
+		ctx,
+		c.GCP.clientOption(RunAPI),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.runService = runService
+
+	functionsService, err := cloudfunctions.NewService(
+		ctx,
+		c.GCP.clientOption(FunctionsAPI),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.functionsService = functionsService
+
+	return nil
+}
+
+func (c *CloudRun) CloseClient() error {
+	return nil
+}
+
+// GetAllServices lists the cloud run services of the project across all locations,
+// 2nd gen cloud functions are backed by cloud run services and are listed as well
+func (c *CloudRun) GetAllServices(ctx context.Context) ([]*run.GoogleCloudRunV2Service, error) {
+
+	var allServices []*run.GoogleCloudRunV2Service
+
+	parent := fmt.Sprintf("projects/%s/locations/-", c.ProjectID)
+
+	err := c.runService.Projects.Locations.Services.List(parent).Pages(ctx, func(resp *run.GoogleCloudRunV2ListServicesResponse) error {
+		allServices = append(allServices, resp.Services...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allServices, nil
+}
+
+func (c *CloudRun) GetRevision(ctx context.Context, revisionName string) (*run.GoogleCloudRunV2Revision, error) {
+	revision, err := c.runService.Projects.Locations.Services.Revisions.Get(revisionName).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// GetAllFunctions lists the 1st gen cloud functions of the project across all locations, they are not backed by
+// cloud run services
+func (c *CloudRun) GetAllFunctions(ctx context.Context) ([]*cloudfunctions.CloudFunction, error) {
+
+	var allFunctions []*cloudfunctions.CloudFunction
+
+	parent := fmt.Sprintf("projects/%s/locations/-", c.ProjectID)
+
+	err := c.functionsService.Projects.Locations.Functions.List(parent).Pages(ctx, func(resp *cloudfunctions.ListFunctionsResponse) error {
+		allFunctions = append(allFunctions, resp.Functions...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allFunctions, nil
+}
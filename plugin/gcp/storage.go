@@ -0,0 +1,59 @@
+// Google Cloud Storage Buckets
+
+package gcp
+
+import (
+	"context"
+
+	"google.golang.org/api/storage/v1"
+)
+
+type Storage struct {
+	storageService *storage.Service
+	GCP
+}
+
+func NewStorage(scopes []string) *Storage {
+	return &Storage{
+		GCP: NewGCP(scopes),
+	}
+}
+
+func (s *Storage) InitializeClient(ctx context.Context) error {
+
+	err := s.GCP.GetCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	storageService, err := storage.NewService(
+		ctx,
+		s.GCP.clientOption(StorageAPI),
+	)
+	if err != nil {
+		return err
+	}
+
+	s.storageService = storageService
+
+	return nil
+}
+
+func (s *Storage) CloseClient() error {
+	return nil
+}
+
+func (s *Storage) GetAllBuckets(ctx context.Context) ([]*storage.Bucket, error) {
+
+	var allBuckets []*storage.Bucket
+
+	err := s.storageService.Buckets.List(s.ProjectID).Pages(ctx, func(buckets *storage.Buckets) error {
+		allBuckets = append(allBuckets, buckets.Items...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allBuckets, nil
+}
@@ -9,13 +9,54 @@ var DefaultComputeEnginePreferences = []*golang.PreferenceItem{
 	{Service: "ComputeInstance", Key: "vCPU", IsNumber: true},
 	{Service: "ComputeInstance", Key: "Region", Pinned: true},
 	{Service: "ComputeInstance", Key: "ExcludeCustomInstances", Value: wrapperspb.String("No"), PreventPinning: true, PossibleValues: []string{"No", "Yes"}},
+	{Service: "ComputeInstance", Key: "CustomMachineTypes", Value: wrapperspb.String("No"), PreventPinning: true, PossibleValues: []string{"No", "Yes"}},
+	{Service: "ComputeInstance", Key: "ArmAssessment", Value: wrapperspb.String("No"), PreventPinning: true, PossibleValues: []string{"No", "Yes"}},
 	{Service: "ComputeInstance", Key: "MachineFamily", Pinned: false},
 	{Service: "ComputeInstance", Key: "MemoryGB", Alias: "Memory", IsNumber: true, Unit: "GiB"},
 	{Service: "ComputeInstance", Key: "CPUBreathingRoom", IsNumber: true, Value: wrapperspb.String("10"), PreventPinning: true, Unit: "%"},
 	{Service: "ComputeInstance", Key: "MemoryBreathingRoom", IsNumber: true, Value: wrapperspb.String("10"), PreventPinning: true, Unit: "%"},
+	{Service: "ComputeInstance", Key: "CPUUsageStatistic", Value: wrapperspb.String("Max"), PreventPinning: true, PossibleValues: []string{"Max", "p99", "p95", "p90", "p50"}},
+	{Service: "ComputeInstance", Key: "MemoryUsageStatistic", Value: wrapperspb.String("Max"), PreventPinning: true, PossibleValues: []string{"Max", "p99", "p95", "p90", "p50"}},
 	{Service: "ComputeInstance", Key: "ExcludeUpsizingFeature", Value: wrapperspb.String("Yes"), PreventPinning: true, PossibleValues: []string{"No", "Yes"}},
 	{Service: "ComputeInstance", Key: "ProvisioningModel", Pinned: true, PossibleValues: []string{"Standard", "Spot"}},
+	{Service: "ComputeInstance", Key: "GPUType", Alias: "GPU Type"},
+	{Service: "ComputeInstance", Key: "GPUCount", Alias: "GPU Count", IsNumber: true},
+	{Service: "ComputeInstance", Key: "GPUBreathingRoom", IsNumber: true, Value: wrapperspb.String("10"), PreventPinning: true, Unit: "%"},
+	{Service: "ComputeInstance", Key: "ActiveCPUUtilization", IsNumber: true, Value: wrapperspb.String("10"), PreventPinning: true, Unit: "%"},
+	{Service: "ComputeInstance", Key: "ActiveNetworkRate", IsNumber: true, Value: wrapperspb.String("50"), PreventPinning: true, Unit: "KiB/s"},
 
 	{Service: "ComputeDisk", Key: "DiskType"},
 	{Service: "ComputeDisk", Key: "DiskSizeGb", IsNumber: true, Unit: "GiB"},
 }
+
+var DefaultStorageBucketPreferences = []*golang.PreferenceItem{
+	{Service: "StorageBucket", Key: "StorageClass", Pinned: false, PossibleValues: []string{"STANDARD", "NEARLINE", "COLDLINE", "ARCHIVE"}},
+	{Service: "StorageBucket", Key: "Location", Pinned: true},
+	{Service: "StorageBucket", Key: "ExcludeAutoclass", Value: wrapperspb.String("No"), PreventPinning: true, PossibleValues: []string{"No", "Yes"}},
+	{Service: "StorageBucket", Key: "ExcludeLifecycleRules", Value: wrapperspb.String("No"), PreventPinning: true, PossibleValues: []string{"No", "Yes"}},
+	{Service: "StorageBucket", Key: "ExcludeRetrievalRisk", Value: wrapperspb.String("No"), PreventPinning: true, PossibleValues: []string{"No", "Yes"}},
+}
+
+var DefaultMemorystorePreferences = []*golang.PreferenceItem{
+	{Service: "MemorystoreInstance", Key: "Region", Pinned: true},
+	{Service: "MemorystoreInstance", Key: "Tier", PossibleValues: []string{"BASIC", "STANDARD_HA"}},
+	{Service: "MemorystoreInstance", Key: "MemorySizeGb", Alias: "Memory", IsNumber: true, Unit: "GiB"},
+	{Service: "MemorystoreInstance", Key: "ReplicaCount", IsNumber: true},
+	{Service: "MemorystoreInstance", Key: "CPUBreathingRoom", IsNumber: true, Value: wrapperspb.String("10"), PreventPinning: true, Unit: "%"},
+	{Service: "MemorystoreInstance", Key: "MemoryBreathingRoom", IsNumber: true, Value: wrapperspb.String("20"), PreventPinning: true, Unit: "%"},
+}
+
+var DefaultCloudRunPreferences = []*golang.PreferenceItem{
+	{Service: "CloudRunService", Key: "vCPU", IsNumber: true},
+	{Service: "CloudRunService", Key: "MemoryMb", Alias: "Memory", IsNumber: true, Unit: "MiB"},
+	{Service: "CloudRunService", Key: "Concurrency", IsNumber: true},
+	{Service: "CloudRunService", Key: "MinInstances", IsNumber: true},
+	{Service: "CloudRunService", Key: "CPUAlwaysAllocated", PossibleValues: []string{"No", "Yes"}},
+	{Service: "CloudRunService", Key: "CPUBreathingRoom", IsNumber: true, Value: wrapperspb.String("10"), PreventPinning: true, Unit: "%"},
+	{Service: "CloudRunService", Key: "MemoryBreathingRoom", IsNumber: true, Value: wrapperspb.String("10"), PreventPinning: true, Unit: "%"},
+}
+
+var DefaultLoadBalancerPreferences = []*golang.PreferenceItem{
+	{Service: "LoadBalancer", Key: "IdleRequestRateThreshold", IsNumber: true, Value: wrapperspb.String("0"), PreventPinning: true, Unit: "req/s"},
+	{Service: "LoadBalancer", Key: "ConsiderNoHealthyBackendsIdle", Value: wrapperspb.String("Yes"), PreventPinning: true, PossibleValues: []string{"No", "Yes"}},
+}
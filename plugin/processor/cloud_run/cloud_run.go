@@ -0,0 +1,144 @@
+package cloud_run
+
+import (
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	"github.com/kaytu-io/kaytu/pkg/style"
+	"github.com/kaytu-io/kaytu/pkg/utils"
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+	"strings"
+	"sync/atomic"
+)
+
+type CloudRunProcessor struct {
+	provider                *gcp.CloudRun
+	metricProvider          *gcp.CloudMonitoring
+	items                   utils.ConcurrentMap[string, CloudRunServiceItem]
+	publishOptimizationItem func(item *golang.ChartOptimizationItem)
+	publishResultSummary    func(summary *golang.ResultSummary)
+	kaytuAcccessToken       string
+	jobQueue                *sdk.JobQueue
+	lazyloadCounter         atomic.Uint32
+
+	defaultPreferences []*golang.PreferenceItem
+
+	summary utils.ConcurrentMap[string, CloudRunServiceSummary]
+}
+
+func NewCloudRunProcessor(
+	prv *gcp.CloudRun,
+	metricPrv *gcp.CloudMonitoring,
+	publishOptimizationItem func(item *golang.ChartOptimizationItem),
+	publishResultSummary func(summary *golang.ResultSummary),
+	kaytuAcccessToken string,
+	jobQueue *sdk.JobQueue,
+	defaultPreferences []*golang.PreferenceItem,
+) *CloudRunProcessor {
+	r := &CloudRunProcessor{
+		provider:                prv,
+		metricProvider:          metricPrv,
+		items:                   utils.NewConcurrentMap[string, CloudRunServiceItem](),
+		publishOptimizationItem: publishOptimizationItem,
+		publishResultSummary:    publishResultSummary,
+		kaytuAcccessToken:       kaytuAcccessToken,
+		jobQueue:                jobQueue,
+		lazyloadCounter:         atomic.Uint32{},
+		defaultPreferences:      defaultPreferences,
+	}
+
+	jobQueue.Push(NewListCloudRunServicesJob(r))
+	return r
+}
+
+func (m *CloudRunProcessor) ReEvaluate(id string, items []*golang.PreferenceItem) {
+	v, _ := m.items.Get(id)
+	v.Preferences = items
+	m.items.Set(id, v)
+	v.OptimizationLoading = true
+	m.publishOptimizationItem(v.ToOptimizationItem())
+	m.jobQueue.Push(NewOptimizeCloudRunServiceJob(m, id))
+}
+
+func (m *CloudRunProcessor) ExportNonInteractive() *golang.NonInteractiveExport {
+	return &golang.NonInteractiveExport{
+		Csv: m.exportCsv(),
+	}
+}
+
+func (m *CloudRunProcessor) exportCsv() []*golang.CSVRow {
+	headers := []string{
+		"Project ID", "Region", "Resource Type", "Resource ID", "Resource Name", "Platform",
+		"Device Runtime (Hrs)", "Current Cost", "Recommendation Cost", "Net Savings",
+		"Current Spec", "Suggested Spec", "Parent Device", "Justification", "Additional Details",
+	}
+	var rows []*golang.CSVRow
+	rows = append(rows, &golang.CSVRow{Row: headers})
+
+	m.items.Range(func(key string, value CloudRunServiceItem) bool {
+		if value.Wastage == nil || value.Wastage.Rightsizing == nil || value.Wastage.Rightsizing.Current == nil {
+			return true
+		}
+		var additionalDetails []string
+		var rightSizingCost, saving, recSpec string
+		if value.Wastage.Rightsizing.Recommended != nil {
+			rightSizingCost = utils.FormatPriceFloat(value.Wastage.Rightsizing.Recommended.Cost)
+			saving = utils.FormatPriceFloat(value.Wastage.Rightsizing.Current.Cost - value.Wastage.Rightsizing.Recommended.Cost)
+			recSpec = serviceSpec(value.Wastage.Rightsizing.Recommended)
+
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("CPU:: Current: %.2f - Recommended: %.2f", value.Wastage.Rightsizing.Current.Cpu,
+					value.Wastage.Rightsizing.Recommended.Cpu))
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("Memory:: Current: %d - Recommended: %d", value.Wastage.Rightsizing.Current.MemoryMb,
+					value.Wastage.Rightsizing.Recommended.MemoryMb))
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("Concurrency:: Current: %d - Recommended: %d", value.Wastage.Rightsizing.Current.Concurrency,
+					value.Wastage.Rightsizing.Recommended.Concurrency))
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("Min Instances:: Current: %d - Recommended: %d", value.Wastage.Rightsizing.Current.MinInstances,
+					value.Wastage.Rightsizing.Recommended.MinInstances))
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("CPU Always Allocated:: Current: %t - Recommended: %t", value.Wastage.Rightsizing.Current.CpuAlwaysAllocated,
+					value.Wastage.Rightsizing.Recommended.CpuAlwaysAllocated))
+		}
+		resourceType := "Cloud Run Service"
+		if value.Function != nil {
+			resourceType = "Cloud Function"
+		}
+		serviceRow := []string{
+			value.ProjectId, value.Region, resourceType, value.Id, value.Name, value.Platform,
+			"730 Hrs", utils.FormatPriceFloat(value.Wastage.Rightsizing.Current.Cost), rightSizingCost, saving,
+			serviceSpec(value.Wastage.Rightsizing.Current), recSpec, "None", value.Wastage.Rightsizing.Description, strings.Join(additionalDetails, "---")}
+
+		rows = append(rows, &golang.CSVRow{Row: serviceRow})
+
+		return true
+	})
+	return rows
+}
+
+func (m *CloudRunProcessor) ResultsSummary() *golang.ResultSummary {
+	summary := &golang.ResultSummary{}
+	var totalCost, savings float64
+	m.summary.Range(func(_ string, item CloudRunServiceSummary) bool {
+		totalCost += item.CurrentRuntimeCost
+		savings += item.Savings
+		return true
+	})
+
+	summary.Message = fmt.Sprintf("Current runtime cost: %s, Savings: %s",
+		style.CostStyle.Render(fmt.Sprintf("%s", utils.FormatPriceFloat(totalCost))), style.SavingStyle.Render(fmt.Sprintf("%s", utils.FormatPriceFloat(savings))))
+	return summary
+}
+
+func (m *CloudRunProcessor) UpdateSummary(itemId string) {
+	i, ok := m.items.Get(itemId)
+	if ok && i.Wastage != nil && i.Wastage.Rightsizing != nil && i.Wastage.Rightsizing.Recommended != nil {
+		m.summary.Set(itemId, CloudRunServiceSummary{
+			CurrentRuntimeCost: i.Wastage.Rightsizing.Current.Cost,
+			Savings:            i.Wastage.Rightsizing.Current.Cost - i.Wastage.Rightsizing.Recommended.Cost,
+		})
+	}
+	m.publishResultSummary(m.ResultsSummary())
+}
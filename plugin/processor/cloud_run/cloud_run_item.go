@@ -0,0 +1,217 @@
+package cloud_run
+
+import (
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	"github.com/kaytu-io/kaytu/pkg/utils"
+	"github.com/opengovern/plugin-gcp/plugin/processor/shared"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/api/cloudfunctions/v1"
+	"google.golang.org/api/run/v2"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type CloudRunServiceItem struct {
+	ProjectId            string
+	Name                 string
+	Id                   string
+	Region               string
+	Platform             string
+	RevisionName         string
+	Cpu                  float64
+	MemoryMb             int64
+	Concurrency          int64
+	MinInstances         int64
+	MaxInstances         int64
+	CpuAlwaysAllocated   bool
+	StartupCpuBoost      bool
+	ExecutionEnvironment string
+	OptimizationLoading  bool
+	Preferences          []*golang.PreferenceItem
+	Skipped              bool
+	LazyLoadingEnabled   bool
+	SkipReason           string
+	Service              *run.GoogleCloudRunV2Service
+	Revision             *run.GoogleCloudRunV2Revision
+	Function             *cloudfunctions.CloudFunction
+	Metrics              map[string][]*golang2.DataPoint
+	Wastage              *golang2.GCPCloudRunOptimizationResponse
+}
+
+func (i CloudRunServiceItem) CloudRunServiceDevice() (*golang.ChartRow, map[string]*golang.Properties) {
+	row := golang.ChartRow{
+		RowId:  i.Id,
+		Values: make(map[string]*golang.ChartRowItem),
+	}
+
+	row.Values["resource_id"] = &golang.ChartRowItem{
+		Value: i.Id,
+	}
+	row.Values["resource_name"] = &golang.ChartRowItem{
+		Value: i.Name,
+	}
+	row.Values["resource_type"] = &golang.ChartRowItem{
+		Value: i.Platform,
+	}
+	row.Values["project_id"] = &golang.ChartRowItem{
+		Value: i.ProjectId,
+	}
+
+	RegionProperty := &golang.Property{Key: "Region"}
+	RevisionProperty := &golang.Property{Key: "Revision", Current: i.RevisionName}
+	CPUAlwaysAllocatedProperty := &golang.Property{Key: "CPU Always Allocated"}
+	CPUProperty := &golang.Property{Key: "  vCPU"}
+	MemoryProperty := &golang.Property{Key: "  MemoryMB"}
+	ConcurrencyProperty := &golang.Property{Key: "  Concurrency"}
+	MinInstancesProperty := &golang.Property{Key: "  Min Instances"}
+	MaxInstancesProperty := &golang.Property{Key: "  Max Instances"}
+	InstanceCountProperty := &golang.Property{Key: "  Instance Count"}
+	RequestCountProperty := &golang.Property{Key: "  Requests (per second)"}
+
+	if i.Wastage != nil && i.Wastage.Rightsizing != nil && i.Wastage.Rightsizing.Current != nil {
+		current := i.Wastage.Rightsizing.Current
+		RegionProperty.Current = current.Region
+		CPUAlwaysAllocatedProperty.Current = yesNo(current.CpuAlwaysAllocated)
+
+		CPUProperty.Current = fmt.Sprintf("%.2f", current.Cpu)
+		CPUProperty.Average = utils.Percentage(shared.PWrapperDouble(i.Wastage.Rightsizing.Cpu.GetAvg()))
+		CPUProperty.Max = utils.Percentage(shared.PWrapperDouble(i.Wastage.Rightsizing.Cpu.GetMax()))
+
+		MemoryProperty.Current = fmt.Sprintf("%d MB", current.MemoryMb)
+		MemoryProperty.Average = utils.Percentage(shared.PWrapperDouble(i.Wastage.Rightsizing.Memory.GetAvg()))
+		MemoryProperty.Max = utils.Percentage(shared.PWrapperDouble(i.Wastage.Rightsizing.Memory.GetMax()))
+
+		ConcurrencyProperty.Current = fmt.Sprintf("%d", current.Concurrency)
+		ConcurrencyProperty.Average = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.Concurrency.GetAvg()))
+		ConcurrencyProperty.Max = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.Concurrency.GetMax()))
+
+		MinInstancesProperty.Current = fmt.Sprintf("%d", current.MinInstances)
+		MaxInstancesProperty.Current = fmt.Sprintf("%d", current.MaxInstances)
+		InstanceCountProperty.Average = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.InstanceCount.GetAvg()))
+		InstanceCountProperty.Max = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.InstanceCount.GetMax()))
+		RequestCountProperty.Average = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.RequestCount.GetAvg()))
+		RequestCountProperty.Max = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.RequestCount.GetMax()))
+
+		row.Values["current_cost"] = &golang.ChartRowItem{
+			Value: utils.FormatPriceFloat(current.Cost),
+		}
+
+		if recommended := i.Wastage.Rightsizing.Recommended; recommended != nil {
+			row.Values["right_sized_cost"] = &golang.ChartRowItem{
+				Value: utils.FormatPriceFloat(recommended.Cost),
+			}
+			row.Values["savings"] = &golang.ChartRowItem{
+				Value: utils.FormatPriceFloat(current.Cost - recommended.Cost),
+			}
+			RegionProperty.Recommended = recommended.Region
+			CPUAlwaysAllocatedProperty.Recommended = yesNo(recommended.CpuAlwaysAllocated)
+			CPUProperty.Recommended = fmt.Sprintf("%.2f", recommended.Cpu)
+			MemoryProperty.Recommended = fmt.Sprintf("%d MB", recommended.MemoryMb)
+			ConcurrencyProperty.Recommended = fmt.Sprintf("%d", recommended.Concurrency)
+			MinInstancesProperty.Recommended = fmt.Sprintf("%d", recommended.MinInstances)
+			MaxInstancesProperty.Recommended = fmt.Sprintf("%d", recommended.MaxInstances)
+		}
+	}
+
+	props := make(map[string]*golang.Properties)
+	properties := &golang.Properties{}
+
+	properties.Properties = append(properties.Properties, RegionProperty)
+	properties.Properties = append(properties.Properties, RevisionProperty)
+	properties.Properties = append(properties.Properties, CPUAlwaysAllocatedProperty)
+	properties.Properties = append(properties.Properties, &golang.Property{
+		Key: "Resources",
+	})
+	properties.Properties = append(properties.Properties, CPUProperty)
+	properties.Properties = append(properties.Properties, MemoryProperty)
+	properties.Properties = append(properties.Properties, &golang.Property{
+		Key: "Scaling",
+	})
+	properties.Properties = append(properties.Properties, ConcurrencyProperty)
+	properties.Properties = append(properties.Properties, MinInstancesProperty)
+	properties.Properties = append(properties.Properties, MaxInstancesProperty)
+	properties.Properties = append(properties.Properties, InstanceCountProperty)
+	properties.Properties = append(properties.Properties, RequestCountProperty)
+
+	props[i.Id] = properties
+
+	return &row, props
+}
+
+func (i CloudRunServiceItem) Devices() ([]*golang.ChartRow, map[string]*golang.Properties) {
+	serviceRow, serviceProps := i.CloudRunServiceDevice()
+
+	return []*golang.ChartRow{serviceRow}, serviceProps
+}
+
+func (i CloudRunServiceItem) ToOptimizationItem() *golang.ChartOptimizationItem {
+
+	deviceRows, deviceProps := i.Devices()
+
+	status := ""
+	if i.Skipped {
+		status = fmt.Sprintf("skipped - %s", i.SkipReason)
+	} else if i.LazyLoadingEnabled && !i.OptimizationLoading {
+		status = "press enter to load"
+	} else if i.OptimizationLoading {
+		status = "loading"
+	} else if i.Wastage != nil && i.Wastage.Rightsizing != nil && i.Wastage.Rightsizing.Recommended != nil {
+		totalSaving := i.Wastage.Rightsizing.Current.Cost - i.Wastage.Rightsizing.Recommended.Cost
+		totalCurrentCost := i.Wastage.Rightsizing.Current.Cost
+		status = fmt.Sprintf("%s (%.2f%%)", utils.FormatPriceFloat(totalSaving), (totalSaving/totalCurrentCost)*100)
+	}
+
+	chartrow := &golang.ChartRow{
+		RowId: i.Id,
+		Values: map[string]*golang.ChartRowItem{
+			"x_kaytu_right_arrow": {
+				Value: "→",
+			},
+			"resource_id": {
+				Value: i.Id,
+			},
+			"resource_name": {
+				Value: i.Name,
+			},
+			"resource_type": {
+				Value: fmt.Sprintf("%.2f vCPU / %d MB", i.Cpu, i.MemoryMb),
+			},
+			"region": {
+				Value: i.Region,
+			},
+			"platform": {
+				Value: i.Platform,
+			},
+			"total_saving": {
+				Value: status,
+			},
+		},
+	}
+
+	coi := &golang.ChartOptimizationItem{
+		OverviewChartRow:   chartrow,
+		DevicesChartRows:   deviceRows,
+		DevicesProperties:  deviceProps,
+		Preferences:        i.Preferences,
+		Loading:            i.OptimizationLoading,
+		Skipped:            i.Skipped,
+		SkipReason:         wrapperspb.String(i.SkipReason),
+		LazyLoadingEnabled: i.LazyLoadingEnabled,
+	}
+	if i.Wastage != nil && i.Wastage.Rightsizing != nil {
+		coi.Description = i.Wastage.Rightsizing.Description
+	}
+
+	return coi
+}
+
+func serviceSpec(s *golang2.RightsizingGcpCloudRunService) string {
+	return fmt.Sprintf("%.2f vCPU / %d MB / concurrency %d / min %d", s.Cpu, s.MemoryMb, s.Concurrency, s.MinInstances)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
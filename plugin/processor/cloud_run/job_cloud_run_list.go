@@ -0,0 +1,213 @@
+package cloud_run
+
+import (
+	"context"
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	"log"
+	"strconv"
+	"strings"
+
+	util "github.com/opengovern/plugin-gcp/utils"
+)
+
+type ListCloudRunServicesJob struct {
+	processor *CloudRunProcessor
+}
+
+func NewListCloudRunServicesJob(processor *CloudRunProcessor) *ListCloudRunServicesJob {
+	return &ListCloudRunServicesJob{
+		processor: processor,
+	}
+}
+
+func (job *ListCloudRunServicesJob) Properties() sdk.JobProperties {
+	return sdk.JobProperties{
+		ID:          "list_cloud_run_services",
+		Description: "List all cloud run services and cloud functions in current project",
+		MaxRetry:    0,
+	}
+}
+
+func (job *ListCloudRunServicesJob) Run(ctx context.Context) error {
+	log.Println("Running list cloud run services job")
+
+	services, err := job.processor.provider.GetAllServices(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("# of services: %d", len(services))
+
+	for _, service := range services {
+		// service name is in form of projects/{project}/locations/{region}/services/{service}
+		nameParts := strings.Split(service.Name, "/")
+
+		platform := PlatformCloudRun
+		if service.Labels["goog-managed-by"] == "cloudfunctions" {
+			platform = PlatformFunctions
+		}
+
+		oi := CloudRunServiceItem{
+			ProjectId:           job.processor.provider.ProjectID,
+			Name:                util.TrimmedString(service.Name, "/"),
+			Id:                  service.Name,
+			Platform:            platform,
+			OptimizationLoading: true,
+			Preferences:         job.processor.defaultPreferences,
+			Skipped:             false,
+			LazyLoadingEnabled:  false,
+			SkipReason:          "NA",
+			Service:             service,
+			Metrics:             nil,
+		}
+		if len(nameParts) > 3 {
+			oi.Region = nameParts[3]
+		}
+
+		if service.LatestReadyRevision == "" {
+			oi.Skipped = true
+			oi.SkipReason = "no ready revision"
+		} else {
+			revision, err := job.processor.provider.GetRevision(ctx, service.LatestReadyRevision)
+			if err != nil {
+				// one revision failing to load should not hide the rest of the services
+				log.Printf("failed to get revision %s: %v", service.LatestReadyRevision, err)
+				continue
+			}
+			oi.Revision = revision
+			oi.RevisionName = util.TrimmedString(revision.Name, "/")
+			oi.Concurrency = revision.MaxInstanceRequestConcurrency
+			oi.ExecutionEnvironment = revision.ExecutionEnvironment
+			if revision.Scaling != nil {
+				oi.MinInstances = revision.Scaling.MinInstanceCount
+				oi.MaxInstances = revision.Scaling.MaxInstanceCount
+			}
+			if service.Scaling != nil && service.Scaling.MinInstanceCount > oi.MinInstances {
+				oi.MinInstances = service.Scaling.MinInstanceCount
+			}
+			for _, container := range revision.Containers {
+				if container.Resources == nil {
+					continue
+				}
+				oi.Cpu += parseCpu(container.Resources.Limits["cpu"])
+				oi.MemoryMb += parseMemoryMb(container.Resources.Limits["memory"])
+				// the serving container decides how cpu is allocated for the whole instance
+				if len(container.Ports) > 0 {
+					oi.CpuAlwaysAllocated = !container.Resources.CpuIdle
+					oi.StartupCpuBoost = container.Resources.StartupCpuBoost
+				}
+			}
+		}
+
+		job.addItem(oi)
+	}
+
+	// 1st gen functions are not backed by cloud run services, projects without the cloud functions api enabled
+	// fail to list them and only have their services optimized
+	functions, err := job.processor.provider.GetAllFunctions(ctx)
+	if err != nil {
+		log.Printf("failed to list 1st gen cloud functions: %v", err)
+		return nil
+	}
+
+	log.Printf("# of 1st gen functions: %d", len(functions))
+
+	for _, function := range functions {
+		// function name is in form of projects/{project}/locations/{region}/functions/{function}
+		nameParts := strings.Split(function.Name, "/")
+
+		oi := CloudRunServiceItem{
+			ProjectId:           job.processor.provider.ProjectID,
+			Name:                util.TrimmedString(function.Name, "/"),
+			Id:                  function.Name,
+			Platform:            PlatformFunctionsGen1,
+			MemoryMb:            function.AvailableMemoryMb,
+			Cpu:                 functionCpu(function.AvailableMemoryMb),
+			Concurrency:         1,
+			MinInstances:        function.MinInstances,
+			MaxInstances:        function.MaxInstances,
+			OptimizationLoading: true,
+			Preferences:         job.processor.defaultPreferences,
+			Skipped:             false,
+			LazyLoadingEnabled:  false,
+			SkipReason:          "NA",
+			Function:            function,
+			Metrics:             nil,
+		}
+		if len(nameParts) > 3 {
+			oi.Region = nameParts[3]
+		}
+		if oi.MemoryMb == 0 {
+			// functions deployed without a memory limit get 256 MB
+			oi.MemoryMb = 256
+			oi.Cpu = functionCpu(oi.MemoryMb)
+		}
+
+		if function.Status != "ACTIVE" {
+			oi.Skipped = true
+			oi.SkipReason = fmt.Sprintf("function is %s", function.Status)
+		}
+
+		job.addItem(oi)
+	}
+
+	return nil
+}
+
+func (job *ListCloudRunServicesJob) addItem(oi CloudRunServiceItem) {
+	if !oi.Skipped {
+		job.processor.lazyloadCounter.Add(1)
+		if job.processor.lazyloadCounter.Load() > uint32(1) {
+			oi.LazyLoadingEnabled = true
+		}
+	}
+
+	job.processor.items.Set(oi.Id, oi)
+	job.processor.publishOptimizationItem(oi.ToOptimizationItem())
+	job.processor.UpdateSummary(oi.Id)
+
+	if !oi.Skipped {
+		job.processor.jobQueue.Push(NewGetCloudRunServiceMetricsJob(job.processor, oi.Id))
+	}
+}
+
+// parseCpu converts a kubernetes style cpu quantity such as "1", "0.5" or "500m" to vCPUs
+func parseCpu(quantity string) float64 {
+	if strings.HasSuffix(quantity, "m") {
+		milli, err := strconv.ParseFloat(strings.TrimSuffix(quantity, "m"), 64)
+		if err != nil {
+			return 0
+		}
+		return milli / 1000
+	}
+	cpu, err := strconv.ParseFloat(quantity, 64)
+	if err != nil {
+		return 0
+	}
+	return cpu
+}
+
+// parseMemoryMb converts a kubernetes style memory quantity such as "512Mi", "2Gi" or "1G" to MiB
+func parseMemoryMb(quantity string) int64 {
+	units := []struct {
+		suffix string
+		bytes  float64
+	}{
+		{"Ki", 1024}, {"Mi", 1024 * 1024}, {"Gi", 1024 * 1024 * 1024},
+		{"k", 1000}, {"M", 1000 * 1000}, {"G", 1000 * 1000 * 1000},
+	}
+	multiplier := 1.0
+	for _, u := range units {
+		if strings.HasSuffix(quantity, u.suffix) {
+			quantity = strings.TrimSuffix(quantity, u.suffix)
+			multiplier = u.bytes
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(quantity, 64)
+	if err != nil {
+		return 0
+	}
+	return int64(value * multiplier / (1024 * 1024))
+}
@@ -0,0 +1,238 @@
+package cloud_run
+
+import (
+	"context"
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"log"
+	"time"
+
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type GetCloudRunServiceMetricsJob struct {
+	processor *CloudRunProcessor
+	itemId    string
+}
+
+func NewGetCloudRunServiceMetricsJob(processor *CloudRunProcessor, itemId string) *GetCloudRunServiceMetricsJob {
+	return &GetCloudRunServiceMetricsJob{
+		processor: processor,
+		itemId:    itemId,
+	}
+}
+
+func (job *GetCloudRunServiceMetricsJob) Properties() sdk.JobProperties {
+	return sdk.JobProperties{
+		ID:          fmt.Sprintf("get_cloud_run_service_metrics_%s", job.itemId),
+		Description: fmt.Sprintf("Get metrics for cloud run service or function: %s", job.itemId),
+		MaxRetry:    0,
+	}
+}
+
+func (job *GetCloudRunServiceMetricsJob) Run(ctx context.Context) error {
+	item, ok := job.processor.items.Get(job.itemId)
+	if !ok {
+		return fmt.Errorf("item not found %s", job.itemId)
+	}
+
+	endTime := time.Now()                         // end time of requested time series
+	startTime := endTime.Add(-24 * 1 * time.Hour) // start time of requested time series
+
+	var serviceMetrics map[string][]*golang2.DataPoint
+	var err error
+	if item.Function != nil {
+		serviceMetrics, err = job.functionMetrics(ctx, item, startTime, endTime)
+	} else {
+		serviceMetrics, err = job.serviceMetrics(ctx, item, startTime, endTime)
+	}
+	if err != nil {
+		return err
+	}
+
+	item.OptimizationLoading = true
+	item.Skipped = false
+	item.SkipReason = "N/A"
+	item.LazyLoadingEnabled = false
+	item.Metrics = serviceMetrics
+
+	for k, v := range item.Metrics {
+		log.Printf("%s %s : %d", item.Id, k, len(v))
+	}
+
+	job.processor.items.Set(item.Id, item)
+	job.processor.publishOptimizationItem(item.ToOptimizationItem())
+	job.processor.UpdateSummary(item.Id)
+
+	job.processor.jobQueue.Push(NewOptimizeCloudRunServiceJob(job.processor, item.Id))
+
+	return nil
+}
+
+// serviceMetrics reads the metrics of all revisions of a cloud run service
+func (job *GetCloudRunServiceMetricsJob) serviceMetrics(ctx context.Context, item CloudRunServiceItem, startTime, endTime time.Time) (map[string][]*golang2.DataPoint, error) {
+	// newRequest builds a request for a metric of all revisions of the service
+	newRequest := func(metricType string, aligner monitoringpb.Aggregation_Aligner, reducer monitoringpb.Aggregation_Reducer, groupBy ...string) *monitoringpb.ListTimeSeriesRequest {
+		return job.processor.metricProvider.NewTimeSeriesRequest(
+			fmt.Sprintf(
+				`metric.type="%s" AND resource.type="cloud_run_revision" AND resource.labels.service_name="%s" AND resource.labels.location="%s"`,
+				metricType,
+				item.Name,
+				item.Region,
+			),
+			&monitoringpb.TimeInterval{
+				EndTime:   timestamppb.New(endTime),
+				StartTime: timestamppb.New(startTime),
+			},
+			&monitoringpb.Aggregation{
+				AlignmentPeriod: &durationpb.Duration{
+					Seconds: 60,
+				},
+				PerSeriesAligner:   aligner,
+				CrossSeriesReducer: reducer,
+				GroupByFields:      groupBy,
+			},
+		)
+	}
+
+	// utilizations are distributions over the container instances, the mean shows the typical load and the 99th percentile the peaks
+	cpuMetric, err := job.processor.metricProvider.GetMetric(ctx, newRequest(
+		"run.googleapis.com/container/cpu/utilizations", monitoringpb.Aggregation_ALIGN_MEAN, monitoringpb.Aggregation_REDUCE_MEAN))
+	if err != nil {
+		return nil, err
+	}
+
+	cpuPeakMetric, err := job.processor.metricProvider.GetMetric(ctx, newRequest(
+		"run.googleapis.com/container/cpu/utilizations", monitoringpb.Aggregation_ALIGN_PERCENTILE_99, monitoringpb.Aggregation_REDUCE_MAX))
+	if err != nil {
+		return nil, err
+	}
+
+	memoryMetric, err := job.processor.metricProvider.GetMetric(ctx, newRequest(
+		"run.googleapis.com/container/memory/utilizations", monitoringpb.Aggregation_ALIGN_MEAN, monitoringpb.Aggregation_REDUCE_MEAN))
+	if err != nil {
+		return nil, err
+	}
+
+	memoryPeakMetric, err := job.processor.metricProvider.GetMetric(ctx, newRequest(
+		"run.googleapis.com/container/memory/utilizations", monitoringpb.Aggregation_ALIGN_PERCENTILE_99, monitoringpb.Aggregation_REDUCE_MAX))
+	if err != nil {
+		return nil, err
+	}
+
+	// instance count is split into active and idle instances
+	instanceCountMetrics, err := job.processor.metricProvider.GetMetricByLabel(ctx, newRequest(
+		"run.googleapis.com/container/instance_count", monitoringpb.Aggregation_ALIGN_MEAN, monitoringpb.Aggregation_REDUCE_SUM, "metric.labels.state"), "state")
+	if err != nil {
+		return nil, err
+	}
+
+	requestCountMetric, err := job.processor.metricProvider.GetMetric(ctx, newRequest(
+		"run.googleapis.com/request_count", monitoringpb.Aggregation_ALIGN_RATE, monitoringpb.Aggregation_REDUCE_SUM))
+	if err != nil {
+		return nil, err
+	}
+
+	concurrencyMetric, err := job.processor.metricProvider.GetMetric(ctx, newRequest(
+		"run.googleapis.com/container/max_request_concurrencies", monitoringpb.Aggregation_ALIGN_PERCENTILE_99, monitoringpb.Aggregation_REDUCE_MAX))
+	if err != nil {
+		return nil, err
+	}
+
+	serviceMetrics := make(map[string][]*golang2.DataPoint)
+
+	serviceMetrics["CPUUtilization"] = cpuMetric
+	serviceMetrics["CPUUtilizationP99"] = cpuPeakMetric
+	serviceMetrics["MemoryUtilization"] = memoryMetric
+	serviceMetrics["MemoryUtilizationP99"] = memoryPeakMetric
+	for state, dps := range instanceCountMetrics {
+		serviceMetrics[fmt.Sprintf("InstanceCount/%s", state)] = dps
+	}
+	serviceMetrics["RequestCount"] = requestCountMetric
+	serviceMetrics["MaxRequestConcurrency"] = concurrencyMetric
+
+	return serviceMetrics, nil
+}
+
+// functionMetrics reads the metrics of a 1st gen cloud function under the keys of the cloud run metrics, the
+// memory is reported in bytes and turned into a utilization of the function memory. 1st gen functions report
+// no cpu usage, their cpu is set by their memory.
+func (job *GetCloudRunServiceMetricsJob) functionMetrics(ctx context.Context, item CloudRunServiceItem, startTime, endTime time.Time) (map[string][]*golang2.DataPoint, error) {
+	newRequest := func(metricType string, aligner monitoringpb.Aggregation_Aligner, reducer monitoringpb.Aggregation_Reducer) *monitoringpb.ListTimeSeriesRequest {
+		return job.processor.metricProvider.NewTimeSeriesRequest(
+			fmt.Sprintf(
+				`metric.type="%s" AND resource.type="cloud_function" AND resource.labels.function_name="%s" AND resource.labels.region="%s"`,
+				metricType,
+				item.Name,
+				item.Region,
+			),
+			&monitoringpb.TimeInterval{
+				EndTime:   timestamppb.New(endTime),
+				StartTime: timestamppb.New(startTime),
+			},
+			&monitoringpb.Aggregation{
+				AlignmentPeriod: &durationpb.Duration{
+					Seconds: 60,
+				},
+				PerSeriesAligner:   aligner,
+				CrossSeriesReducer: reducer,
+			},
+		)
+	}
+
+	memoryBytes := float64(item.MemoryMb) * 1024 * 1024
+	memoryMetric, err := job.processor.metricProvider.GetMetric(ctx, newRequest(
+		"cloudfunctions.googleapis.com/function/user_memory_bytes", monitoringpb.Aggregation_ALIGN_MEAN, monitoringpb.Aggregation_REDUCE_MEAN))
+	if err != nil {
+		return nil, err
+	}
+
+	memoryPeakMetric, err := job.processor.metricProvider.GetMetric(ctx, newRequest(
+		"cloudfunctions.googleapis.com/function/user_memory_bytes", monitoringpb.Aggregation_ALIGN_PERCENTILE_99, monitoringpb.Aggregation_REDUCE_MAX))
+	if err != nil {
+		return nil, err
+	}
+
+	instanceCountMetric, err := job.processor.metricProvider.GetMetric(ctx, newRequest(
+		"cloudfunctions.googleapis.com/function/active_instances", monitoringpb.Aggregation_ALIGN_MEAN, monitoringpb.Aggregation_REDUCE_SUM))
+	if err != nil {
+		return nil, err
+	}
+
+	requestCountMetric, err := job.processor.metricProvider.GetMetric(ctx, newRequest(
+		"cloudfunctions.googleapis.com/function/execution_count", monitoringpb.Aggregation_ALIGN_RATE, monitoringpb.Aggregation_REDUCE_SUM))
+	if err != nil {
+		return nil, err
+	}
+
+	// execution times are in nanoseconds, 1st gen functions are billed for the time their executions run
+	executionTimeMetric, err := job.processor.metricProvider.GetMetric(ctx, newRequest(
+		"cloudfunctions.googleapis.com/function/execution_times", monitoringpb.Aggregation_ALIGN_MEAN, monitoringpb.Aggregation_REDUCE_MEAN))
+	if err != nil {
+		return nil, err
+	}
+
+	serviceMetrics := make(map[string][]*golang2.DataPoint)
+
+	serviceMetrics["MemoryUtilization"] = scaleDataPoints(memoryMetric, 1/memoryBytes)
+	serviceMetrics["MemoryUtilizationP99"] = scaleDataPoints(memoryPeakMetric, 1/memoryBytes)
+	serviceMetrics["InstanceCount/active"] = instanceCountMetric
+	serviceMetrics["RequestCount"] = requestCountMetric
+	serviceMetrics["ExecutionTime"] = scaleDataPoints(executionTimeMetric, 1e-9)
+	return serviceMetrics, nil
+}
+
+func scaleDataPoints(dps []*golang2.DataPoint, factor float64) []*golang2.DataPoint {
+	scaled := make([]*golang2.DataPoint, 0, len(dps))
+	for _, dp := range dps {
+		scaled = append(scaled, &golang2.DataPoint{
+			StartTime: dp.GetStartTime(),
+			EndTime:   dp.GetEndTime(),
+			Value:     dp.GetValue() * factor,
+		})
+	}
+	return scaled
+}
@@ -0,0 +1,53 @@
+package cloud_run
+
+import (
+	"context"
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+)
+
+type OptimizeCloudRunServiceJob struct {
+	processor *CloudRunProcessor
+	itemId    string
+}
+
+func NewOptimizeCloudRunServiceJob(processor *CloudRunProcessor, itemId string) *OptimizeCloudRunServiceJob {
+	return &OptimizeCloudRunServiceJob{
+		processor: processor,
+		itemId:    itemId,
+	}
+}
+
+func (job *OptimizeCloudRunServiceJob) Properties() sdk.JobProperties {
+	return sdk.JobProperties{
+		ID:          fmt.Sprintf("optimize_cloud_run_service_%s", job.itemId),
+		Description: fmt.Sprintf("Optimizing %s", job.itemId),
+		MaxRetry:    3,
+	}
+}
+
+func (job *OptimizeCloudRunServiceJob) Run(ctx context.Context) error {
+	item, ok := job.processor.items.Get(job.itemId)
+	if !ok {
+		return fmt.Errorf("item not found %s", job.itemId)
+	}
+
+	if item.LazyLoadingEnabled {
+		job.processor.jobQueue.Push(NewGetCloudRunServiceMetricsJob(job.processor, job.itemId))
+		return nil
+	}
+
+	item.OptimizationLoading = false
+	item.Skipped = false
+	item.SkipReason = "N/A"
+	item.LazyLoadingEnabled = false
+	// the instances are sized locally, the optimization service has no Cloud Run support
+	item.Wastage = &golang2.GCPCloudRunOptimizationResponse{Rightsizing: recommendService(item)}
+
+	job.processor.items.Set(job.itemId, item)
+	job.processor.publishOptimizationItem(item.ToOptimizationItem())
+	job.processor.UpdateSummary(item.Id)
+
+	return nil
+}
@@ -0,0 +1,367 @@
+package cloud_run
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kaytu-io/kaytu/preferences"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	PlatformCloudRun      = "Cloud Run"
+	PlatformFunctions     = "Cloud Functions"
+	PlatformFunctionsGen1 = "Cloud Functions (1st gen)"
+
+	monthSeconds = 730 * 60 * 60
+	mib          = 1024 * 1024
+	gib          = 1024 * mib
+)
+
+// Cloud Run list prices of us-central1 (tier 1) from https://cloud.google.com/run/pricing, 2nd gen cloud functions
+// are billed as cloud run services
+const (
+	// request-based billing, cpu is only allocated while requests are processed
+	requestCpuPrice    = 0.000024  // per vCPU-second
+	requestMemoryPrice = 0.0000025 // per GiB-second
+	requestPrice       = 0.40      // per million requests
+	// idle min instances of request-based billing
+	idleCpuPrice    = 0.0000025 // per vCPU-second
+	idleMemoryPrice = 0.0000025 // per GiB-second
+	// instance-based billing, cpu is always allocated
+	instanceCpuPrice    = 0.000018 // per vCPU-second
+	instanceMemoryPrice = 0.000002 // per GiB-second
+)
+
+// functionTier is a memory size of 1st gen cloud functions, the cpu is set by the memory. The prices are the
+// tier 1 prices per 100ms of execution from https://cloud.google.com/functions/pricing-1stgen, the cpu is the
+// clock speed of the tier over the 2.4 GHz of a vCPU.
+type functionTier struct {
+	memoryMb int64
+	cpu      float64
+	price    float64
+}
+
+var functionTiers = []functionTier{
+	{memoryMb: 128, cpu: 0.083, price: 0.000000231},
+	{memoryMb: 256, cpu: 0.167, price: 0.000000463},
+	{memoryMb: 512, cpu: 0.333, price: 0.000000925},
+	{memoryMb: 1024, cpu: 0.583, price: 0.000001650},
+	{memoryMb: 2048, cpu: 1, price: 0.000002900},
+	{memoryMb: 4096, cpu: 2, price: 0.000005800},
+	{memoryMb: 8192, cpu: 2, price: 0.000006800},
+}
+
+// functionTierOf returns the smallest tier with at least the memory
+func functionTierOf(memoryMb int64) functionTier {
+	for _, t := range functionTiers {
+		if memoryMb <= t.memoryMb {
+			return t
+		}
+	}
+	return functionTiers[len(functionTiers)-1]
+}
+
+func functionCpu(memoryMb int64) float64 {
+	return functionTierOf(memoryMb).cpu
+}
+
+// wholeCpus are the cpu limits allowed from 1 vCPU up, fractional limits are allowed below
+var wholeCpus = []float64{1, 2, 4, 6, 8}
+
+const (
+	minFractionalCpu = 0.08
+	maxMemoryMb      = 32 * 1024
+)
+
+// minMemoryMb is the smallest memory limit allowed for the cpu and the execution environment
+func minMemoryMb(cpu float64, executionEnvironment string) int64 {
+	switch {
+	case cpu >= 6:
+		return 4096
+	case cpu >= 4:
+		return 2048
+	case executionEnvironment == "EXECUTION_ENVIRONMENT_GEN2":
+		return 512
+	}
+	return 128
+}
+
+// minCpu is the smallest cpu limit allowed for the memory
+func minCpu(memoryMb int64) float64 {
+	switch {
+	case memoryMb > 24*1024:
+		return 8
+	case memoryMb > 16*1024:
+		return 6
+	case memoryMb > 8*1024:
+		return 4
+	case memoryMb > 4*1024:
+		return 2
+	case memoryMb > 1024:
+		return 1
+	case memoryMb > 512:
+		return 0.5
+	}
+	return minFractionalCpu
+}
+
+// roundCpu rounds the cpu up to an allowed limit, fractional limits need a concurrency of 1, the 1st gen
+// execution environment and request-based billing
+func roundCpu(cpu float64, fractional bool) float64 {
+	if fractional && cpu < 1 {
+		return max(math.Ceil(cpu*100)/100, minFractionalCpu)
+	}
+	for _, c := range wholeCpus {
+		if cpu <= c {
+			return c
+		}
+	}
+	return wholeCpus[len(wholeCpus)-1]
+}
+
+// serviceUsage is the usage of a service the sizes are priced on, in instances and requests per second
+type serviceUsage struct {
+	activeInstances float64
+	idleInstances   float64
+	requests        float64
+	// executionSeconds is the mean execution time of 1st gen functions
+	executionSeconds float64
+}
+
+func newServiceUsage(item CloudRunServiceItem) serviceUsage {
+	return serviceUsage{
+		activeInstances:  meanValue(item.Metrics["InstanceCount/active"]),
+		idleInstances:    meanValue(item.Metrics["InstanceCount/idle"]),
+		requests:         meanValue(item.Metrics["RequestCount"]),
+		executionSeconds: meanValue(item.Metrics["ExecutionTime"]),
+	}
+}
+
+// serviceCost is the monthly cost of a service with the usage, the instance count is assumed not to change
+// with the size of the instances
+func serviceCost(item CloudRunServiceItem, service *golang2.RightsizingGcpCloudRunService, usage serviceUsage) float64 {
+	memoryGib := float64(service.MemoryMb) * mib / gib
+	if item.Function != nil {
+		// executions are billed rounded up to 100ms, idle min instances at the cloud run idle rates
+		billed := math.Ceil(usage.executionSeconds*10) / 10
+		idle := min(usage.idleInstances, float64(service.MinInstances))
+		return usage.requests*monthSeconds*billed*10*functionTierOf(service.MemoryMb).price +
+			idle*(service.Cpu*idleCpuPrice+memoryGib*idleMemoryPrice)*monthSeconds +
+			usage.requests*monthSeconds/1e6*requestPrice
+	}
+	if service.CpuAlwaysAllocated {
+		return (usage.activeInstances + usage.idleInstances) * (service.Cpu*instanceCpuPrice + memoryGib*instanceMemoryPrice) * monthSeconds
+	}
+	idle := min(usage.idleInstances, float64(service.MinInstances))
+	return usage.activeInstances*(service.Cpu*requestCpuPrice+memoryGib*requestMemoryPrice)*monthSeconds +
+		idle*(service.Cpu*idleCpuPrice+memoryGib*idleMemoryPrice)*monthSeconds +
+		usage.requests*monthSeconds/1e6*requestPrice
+}
+
+func meanValue(dps []*golang2.DataPoint) float64 {
+	if len(dps) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, dp := range dps {
+		sum += dp.GetValue()
+	}
+	return sum / float64(len(dps))
+}
+
+func maxValue(dps []*golang2.DataPoint) float64 {
+	var m float64
+	for _, dp := range dps {
+		m = max(m, dp.GetValue())
+	}
+	return m
+}
+
+// usage reports the mean of the datapoints as the average and the maximum of the peaks as the max
+func usage(dps, peaks []*golang2.DataPoint) *golang2.Usage {
+	if len(dps) == 0 && len(peaks) == 0 {
+		return nil
+	}
+	if len(peaks) == 0 {
+		peaks = dps
+	}
+	return &golang2.Usage{
+		Avg: wrapperspb.Double(meanValue(dps)),
+		Max: wrapperspb.Double(maxValue(peaks)),
+	}
+}
+
+// sumByTime adds up the datapoints of several series ending at the same time
+func sumByTime(series ...[]*golang2.DataPoint) []*golang2.DataPoint {
+	sums := make(map[int64]*golang2.DataPoint)
+	for _, dps := range series {
+		for _, dp := range dps {
+			end := dp.GetEndTime().GetValue()
+			if sums[end] == nil {
+				sums[end] = &golang2.DataPoint{StartTime: dp.GetStartTime(), EndTime: dp.GetEndTime()}
+			}
+			sums[end].Value += dp.GetValue()
+		}
+	}
+	var dps []*golang2.DataPoint
+	for _, dp := range sums {
+		dps = append(dps, dp)
+	}
+	sort.Slice(dps, func(i, j int) bool {
+		return dps[i].GetEndTime().GetValue() < dps[j].GetEndTime().GetValue()
+	})
+	return dps
+}
+
+func numberPreference(prefs map[string]*string, key string) (float64, bool) {
+	v := prefs[key]
+	if v == nil || *v == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(*v), 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// recommendService sizes the cpu and memory of the instances on their 99th percentile utilization plus the
+// breathing rooms, within the limits cloud run allows. 1st gen functions are sized on their memory only,
+// their cpu follows the memory tier.
+func recommendService(item CloudRunServiceItem) *golang2.GcpCloudRunServiceRecommendation {
+	prefs := preferences.Export(item.Preferences)
+	usageRates := newServiceUsage(item)
+
+	current := &golang2.RightsizingGcpCloudRunService{
+		Region:             item.Region,
+		Cpu:                item.Cpu,
+		MemoryMb:           item.MemoryMb,
+		Concurrency:        item.Concurrency,
+		MinInstances:       item.MinInstances,
+		MaxInstances:       item.MaxInstances,
+		CpuAlwaysAllocated: item.CpuAlwaysAllocated,
+	}
+	current.Cost = serviceCost(item, current, usageRates)
+
+	var instanceCounts [][]*golang2.DataPoint
+	for key, dps := range item.Metrics {
+		if strings.HasPrefix(key, "InstanceCount/") {
+			instanceCounts = append(instanceCounts, dps)
+		}
+	}
+	recommendation := &golang2.GcpCloudRunServiceRecommendation{
+		Current:       current,
+		Cpu:           usage(item.Metrics["CPUUtilization"], item.Metrics["CPUUtilizationP99"]),
+		Memory:        usage(item.Metrics["MemoryUtilization"], item.Metrics["MemoryUtilizationP99"]),
+		InstanceCount: usage(sumByTime(instanceCounts...), nil),
+		RequestCount:  usage(item.Metrics["RequestCount"], nil),
+		Concurrency:   usage(item.Metrics["MaxRequestConcurrency"], nil),
+	}
+	if recommendation.Memory == nil {
+		recommendation.Description = "No memory usage was reported, the service has not run in the last day."
+		return recommendation
+	}
+
+	pinned := make(map[string]bool)
+	for _, p := range item.Preferences {
+		pinned[p.Key] = p.Pinned
+	}
+	cpuBreathingRoom, ok := numberPreference(prefs, "CPUBreathingRoom")
+	if !ok {
+		cpuBreathingRoom = 10
+	}
+	memoryBreathingRoom, ok := numberPreference(prefs, "MemoryBreathingRoom")
+	if !ok {
+		memoryBreathingRoom = 10
+	}
+
+	recommended := &golang2.RightsizingGcpCloudRunService{
+		Region:             item.Region,
+		Concurrency:        item.Concurrency,
+		MinInstances:       item.MinInstances,
+		MaxInstances:       item.MaxInstances,
+		CpuAlwaysAllocated: item.CpuAlwaysAllocated,
+	}
+	if v, ok := numberPreference(prefs, "Concurrency"); ok && item.Function == nil {
+		recommended.Concurrency = int64(v)
+	}
+	if v, ok := numberPreference(prefs, "MinInstances"); ok {
+		recommended.MinInstances = int64(v)
+	}
+	if v := prefs["CPUAlwaysAllocated"]; v != nil && *v != "" && item.Function == nil {
+		recommended.CpuAlwaysAllocated = *v == "Yes"
+	}
+
+	memoryMb := int64(math.Ceil(recommendation.Memory.GetMax().GetValue() * float64(item.MemoryMb) * (1 + memoryBreathingRoom/100)))
+	if v, ok := numberPreference(prefs, "MemoryMb"); ok {
+		memoryMb = int64(v)
+	}
+	if pinned["MemoryMb"] {
+		memoryMb = item.MemoryMb
+	}
+
+	var description string
+	if item.Function != nil {
+		if v, ok := numberPreference(prefs, "vCPU"); ok {
+			for _, t := range functionTiers {
+				if t.cpu >= v {
+					memoryMb = max(memoryMb, t.memoryMb)
+					break
+				}
+			}
+		}
+		if pinned["vCPU"] {
+			memoryMb = max(memoryMb, item.MemoryMb)
+		}
+		tier := functionTierOf(memoryMb)
+		recommended.MemoryMb = tier.memoryMb
+		recommended.Cpu = tier.cpu
+		recommended.Concurrency = 1
+		description = fmt.Sprintf("The function peaks at %.0f%% of %d MB. The cpu of 1st gen functions follows their memory, executions may take longer with less memory.",
+			recommendation.Memory.GetMax().GetValue()*100, item.MemoryMb)
+	} else {
+		cpu := item.Cpu
+		if recommendation.Cpu != nil {
+			cpu = recommendation.Cpu.GetMax().GetValue() * item.Cpu * (1 + cpuBreathingRoom/100)
+		}
+		if v, ok := numberPreference(prefs, "vCPU"); ok {
+			cpu = v
+		}
+		if pinned["vCPU"] {
+			cpu = item.Cpu
+		}
+		fractional := recommended.Concurrency <= 1 && !recommended.CpuAlwaysAllocated &&
+			item.ExecutionEnvironment != "EXECUTION_ENVIRONMENT_GEN2"
+		memoryMb = min(max(int64(math.Ceil(float64(memoryMb)/128))*128, minMemoryMb(0, item.ExecutionEnvironment)), maxMemoryMb)
+		cpu = roundCpu(max(cpu, minCpu(memoryMb)), fractional)
+		memoryMb = max(memoryMb, minMemoryMb(cpu, item.ExecutionEnvironment))
+		recommended.Cpu = cpu
+		recommended.MemoryMb = memoryMb
+		description = fmt.Sprintf("The instances peak at %.0f%% of %d MB.", recommendation.Memory.GetMax().GetValue()*100, item.MemoryMb)
+		if recommendation.Cpu != nil {
+			description = fmt.Sprintf("The instances peak at %.0f%% of %.2f vCPU and %.0f%% of %d MB.",
+				recommendation.Cpu.GetMax().GetValue()*100, item.Cpu, recommendation.Memory.GetMax().GetValue()*100, item.MemoryMb)
+		}
+		if recommended.CpuAlwaysAllocated != item.CpuAlwaysAllocated {
+			if recommended.CpuAlwaysAllocated {
+				description += " Always allocate the cpu, the instances are busy most of the time."
+			} else {
+				description += " Only allocate the cpu while processing requests, background work stops between requests."
+			}
+		}
+	}
+	recommended.Cost = serviceCost(item, recommended, usageRates)
+
+	if recommended.Cost >= current.Cost-0.01 {
+		recommendation.Description = description + " The instances fit their usage."
+		return recommendation
+	}
+	recommendation.Recommended = recommended
+	recommendation.Description = description
+	return recommendation
+}
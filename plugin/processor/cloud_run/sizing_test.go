@@ -0,0 +1,126 @@
+package cloud_run
+
+import (
+	"testing"
+
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/api/cloudfunctions/v1"
+)
+
+func series(values ...float64) []*golang2.DataPoint {
+	var dps []*golang2.DataPoint
+	for _, v := range values {
+		dps = append(dps, &golang2.DataPoint{Value: v})
+	}
+	return dps
+}
+
+func testService(cpu float64, memoryMb, concurrency int64, cpuPeak, memoryPeak float64, prefs ...*golang.PreferenceItem) CloudRunServiceItem {
+	return CloudRunServiceItem{
+		Region:      "us-central1",
+		Platform:    PlatformCloudRun,
+		Cpu:         cpu,
+		MemoryMb:    memoryMb,
+		Concurrency: concurrency,
+		Preferences: prefs,
+		Metrics: map[string][]*golang2.DataPoint{
+			"CPUUtilization":       series(cpuPeak / 2),
+			"CPUUtilizationP99":    series(cpuPeak),
+			"MemoryUtilization":    series(memoryPeak / 2),
+			"MemoryUtilizationP99": series(memoryPeak),
+			"InstanceCount/active": series(2, 4),
+			"RequestCount":         series(10),
+		},
+	}
+}
+
+func TestRecommendService(t *testing.T) {
+	function := testService(functionCpu(1024), 1024, 1, 0, 0.2)
+	function.Platform = PlatformFunctionsGen1
+	function.Function = &cloudfunctions.CloudFunction{AvailableMemoryMb: 1024}
+	function.Metrics["ExecutionTime"] = series(0.25)
+	delete(function.Metrics, "CPUUtilization")
+	delete(function.Metrics, "CPUUtilizationP99")
+
+	cases := []struct {
+		name         string
+		item         CloudRunServiceItem
+		wantCpu      float64
+		wantMemoryMb int64
+	}{
+		{
+			name:         "concurrent service keeps a whole vCPU",
+			item:         testService(2, 4096, 80, 0.2, 0.2),
+			wantCpu:      1,
+			wantMemoryMb: 1024,
+		},
+		{
+			name:         "single request service gets a fractional vCPU",
+			item:         testService(1, 512, 1, 0.3, 0.5),
+			wantCpu:      0.33,
+			wantMemoryMb: 384,
+		},
+		{
+			name: "pinned vCPU is kept",
+			item: testService(2, 4096, 80, 0.2, 0.2,
+				&golang.PreferenceItem{Key: "vCPU", Pinned: true}),
+			wantCpu:      2,
+			wantMemoryMb: 1024,
+		},
+		{
+			name:         "memory above 4 GiB needs 2 vCPU",
+			item:         testService(4, 16384, 80, 0.1, 0.3),
+			wantCpu:      2,
+			wantMemoryMb: 5504,
+		},
+		{
+			name:         "1st gen function moves to a smaller memory tier",
+			item:         function,
+			wantCpu:      0.167,
+			wantMemoryMb: 256,
+		},
+		{
+			name: "busy service fits",
+			item: testService(1, 512, 80, 0.9, 0.9),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := recommendService(c.item)
+			if c.wantMemoryMb == 0 {
+				if r.Recommended != nil {
+					t.Fatalf("expected no recommendation, got %v", r.Recommended)
+				}
+				return
+			}
+			if r.Recommended == nil {
+				t.Fatalf("expected a recommendation: %s", r.Description)
+			}
+			if r.Recommended.Cpu != c.wantCpu || r.Recommended.MemoryMb != c.wantMemoryMb {
+				t.Errorf("recommended %.3f vCPU %d MB, want %.3f vCPU %d MB", r.Recommended.Cpu, r.Recommended.MemoryMb, c.wantCpu, c.wantMemoryMb)
+			}
+			if r.Recommended.Cost >= r.Current.Cost {
+				t.Errorf("recommended cost %v is not below current cost %v", r.Recommended.Cost, r.Current.Cost)
+			}
+		})
+	}
+}
+
+func TestRoundCpu(t *testing.T) {
+	for _, c := range []struct {
+		cpu        float64
+		fractional bool
+		want       float64
+	}{
+		{0.01, true, 0.08},
+		{0.444, true, 0.45},
+		{0.444, false, 1},
+		{2.5, false, 4},
+		{12, false, 8},
+	} {
+		if got := roundCpu(c.cpu, c.fractional); got != c.want {
+			t.Errorf("roundCpu(%v, %v) = %v, want %v", c.cpu, c.fractional, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,6 @@
+package cloud_run
+
+type CloudRunServiceSummary struct {
+	CurrentRuntimeCost float64
+	Savings            float64
+}
@@ -0,0 +1,115 @@
+package compute_instance
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/kaytu-io/kaytu/preferences"
+	"github.com/opengovern/plugin-gcp/plugin/processor/shared"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	defaultGPUBreathingRoom = 10
+	// gpuIdleUtilization is the utilization percentage of the busiest GPU below which the GPUs are dropped
+	gpuIdleUtilization = 1
+)
+
+// n1AcceleratorMaxCpus are the GPU counts attachable to N1 machine types and the most vCPUs allowed with
+// each count, https://cloud.google.com/compute/docs/gpus
+var n1AcceleratorMaxCpus = map[string]map[int32]int64{
+	"nvidia-tesla-t4":   {1: 48, 2: 48, 4: 96},
+	"nvidia-tesla-p4":   {1: 24, 2: 48, 4: 96},
+	"nvidia-tesla-v100": {1: 12, 2: 24, 4: 48, 8: 96},
+	"nvidia-tesla-p100": {1: 16, 2: 32, 4: 64},
+}
+
+// gpuUsage returns the average, maximum and percentiles of the GPU datapoints, nil without datapoints
+func gpuUsage(dps []*golang2.DataPoint) *golang2.Usage {
+	if len(dps) == 0 {
+		return nil
+	}
+	var sum float64
+	maxValue := dps[0].GetValue()
+	for _, dp := range dps {
+		sum += dp.GetValue()
+		maxValue = max(maxValue, dp.GetValue())
+	}
+	usage := &golang2.Usage{
+		Avg: wrapperspb.Double(sum / float64(len(dps))),
+		Max: wrapperspb.Double(maxValue),
+	}
+	shared.AddPercentiles(usage, dps, dps, "")
+	return usage
+}
+
+// recommendAccelerators sets the GPU usage of the instance from the collected datapoints and decides whether
+// its GPUs are kept, reduced or dropped. The GPUs attached to N1 machine types are reduced to the fewest
+// that cover the busiest GPU's utilization plus the GPUBreathingRoom and the recommended vCPUs, and dropped
+// when they are idle. The GPUs built into accelerator-optimized machine types and GPUs without datapoints
+// are kept.
+func recommendAccelerators(item *ComputeInstanceItem) {
+	rightsizing := item.Wastage.GetRightsizing()
+	if rightsizing == nil || len(item.Accelerators) == 0 {
+		return
+	}
+	rightsizing.Gpu = gpuUsage(item.Metrics["gpuUtilization"])
+	rightsizing.GpuMemory = gpuUsage(item.Metrics["gpuMemoryUsage"])
+
+	recommended := rightsizing.Recommended
+	if recommended == nil {
+		return
+	}
+	recommended.Accelerators = item.Accelerators
+	if rightsizing.Gpu == nil || len(item.Accelerators) != 1 || machineFamily(recommended.MachineType) != "n1" {
+		return
+	}
+	accelerator := item.Accelerators[0]
+	maxCpus, ok := n1AcceleratorMaxCpus[accelerator.AcceleratorType]
+	if !ok || accelerator.Count == 0 {
+		return
+	}
+
+	breathingRoom := float64(defaultGPUBreathingRoom)
+	if v := preferences.Export(item.Preferences)["GPUBreathingRoom"]; v != nil {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(*v), 64); err == nil {
+			breathingRoom = f
+		}
+	}
+	// the busiest GPU bounds the utilization of all of them
+	utilization := rightsizing.Gpu.GetMax().GetValue() * (1 + breathingRoom/100)
+
+	count := accelerator.Count
+	if utilization < gpuIdleUtilization {
+		count = 0
+	} else {
+		needed := int32(math.Ceil(float64(accelerator.Count) * utilization / 100))
+		for c, cpus := range maxCpus {
+			if c >= needed && c < count && recommended.Cpu <= cpus {
+				count = c
+			}
+		}
+	}
+	if count == accelerator.Count {
+		return
+	}
+
+	perGpuCost := rightsizing.GetCurrent().GetAcceleratorsCost() / float64(accelerator.Count)
+	acceleratorsCost := perGpuCost * float64(count)
+	recommended.Cost += acceleratorsCost - recommended.AcceleratorsCost
+	recommended.AcceleratorsCost = acceleratorsCost
+	if count == 0 {
+		recommended.Accelerators = nil
+		rightsizing.Description = strings.TrimSpace(rightsizing.Description + fmt.Sprintf(
+			"\nThe %s GPUs are idle, the busiest GPU peaked at %.1f%% utilization.",
+			accelerator.AcceleratorType, rightsizing.Gpu.GetMax().GetValue()))
+		return
+	}
+	recommended.Accelerators = []*golang2.GcpComputeAccelerator{{AcceleratorType: accelerator.AcceleratorType, Count: count}}
+	rightsizing.Description = strings.TrimSpace(rightsizing.Description + fmt.Sprintf(
+		"\n%d %s GPUs cover the busiest GPU's %.1f%% peak utilization of the %d attached with the breathing room.",
+		count, accelerator.AcceleratorType, rightsizing.Gpu.GetMax().GetValue(), accelerator.Count))
+}
@@ -0,0 +1,83 @@
+package compute_instance
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestRecommendAccelerators(t *testing.T) {
+	utilization := func(values ...float64) []*golang2.DataPoint {
+		var dps []*golang2.DataPoint
+		for i, v := range values {
+			dps = append(dps, &golang2.DataPoint{StartTime: wrapperspb.Int64(int64(i) * 60), EndTime: wrapperspb.Int64(int64(i)*60 + 60), Value: v})
+		}
+		return dps
+	}
+
+	tests := []struct {
+		name         string
+		machineType  string
+		cpu          int64
+		accelerator  string
+		count        int32
+		gpu          []*golang2.DataPoint
+		want         int32
+		wantCost     float64
+		wantMaxUsage float64
+	}{
+		{name: "no datapoints", machineType: "n1-standard-8", cpu: 8, accelerator: "nvidia-tesla-t4", count: 4, want: 4, wantCost: 600},
+		{name: "busy", machineType: "n1-standard-8", cpu: 8, accelerator: "nvidia-tesla-t4", count: 4,
+			gpu: utilization(50, 95), want: 4, wantCost: 600, wantMaxUsage: 95},
+		// 4 GPUs at 40% with the breathing room fit 2
+		{name: "reduced", machineType: "n1-standard-8", cpu: 8, accelerator: "nvidia-tesla-t4", count: 4,
+			gpu: utilization(20, 40), want: 2, wantCost: 400, wantMaxUsage: 40},
+		// 3 V100 GPUs are not attachable, the next count is 4
+		{name: "valid counts", machineType: "n1-standard-8", cpu: 8, accelerator: "nvidia-tesla-v100", count: 8,
+			gpu: utilization(30, 35), want: 4, wantCost: 400, wantMaxUsage: 35},
+		// a single V100 allows at most 12 vCPUs
+		{name: "vCPU limit", machineType: "n1-standard-16", cpu: 16, accelerator: "nvidia-tesla-v100", count: 2,
+			gpu: utilization(10, 20), want: 2, wantCost: 600, wantMaxUsage: 20},
+		{name: "idle", machineType: "n1-standard-8", cpu: 8, accelerator: "nvidia-tesla-t4", count: 2,
+			gpu: utilization(0, 0.5), want: 0, wantCost: 200, wantMaxUsage: 0.5},
+		{name: "accelerator-optimized", machineType: "a2-highgpu-1g", cpu: 12, accelerator: "nvidia-tesla-a100", count: 1,
+			gpu: utilization(0, 0), want: 1, wantCost: 600},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accelerators := []*golang2.GcpComputeAccelerator{{AcceleratorType: tt.accelerator, Count: tt.count}}
+			item := ComputeInstanceItem{
+				Accelerators: accelerators,
+				Preferences:  []*golang.PreferenceItem{{Key: "GPUBreathingRoom", Value: wrapperspb.String("10")}},
+				Metrics:      map[string][]*golang2.DataPoint{"gpuUtilization": tt.gpu},
+				Wastage: &golang2.GCPComputeOptimizationResponse{
+					Rightsizing: &golang2.GcpComputeInstanceRightsizingRecommendation{
+						Current: &golang2.RightsizingGcpComputeInstance{MachineType: tt.machineType, Cpu: tt.cpu,
+							Cost: 600, AcceleratorsCost: 400, Accelerators: accelerators},
+						Recommended: &golang2.RightsizingGcpComputeInstance{MachineType: tt.machineType, Cpu: tt.cpu,
+							Cost: 600, AcceleratorsCost: 400},
+					},
+				},
+			}
+
+			recommendAccelerators(&item)
+			recommended := item.Wastage.Rightsizing.Recommended
+			var got int32
+			for _, a := range recommended.Accelerators {
+				got += a.Count
+			}
+			if got != tt.want {
+				t.Errorf("%d GPUs, want %d", got, tt.want)
+			}
+			if math.Abs(recommended.Cost-tt.wantCost) > 1e-9 {
+				t.Errorf("cost %v, want %v", recommended.Cost, tt.wantCost)
+			}
+			if gpu := item.Wastage.Rightsizing.Gpu; tt.gpu != nil && gpu.GetMax().GetValue() != tt.wantMaxUsage {
+				t.Errorf("max usage %v, want %v", gpu.GetMax().GetValue(), tt.wantMaxUsage)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package compute_instance
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kaytu-io/kaytu/preferences"
+	util "github.com/opengovern/plugin-gcp/utils"
+)
+
+const (
+	ArmCompatibilityLow          = "Low Risk"
+	ArmCompatibilityMedium       = "Medium Risk"
+	ArmCompatibilityHigh         = "High Risk"
+	ArmCompatibilityIncompatible = "Incompatible"
+)
+
+// armFamily are the shapes of an Arm machine family, its prices and regions are the ones of the Cloud Billing Catalog
+type armFamily struct {
+	cpuCounts []int64
+	// memoryPerCpuGb are the memory per vCPU of the machine types of the family, by type name
+	memoryPerCpuGb map[string]int64
+}
+
+var armFamilies = map[string]armFamily{
+	"t2a": {
+		cpuCounts:      []int64{1, 2, 4, 8, 16, 32, 48},
+		memoryPerCpuGb: map[string]int64{"standard": 4},
+	},
+	"c4a": {
+		cpuCounts:      []int64{1, 2, 4, 8, 16, 32, 48, 64, 72},
+		memoryPerCpuGb: map[string]int64{"highcpu": 2, "standard": 4, "highmem": 8},
+	},
+}
+
+// vCpuPerformance is the throughput of a vCPU of the family relative to an N1 vCPU, from the per vCPU
+// CoreMark scores published in https://cloud.google.com/compute/docs/coremark-scores-of-vm-instances rounded
+// to a tenth. The vCPUs of t2d, t2a and c4a are whole cores rather than hyperthreads.
+var vCpuPerformance = map[string]float64{
+	"n1":  1.0,
+	"e2":  1.0,
+	"n2":  1.2,
+	"n2d": 1.2,
+	"n4":  1.4,
+	"c2":  1.3,
+	"c2d": 1.5,
+	"c3":  1.4,
+	"c3d": 1.5,
+	"c4":  1.6,
+	"m1":  1.0,
+	"m2":  1.0,
+	"m3":  1.3,
+	"t2d": 1.9,
+	"t2a": 1.5,
+	"c4a": 2.0,
+}
+
+// ArmAssessment is the cheapest Arm machine type fitting the recommended (or current) shape of an x86 instance,
+// with its list price and vCPU price-performance compared to the current machine type and the risks of the migration
+type ArmAssessment struct {
+	MachineType string  `json:"machine_type"`
+	Cpu         int64   `json:"cpu"`
+	MemoryMb    int64   `json:"memory_mb"`
+	Cost        float64 `json:"cost"`
+	Saving      float64 `json:"saving"`
+	// PricePerformanceGain is the increase of the vCPU performance per dollar over the current machine type,
+	// 0.3 being 30% more, nil when the performance of the current family is not known
+	PricePerformanceGain *float64 `json:"price_performance_gain,omitempty"`
+	Compatibility        string   `json:"compatibility"`
+	Risks                []string `json:"risks,omitempty"`
+}
+
+// armOsImages are the boot disk licenses of operating systems with arm64 images, the ones matching
+// armOsOldReleases have no arm64 image for their release
+var (
+	armOsImages      = regexp.MustCompile(`(debian|ubuntu|rhel|sles|rocky-linux|centos-stream|cos|fedora|almalinux)`)
+	armOsOldReleases = regexp.MustCompile(`(debian-(8|9|10)|ubuntu-(14|16|18)|rhel-(6|7)|sles-12|centos-(6|7))`)
+)
+
+// armOsCompatibility judges whether the OS of the boot disk has arm64 images, from the OS mapped by
+// mapImageToOS and the boot disk licenses
+func armOsCompatibility(os string, licenses []string) (string, string) {
+	license := ""
+	if len(licenses) > 0 {
+		license = util.TrimmedString(licenses[0], "/")
+	}
+	switch {
+	case os == "windows":
+		return ArmCompatibilityIncompatible, "Windows Server has no Arm images on Compute Engine"
+	case strings.HasSuffix(os, "-sap"):
+		return ArmCompatibilityIncompatible, "SAP images are only certified on x86"
+	case armOsOldReleases.MatchString(license):
+		return ArmCompatibilityHigh, fmt.Sprintf("%s has no arm64 image, the OS has to be upgraded first", license)
+	case armOsImages.MatchString(license):
+		return ArmCompatibilityLow, fmt.Sprintf("%s has arm64 images", license)
+	}
+	return ArmCompatibilityMedium, "the OS could not be identified from the boot disk licenses"
+}
+
+// assessArm evaluates moving the instance to the Arm families when the ArmAssessment preference allows it,
+// nil when the instance is already on Arm or the catalog has no Arm price in its region. The families and
+// shapes are tried in order, the first of equally priced machine types is kept.
+func assessArm(ctx context.Context, prices *priceBook, item ComputeInstanceItem) *ArmAssessment {
+	rightsizing := item.Wastage.GetRightsizing()
+	if rightsizing == nil || rightsizing.Current == nil {
+		return nil
+	}
+	if v := preferences.Export(item.Preferences)["ArmAssessment"]; v == nil || *v != "Yes" {
+		return nil
+	}
+	current := rightsizing.Current
+	if _, ok := armFamilies[machineFamily(current.MachineType)]; ok {
+		return nil
+	}
+	target := current
+	if rightsizing.Recommended != nil {
+		target = rightsizing.Recommended
+	}
+
+	region := zoneToRegion(current.Zone)
+	if region == "" {
+		region = zoneToRegion(item.Region)
+	}
+	var best *ArmAssessment
+	for _, name := range sortedKeys(armFamilies) {
+		family := armFamilies[name]
+		familyPrices, ok := prices.Instance(ctx, name, region, current.Preemptible)
+		if !ok {
+			continue
+		}
+		for _, shape := range sortedKeys(family.memoryPerCpuGb) {
+			memoryPerCpu := family.memoryPerCpuGb[shape]
+			for _, cpu := range family.cpuCounts {
+				memoryMb := cpu * memoryPerCpu * 1024
+				if cpu < target.Cpu || memoryMb < target.MemoryMb {
+					continue
+				}
+				cost := familyPrices.Hourly(cpu, memoryMb)*monthHours + current.OsLicenseCost
+				if best == nil || cost < best.Cost {
+					best = &ArmAssessment{
+						MachineType: fmt.Sprintf("%s-%s-%d", name, shape, cpu),
+						Cpu:         cpu,
+						MemoryMb:    memoryMb,
+						Cost:        cost,
+					}
+				}
+				break
+			}
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	best.Saving = current.Cost - best.Cost
+	best.PricePerformanceGain = pricePerformanceGain(current.MachineType, current.Cpu, current.Cost, best.MachineType, best.Cpu, best.Cost)
+
+	var licenses []string
+	for _, d := range item.Instance.GetDisks() {
+		if d.GetBoot() {
+			licenses = d.GetLicenses()
+		}
+	}
+	compatibility, osRisk := armOsCompatibility(item.InstanceOsLicense, licenses)
+	best.Compatibility = compatibility
+	best.Risks = append(best.Risks, osRisk)
+	if len(item.Accelerators) > 0 {
+		best.Compatibility = ArmCompatibilityIncompatible
+		best.Risks = append(best.Risks, "Arm machine types do not support GPUs")
+	}
+	if best.Compatibility != ArmCompatibilityIncompatible {
+		best.Risks = append(best.Risks,
+			"the boot disk has to be recreated from an arm64 image, x86 binaries and container images have to be rebuilt for arm64")
+	}
+	return best
+}
+
+// pricePerformanceGain compares the vCPU performance per dollar of the machine types, nil when the performance
+// of either family is not known
+func pricePerformanceGain(currentType string, currentCpu int64, currentCost float64, armType string, armCpu int64, armCost float64) *float64 {
+	currentPerformance, ok := vCpuPerformance[machineFamily(currentType)]
+	armPerformance, armOk := vCpuPerformance[machineFamily(armType)]
+	if !ok || !armOk || currentCpu <= 0 || currentCost <= 0 || armCost <= 0 {
+		return nil
+	}
+	gain := (float64(armCpu)*armPerformance/armCost)/(float64(currentCpu)*currentPerformance/currentCost) - 1
+	return &gain
+}
+
+func (a *ArmAssessment) PricePerformanceString() string {
+	if a.PricePerformanceGain == nil {
+		return "N/A"
+	}
+	return fmt.Sprintf("%+.0f%%", *a.PricePerformanceGain*100)
+}
+
+// sortedKeys returns the keys of the map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
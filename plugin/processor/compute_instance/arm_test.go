@@ -0,0 +1,101 @@
+package compute_instance
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestAssessArm(t *testing.T) {
+	// the catalog prices are cached, families without a cached price are looked up with no pricing client
+	prices := newPriceBook(nil)
+	for key, p := range map[string]resourcePrices{
+		"t2a/us-central1/" + gcp.UsageOnDemand:    {Cpu: 0.02, MemoryGb: 0.004},
+		"c4a/us-central1/" + gcp.UsageOnDemand:    {Cpu: 0.02, MemoryGb: 0.004},
+		"c4a/us-central1/" + gcp.UsagePreemptible: {Cpu: 0.01, MemoryGb: 0.001},
+	} {
+		prices.prices[key] = catalogPrices{resourcePrices: p, Found: true}
+	}
+
+	tests := []struct {
+		name        string
+		machineType string
+		zone        string
+		preemptible bool
+		want        string
+		wantCost    float64
+	}{
+		// t2a-standard-4 and c4a-standard-4 cost the same, the first family in order is kept
+		{name: "equal prices", machineType: "n2-standard-4", zone: "us-central1-a", want: "c4a-standard-4",
+			wantCost: (4*0.02 + 16*0.004) * monthHours},
+		{name: "spot", machineType: "n2-standard-4", zone: "us-central1-a", preemptible: true, want: "c4a-standard-4",
+			wantCost: (4*0.01 + 16*0.001) * monthHours},
+		{name: "no price in the region", machineType: "n2-standard-4", zone: "europe-west1-b"},
+		{name: "already on Arm", machineType: "t2a-standard-4", zone: "us-central1-a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := &golang2.RightsizingGcpComputeInstance{MachineType: tt.machineType, Zone: tt.zone,
+				Cpu: 4, MemoryMb: 16384, Cost: 200, Preemptible: tt.preemptible}
+			item := ComputeInstanceItem{
+				Preferences: []*golang.PreferenceItem{{Key: "ArmAssessment", Value: wrapperspb.String("Yes")}},
+				Wastage: &golang2.GCPComputeOptimizationResponse{
+					Rightsizing: &golang2.GcpComputeInstanceRightsizingRecommendation{Current: current},
+				},
+			}
+			for i := 0; i < 10; i++ {
+				assessment := assessArm(context.Background(), prices, item)
+				got := ""
+				if assessment != nil {
+					got = assessment.MachineType
+				}
+				if got != tt.want {
+					t.Fatalf("machine type %q, want %q", got, tt.want)
+				}
+				if assessment != nil && (math.Abs(assessment.Cost-tt.wantCost) > 1e-9 || math.Abs(assessment.Saving-(200-tt.wantCost)) > 1e-9) {
+					t.Fatalf("cost %v saving %v, want %v", assessment.Cost, assessment.Saving, tt.wantCost)
+				}
+				// a c4a vCPU performs as 2 N1 vCPUs and an n2 one as 1.2
+				if wantGain := (4*2.0/tt.wantCost)/(4*1.2/200) - 1; assessment != nil &&
+					(assessment.PricePerformanceGain == nil || math.Abs(*assessment.PricePerformanceGain-wantGain) > 1e-9) {
+					t.Fatalf("price-performance %s, want %+.0f%%", assessment.PricePerformanceString(), wantGain*100)
+				}
+			}
+		})
+	}
+}
+
+func TestPricePerformanceGain(t *testing.T) {
+	tests := []struct {
+		name        string
+		currentType string
+		currentCpu  int64
+		currentCost float64
+		armType     string
+		armCpu      int64
+		armCost     float64
+		want        string
+	}{
+		{name: "same price", currentType: "n1-standard-4", currentCpu: 4, currentCost: 100, armType: "t2a-standard-4", armCpu: 4, armCost: 100, want: "+50%"},
+		{name: "cheaper", currentType: "n2-standard-8", currentCpu: 8, currentCost: 240, armType: "c4a-standard-8", armCpu: 8, armCost: 200, want: "+100%"},
+		// t2d vCPUs are whole cores too
+		{name: "slower", currentType: "t2d-standard-4", currentCpu: 4, currentCost: 100, armType: "t2a-standard-4", armCpu: 4, armCost: 100, want: "-21%"},
+		{name: "more vCPUs", currentType: "n1-standard-2", currentCpu: 2, currentCost: 50, armType: "t2a-standard-4", armCpu: 4, armCost: 100, want: "+50%"},
+		{name: "custom n1", currentType: "custom-4-8192", currentCpu: 4, currentCost: 100, armType: "c4a-highcpu-4", armCpu: 4, armCost: 100, want: "+100%"},
+		{name: "unknown family", currentType: "a2-highgpu-1g", currentCpu: 12, currentCost: 2000, armType: "c4a-standard-16", armCpu: 16, armCost: 500, want: "N/A"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assessment := &ArmAssessment{PricePerformanceGain: pricePerformanceGain(tt.currentType, tt.currentCpu, tt.currentCost,
+				tt.armType, tt.armCpu, tt.armCost)}
+			if got := assessment.PricePerformanceString(); got != tt.want {
+				t.Errorf("price-performance %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package compute_instance
+
+import (
+	"context"
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	"github.com/kaytu-io/kaytu/pkg/utils"
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+	"math"
+	"sort"
+)
+
+var commitmentPlans = []struct {
+	Name string
+	Plan string
+}{
+	{Name: "1 Year", Plan: "TWELVE_MONTH"},
+	{Name: "3 Years", Plan: "THIRTY_SIX_MONTH"},
+}
+
+// steadyState is the recommended usage of the instances of a region and commitment type, averaged over the
+// hours of the month
+type steadyState struct {
+	Region         string
+	CommitmentType string
+	MachineFamily  string
+	Cpu            int64
+	MemoryMb       int64
+
+	cpu, memoryMb float64
+}
+
+// runtimeShare is the share of the time the instance runs: its billed runtime when there is a billing export,
+// the hours it reported CPU activity over the schedule detection window otherwise, and always-on when neither
+// is known
+func runtimeShare(item ComputeInstanceItem) float64 {
+	if item.BilledCost != nil {
+		return min(float64(item.BilledCost.RuntimeHours)/(gcp.BillingExportDays*24), 1)
+	}
+	if activity := item.ActivityMetrics["cpuUtilization"]; len(activity) > 0 {
+		return min(float64(len(activity))/scheduleLookback.Hours(), 1)
+	}
+	return 1
+}
+
+// CommitmentPurchase is a suggested resource-based commitment for the usage not covered by existing commitments
+type CommitmentPurchase struct {
+	Region            string
+	CommitmentType    string
+	Plan              string
+	SteadyCpu         int64
+	SteadyMemoryMb    int64
+	CommittedCpu      int64
+	CommittedMemoryMb int64
+	Cpu               int64
+	MemoryMb          int64
+	// OnDemandCost is the monthly cost of the suggested vCPUs and memory without commitment, after sustained use discounts
+	OnDemandCost float64
+	// CommitmentCost is the monthly cost of the suggested commitment
+	CommitmentCost float64
+	// BreakEvenUtilization is the share of the committed resources that must be used for the commitment to pay off
+	BreakEvenUtilization float64
+	// Prices are the on-demand prices of the family in the region, weighing vCPUs against memory in the coverage
+	Prices resourcePrices
+}
+
+func (p CommitmentPurchase) Savings() float64 {
+	return p.OnDemandCost - p.CommitmentCost
+}
+
+func (p CommitmentPurchase) CurrentCoverage() float64 {
+	return coverage(p.Prices, p.CommittedCpu, p.CommittedMemoryMb, p.SteadyCpu, p.SteadyMemoryMb)
+}
+
+func (p CommitmentPurchase) CoverageAfterPurchase() float64 {
+	return coverage(p.Prices, p.CommittedCpu+p.Cpu, p.CommittedMemoryMb+p.MemoryMb, p.SteadyCpu, p.SteadyMemoryMb)
+}
+
+func coverage(prices resourcePrices, cpu, memoryMb, steadyCpu, steadyMemoryMb int64) float64 {
+	total := prices.Hourly(steadyCpu, steadyMemoryMb)
+	if total == 0 {
+		return 0
+	}
+	return prices.Hourly(min(cpu, steadyCpu), min(memoryMb, steadyMemoryMb)) / total * 100
+}
+
+// steadyStates sums the recommended vCPUs and memory of all evaluated, non-preemptible instances
+// per region and commitment type, each weighted by the share of the time the instance runs
+func (m *ComputeInstanceProcessor) steadyStates() []*steadyState {
+	states := make(map[string]*steadyState)
+	m.items.Range(func(_ string, item ComputeInstanceItem) bool {
+		if item.Wastage == nil || item.Wastage.Rightsizing == nil || item.Wastage.Rightsizing.Current == nil {
+			return true
+		}
+		spec := item.Wastage.Rightsizing.Current
+		if item.Wastage.Rightsizing.Recommended != nil {
+			spec = item.Wastage.Rightsizing.Recommended
+		}
+		if spec.Preemptible {
+			return true
+		}
+		family := machineFamily(spec.MachineType)
+		commitmentType, ok := commitmentTypes[family]
+		if !ok {
+			return true
+		}
+		region := zoneToRegion(item.Region)
+		key := fmt.Sprintf("%s/%s", region, commitmentType)
+		state, ok := states[key]
+		if !ok {
+			state = &steadyState{Region: region, CommitmentType: commitmentType, MachineFamily: family}
+			states[key] = state
+		}
+		share := runtimeShare(item)
+		state.cpu += float64(spec.Cpu) * share
+		state.memoryMb += float64(spec.MemoryMb) * share
+		return true
+	})
+
+	var result []*steadyState
+	for _, state := range states {
+		state.Cpu = int64(math.Floor(state.cpu))
+		state.MemoryMb = int64(math.Floor(state.memoryMb))
+		result = append(result, state)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Region != result[j].Region {
+			return result[i].Region < result[j].Region
+		}
+		return result[i].CommitmentType < result[j].CommitmentType
+	})
+	return result
+}
+
+// CommitmentPurchases suggests 1-year and 3-year commitments for the steady-state usage of the fleet
+// exceeding the existing commitments
+func (m *ComputeInstanceProcessor) CommitmentPurchases(ctx context.Context) []CommitmentPurchase {
+	var purchases []CommitmentPurchase
+	for _, state := range m.steadyStates() {
+		committedCpu, committedMemoryMb := m.commitments.Committed(state.Region, state.CommitmentType)
+
+		// vCPUs are committed in whole units and memory in multiples of 256 MB
+		cpu := max(state.Cpu-committedCpu, 0)
+		memoryMb := max(state.MemoryMb-committedMemoryMb, 0) / 256 * 256
+		if cpu == 0 && memoryMb == 0 {
+			continue
+		}
+
+		prices := m.prices.OnDemand(ctx, state.MachineFamily, state.Region)
+		onDemandCost := prices.Hourly(cpu, memoryMb) * monthHours * (1 - sustainedUseDiscounts[state.MachineFamily])
+
+		for _, plan := range commitmentPlans {
+			commitmentCost := m.prices.Committed(ctx, state.MachineFamily, state.Region, plan.Plan).Hourly(cpu, memoryMb) * monthHours
+			if commitmentCost >= onDemandCost {
+				continue
+			}
+			purchases = append(purchases, CommitmentPurchase{
+				Region:               state.Region,
+				CommitmentType:       state.CommitmentType,
+				Plan:                 plan.Name,
+				SteadyCpu:            state.Cpu,
+				SteadyMemoryMb:       state.MemoryMb,
+				CommittedCpu:         committedCpu,
+				CommittedMemoryMb:    committedMemoryMb,
+				Cpu:                  cpu,
+				MemoryMb:             memoryMb,
+				OnDemandCost:         onDemandCost,
+				CommitmentCost:       commitmentCost,
+				BreakEvenUtilization: commitmentCost / onDemandCost * 100,
+				Prices:               prices,
+			})
+		}
+	}
+	return purchases
+}
+
+func (m *ComputeInstanceProcessor) exportCommitmentsCsv() []*golang.CSVRow {
+	purchases := m.CommitmentPurchases(context.Background())
+
+	headers := []string{
+		"Region", "Commitment Type", "Plan", "Steady-state vCPU", "Steady-state Memory (GB)",
+		"Committed vCPU", "Committed Memory (GB)", "Current Coverage", "Suggested vCPU", "Suggested Memory (GB)",
+		"Coverage After Purchase", "On-demand Cost", "Commitment Cost", "Net Savings", "Break-even Utilization",
+	}
+	rows := []*golang.CSVRow{{Row: headers}}
+	for _, p := range purchases {
+		rows = append(rows, &golang.CSVRow{Row: []string{
+			p.Region, p.CommitmentType, p.Plan, fmt.Sprintf("%d", p.SteadyCpu), fmt.Sprintf("%.2f", float64(p.SteadyMemoryMb)/1024),
+			fmt.Sprintf("%d", p.CommittedCpu), fmt.Sprintf("%.2f", float64(p.CommittedMemoryMb)/1024),
+			fmt.Sprintf("%.2f%%", p.CurrentCoverage()), fmt.Sprintf("%d", p.Cpu), fmt.Sprintf("%.2f", float64(p.MemoryMb)/1024),
+			fmt.Sprintf("%.2f%%", p.CoverageAfterPurchase()), utils.FormatPriceFloat(p.OnDemandCost),
+			utils.FormatPriceFloat(p.CommitmentCost), utils.FormatPriceFloat(p.Savings()),
+			fmt.Sprintf("%.2f%%", p.BreakEvenUtilization),
+		}})
+	}
+	return rows
+}
@@ -0,0 +1,42 @@
+package compute_instance
+
+import (
+	"testing"
+
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+)
+
+func TestRuntimeShare(t *testing.T) {
+	activity := make([]*golang2.DataPoint, int(scheduleLookback.Hours())/2)
+
+	tests := []struct {
+		name string
+		item ComputeInstanceItem
+		want float64
+	}{
+		{"unknown runtime", ComputeInstanceItem{}, 1},
+		{"billed half the time", ComputeInstanceItem{BilledCost: &gcp.ResourceCost{RuntimeHours: gcp.BillingExportDays * 12}}, 0.5},
+		{"billed over the window", ComputeInstanceItem{BilledCost: &gcp.ResourceCost{RuntimeHours: gcp.BillingExportDays * 48}}, 1},
+		{"active half the time", ComputeInstanceItem{ActivityMetrics: map[string][]*golang2.DataPoint{"cpuUtilization": activity}}, 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runtimeShare(tt.item); got != tt.want {
+				t.Errorf("runtimeShare = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	if got := coverage(fallbackPrices, 2, 8192, 4, 16384); got != 50 {
+		t.Errorf("coverage = %v, want 50", got)
+	}
+	if got := coverage(fallbackPrices, 8, 32768, 4, 16384); got != 100 {
+		t.Errorf("coverage = %v, want 100", got)
+	}
+	if got := coverage(fallbackPrices, 1, 1024, 0, 0); got != 0 {
+		t.Errorf("coverage = %v, want 0", got)
+	}
+}
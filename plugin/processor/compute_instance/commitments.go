@@ -0,0 +1,256 @@
+package compute_instance
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/api/compute/v1"
+	"sort"
+	"strings"
+	"sync"
+
+	util "github.com/opengovern/plugin-gcp/utils"
+)
+
+const (
+	SavingsTypeRealCash           = "Real Cash"
+	SavingsTypeCommitmentCapacity = "Frees Commitment Capacity"
+	SavingsTypeMixed              = "Real Cash + Frees Commitment Capacity"
+)
+
+// commitmentTypes maps a machine family to the resource-based commitment type that covers it
+var commitmentTypes = map[string]string{
+	"n1":  "GENERAL_PURPOSE",
+	"n2":  "GENERAL_PURPOSE_N2",
+	"n2d": "GENERAL_PURPOSE_N2D",
+	"n4":  "GENERAL_PURPOSE_N4",
+	"e2":  "GENERAL_PURPOSE_E2",
+	"t2d": "GENERAL_PURPOSE_T2D",
+	"c2":  "COMPUTE_OPTIMIZED",
+	"c2d": "COMPUTE_OPTIMIZED_C2D",
+	"c3":  "COMPUTE_OPTIMIZED_C3",
+	"c3d": "COMPUTE_OPTIMIZED_C3D",
+	"h3":  "COMPUTE_OPTIMIZED_H3",
+	"m1":  "MEMORY_OPTIMIZED",
+	"m2":  "MEMORY_OPTIMIZED",
+	"m3":  "MEMORY_OPTIMIZED_M3",
+	"a2":  "ACCELERATOR_OPTIMIZED",
+	"a3":  "ACCELERATOR_OPTIMIZED_A3",
+	"g2":  "GRAPHICS_OPTIMIZED",
+}
+
+// sustainedUseDiscounts is the discount of a machine family running for the whole month
+// (https://cloud.google.com/compute/docs/sustained-use-discounts), families not in the list are not
+// eligible for sustained use discounts
+var sustainedUseDiscounts = map[string]float64{
+	"n1":  0.30,
+	"n2":  0.20,
+	"n2d": 0.20,
+	"c2":  0.20,
+	"m1":  0.30,
+	"m2":  0.30,
+}
+
+// machineFamily returns the family of a predefined or custom machine type, N1 custom machine types
+// have no family prefix
+func machineFamily(machineType string) string {
+	if strings.HasPrefix(machineType, "custom-") {
+		return "n1"
+	}
+	return strings.Split(machineType, "-")[0]
+}
+
+func zoneToRegion(zone string) string {
+	if i := strings.LastIndex(zone, "-"); i > 0 && strings.Count(zone, "-") > 1 {
+		return zone[:i]
+	}
+	return zone
+}
+
+// CommitmentCoverage is the part of an instance's vCPUs and memory covered by active commitments
+type CommitmentCoverage struct {
+	CommitmentType string `json:"commitment_type"`
+	Cpu            int64  `json:"cpu"`
+	MemoryMb       int64  `json:"memory_mb"`
+	// Discount is the average discount of the commitments the coverage is taken from
+	Discount float64 `json:"discount"`
+	// Prices are the on-demand prices of the covered family in the region, weighing the covered vCPUs and memory
+	Prices resourcePrices `json:"-"`
+}
+
+type commitmentPool struct {
+	Cpu      int64
+	MemoryMb int64
+	// Discount is weighted by the on-demand price of the committed resources
+	Discount float64
+	Prices   resourcePrices
+	// onDemand and committed are the hourly on-demand and commitment prices of the committed resources
+	onDemand, committed float64
+}
+
+// commitmentDemand is the vCPUs and memory of a running instance that commitments can cover
+type commitmentDemand struct {
+	ItemId      string
+	Zone        string
+	MachineType string
+	Cpu         int64
+	MemoryMb    int64
+}
+
+// commitmentAllocator spreads the active commitments of the project over its running instances, the way GCP
+// applies them to the eligible usage of a region. All instances are allocated at once, in the order of their
+// ids, so the coverage does not depend on the order the instances are evaluated in.
+type commitmentAllocator struct {
+	lock        sync.Mutex
+	pools       map[string]*commitmentPool
+	committed   map[string]commitmentPool
+	allocations map[string]*CommitmentCoverage
+}
+
+func newCommitmentAllocator(ctx context.Context, commitments []*compute.Commitment, prices *priceBook) *commitmentAllocator {
+	a := &commitmentAllocator{
+		pools:       make(map[string]*commitmentPool),
+		committed:   make(map[string]commitmentPool),
+		allocations: make(map[string]*CommitmentCoverage),
+	}
+	for _, c := range commitments {
+		if c.Status != "ACTIVE" {
+			continue
+		}
+		region := util.TrimmedString(c.Region, "/")
+		family := commitmentFamily(c.Type)
+		key := fmt.Sprintf("%s/%s", region, c.Type)
+		pool, ok := a.pools[key]
+		if !ok {
+			pool = &commitmentPool{Prices: prices.OnDemand(ctx, family, region)}
+			a.pools[key] = pool
+		}
+		committedPrices := prices.Committed(ctx, family, region, c.Plan)
+		for _, r := range c.Resources {
+			switch r.Type {
+			case "VCPU":
+				pool.Cpu += r.Amount
+				pool.onDemand += pool.Prices.Hourly(r.Amount, 0)
+				pool.committed += committedPrices.Hourly(r.Amount, 0)
+			case "MEMORY":
+				pool.MemoryMb += r.Amount
+				pool.onDemand += pool.Prices.Hourly(0, r.Amount)
+				pool.committed += committedPrices.Hourly(0, r.Amount)
+			}
+		}
+		if pool.onDemand > 0 {
+			pool.Discount = 1 - pool.committed/pool.onDemand
+		}
+	}
+	for key, pool := range a.pools {
+		a.committed[key] = *pool
+	}
+	return a
+}
+
+// Committed returns the total vCPUs and memory committed in the region for the commitment type
+func (a *commitmentAllocator) Committed(region, commitmentType string) (int64, int64) {
+	if a == nil {
+		return 0, 0
+	}
+	pool := a.committed[fmt.Sprintf("%s/%s", region, commitmentType)]
+	return pool.Cpu, pool.MemoryMb
+}
+
+// AllocateAll takes the vCPUs and memory of the instances from the commitments of their region and family,
+// in the order of the instance ids. Preemptible and stopped instances are left out of the demands, as
+// commitments never apply to them.
+func (a *commitmentAllocator) AllocateAll(demands []commitmentDemand) {
+	if a == nil {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	sorted := append([]commitmentDemand{}, demands...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ItemId < sorted[j].ItemId
+	})
+
+	for _, d := range sorted {
+		commitmentType, ok := commitmentTypes[machineFamily(d.MachineType)]
+		if !ok {
+			continue
+		}
+		pool, ok := a.pools[fmt.Sprintf("%s/%s", zoneToRegion(d.Zone), commitmentType)]
+		if !ok {
+			continue
+		}
+
+		coverage := &CommitmentCoverage{
+			CommitmentType: commitmentType,
+			Cpu:            min(d.Cpu, pool.Cpu),
+			MemoryMb:       min(d.MemoryMb, pool.MemoryMb),
+			Discount:       pool.Discount,
+			Prices:         pool.Prices,
+		}
+		pool.Cpu -= coverage.Cpu
+		pool.MemoryMb -= coverage.MemoryMb
+		if coverage.Cpu == 0 && coverage.MemoryMb == 0 {
+			continue
+		}
+		a.allocations[d.ItemId] = coverage
+	}
+}
+
+// Coverage returns the commitment coverage allocated to the instance, nil when it is not covered
+func (a *commitmentAllocator) Coverage(itemId string) *CommitmentCoverage {
+	if a == nil {
+		return nil
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.allocations[itemId]
+}
+
+// CommitmentCost is the cost of an instance after applying committed use and sustained use discounts
+type CommitmentCost struct {
+	// OnDemandCost is the part of the cost not covered by commitments, after sustained use discounts
+	OnDemandCost float64
+	// CommittedCost is the part of the cost paid upfront by the commitments covering the instance
+	CommittedCost float64
+}
+
+func (c CommitmentCost) Cost() float64 {
+	return c.OnDemandCost + c.CommittedCost
+}
+
+// effectiveCost applies the commitment coverage and sustained use discounts to the list price of an instance,
+// the extra costs (os license, accelerators) are never covered by resource-based commitments
+func effectiveCost(machineType string, cpu, memoryMb int64, listCost, extraCost float64, preemptible bool, coverage *CommitmentCoverage) CommitmentCost {
+	resourceCost := listCost - extraCost
+	sud := 0.0
+	if !preemptible {
+		sud = sustainedUseDiscounts[machineFamily(machineType)]
+	}
+
+	coveredFraction, discount := 0.0, 0.0
+	if coverage != nil && coverage.CommitmentType == commitmentTypes[machineFamily(machineType)] {
+		if total := coverage.Prices.Hourly(cpu, memoryMb); total > 0 {
+			coveredFraction = coverage.Prices.Hourly(min(cpu, coverage.Cpu), min(memoryMb, coverage.MemoryMb)) / total
+		}
+		discount = coverage.Discount
+	}
+
+	return CommitmentCost{
+		OnDemandCost:  resourceCost*(1-coveredFraction)*(1-sud) + extraCost,
+		CommittedCost: resourceCost * coveredFraction * (1 - discount),
+	}
+}
+
+// savingsType tells whether the saving of a recommendation lowers the bill or only frees capacity
+// of commitments that are paid for anyway
+func savingsType(cashSaving, freedCommitment float64) string {
+	switch {
+	case freedCommitment > 0.01 && cashSaving > 0.01:
+		return SavingsTypeMixed
+	case freedCommitment > 0.01:
+		return SavingsTypeCommitmentCapacity
+	}
+	return SavingsTypeRealCash
+}
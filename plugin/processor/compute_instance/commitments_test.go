@@ -0,0 +1,105 @@
+package compute_instance
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+)
+
+func testCommitments() []*compute.Commitment {
+	return []*compute.Commitment{
+		{
+			Status: "ACTIVE",
+			Region: "https://www.googleapis.com/compute/v1/projects/p/regions/us-central1",
+			Type:   "GENERAL_PURPOSE_N2",
+			Plan:   "TWELVE_MONTH",
+			Resources: []*compute.ResourceCommitment{
+				{Type: "VCPU", Amount: 6},
+				{Type: "MEMORY", Amount: 24576},
+			},
+		},
+		{
+			Status: "EXPIRED",
+			Region: "https://www.googleapis.com/compute/v1/projects/p/regions/us-central1",
+			Type:   "GENERAL_PURPOSE_N2",
+			Plan:   "TWELVE_MONTH",
+			Resources: []*compute.ResourceCommitment{
+				{Type: "VCPU", Amount: 100},
+			},
+		},
+	}
+}
+
+func TestAllocateAllIsOrderIndependent(t *testing.T) {
+	demands := []commitmentDemand{
+		{ItemId: "3", Zone: "us-central1-a", MachineType: "n2-standard-4", Cpu: 4, MemoryMb: 16384},
+		{ItemId: "1", Zone: "us-central1-b", MachineType: "n2-standard-4", Cpu: 4, MemoryMb: 16384},
+		{ItemId: "2", Zone: "us-central1-c", MachineType: "e2-standard-4", Cpu: 4, MemoryMb: 16384},
+		{ItemId: "4", Zone: "europe-west1-b", MachineType: "n2-standard-4", Cpu: 4, MemoryMb: 16384},
+	}
+	reversed := []commitmentDemand{demands[3], demands[2], demands[1], demands[0]}
+
+	for _, order := range [][]commitmentDemand{demands, reversed} {
+		a := newCommitmentAllocator(context.Background(), testCommitments(), nil)
+		a.AllocateAll(order)
+
+		first := a.Coverage("1")
+		if first == nil || first.Cpu != 4 || first.MemoryMb != 16384 {
+			t.Fatalf("instance 1 coverage = %+v, want 4 vCPU / 16384 MB", first)
+		}
+		if third := a.Coverage("3"); third == nil || third.Cpu != 2 || third.MemoryMb != 8192 {
+			t.Fatalf("instance 3 coverage = %+v, want 2 vCPU / 8192 MB", third)
+		}
+		if a.Coverage("2") != nil || a.Coverage("4") != nil {
+			t.Fatalf("instances of another family or region must not be covered")
+		}
+	}
+}
+
+func TestCommitmentDiscountFallback(t *testing.T) {
+	a := newCommitmentAllocator(context.Background(), testCommitments(), nil)
+	a.AllocateAll([]commitmentDemand{{ItemId: "1", Zone: "us-central1-a", MachineType: "n2-standard-2", Cpu: 2, MemoryMb: 8192}})
+
+	coverage := a.Coverage("1")
+	if coverage == nil || math.Abs(coverage.Discount-0.37) > 1e-9 {
+		t.Fatalf("coverage = %+v, want the 37%% 1 year discount", coverage)
+	}
+	if cpu, memoryMb := a.Committed("us-central1", "GENERAL_PURPOSE_N2"); cpu != 6 || memoryMb != 24576 {
+		t.Fatalf("committed = %d vCPU / %d MB, want 6 vCPU / 24576 MB", cpu, memoryMb)
+	}
+}
+
+func TestEffectiveCost(t *testing.T) {
+	coverage := &CommitmentCoverage{
+		CommitmentType: "GENERAL_PURPOSE_N2",
+		Cpu:            2,
+		MemoryMb:       8192,
+		Discount:       0.37,
+		Prices:         fallbackPrices,
+	}
+	listCost := fallbackPrices.Hourly(4, 16384) * monthHours
+
+	tests := []struct {
+		name        string
+		machineType string
+		preemptible bool
+		coverage    *CommitmentCoverage
+		onDemand    float64
+		committed   float64
+	}{
+		{"uncovered", "n2-standard-4", false, nil, listCost * 0.8, 0},
+		{"half covered", "n2-standard-4", false, coverage, listCost / 2 * 0.8, listCost / 2 * 0.63},
+		{"other family", "e2-standard-4", false, coverage, listCost, 0},
+		{"preemptible", "n2-standard-4", true, nil, listCost, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cost := effectiveCost(tt.machineType, 4, 16384, listCost, 0, tt.preemptible, tt.coverage)
+			if math.Abs(cost.OnDemandCost-tt.onDemand) > 1e-6 || math.Abs(cost.CommittedCost-tt.committed) > 1e-6 {
+				t.Errorf("effectiveCost = %+v, want on-demand %.4f committed %.4f", cost, tt.onDemand, tt.committed)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package compute_instance
 
 import (
+	"encoding/csv"
 	"fmt"
 	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
 	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
@@ -8,11 +9,34 @@ import (
 	"github.com/kaytu-io/kaytu/pkg/utils"
 	"github.com/opengovern/plugin-gcp/plugin/gcp"
 	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 )
 
+// ExportOptions are the local files the results are written to at the end of the run
+type ExportOptions struct {
+	// JsonFile receives a structured record per instance, as JSON Lines when it has the .jsonl extension
+	JsonFile string
+	// TerraformFile receives the terraform changes applying the recommendations, as a patch against
+	// the files of TerraformDir when it is set, as HCL snippets otherwise
+	TerraformFile string
+	TerraformDir  string
+	// TerraformAllowRecreate sets the disk type, disk shrink and preemptible changes that force a resource to
+	// be recreated, they are left as guidance comments otherwise
+	TerraformAllowRecreate bool
+	// GcloudScriptDir receives a gcloud remediation script per project
+	GcloudScriptDir string
+	// HtmlFile receives a self-contained HTML report of the results
+	HtmlFile string
+	// CommitmentsFile receives the commitment purchase recommendations as CSV
+	CommitmentsFile string
+	// SummaryFile receives the savings breakdowns as CSV
+	SummaryFile string
+}
+
 type ComputeInstanceProcessor struct {
 	provider                *gcp.Compute
 	metricProvider          *gcp.CloudMonitoring
@@ -23,6 +47,20 @@ type ComputeInstanceProcessor struct {
 	jobQueue                *sdk.JobQueue
 	lazyloadCounter         atomic.Uint32
 	client                  golang2.OptimizationClient
+	commitments             *commitmentAllocator
+	prices                  *priceBook
+	billingExport           *gcp.BillingExport
+	billingCosts            map[string]gcp.ResourceCost
+	recommender             *gcp.Recommender
+	recommendations         map[string][]gcp.InstanceRecommendation
+	exportOptions           ExportOptions
+	applyOptions            ApplyOptions
+	rollbackLock            sync.Mutex
+	summaryLabel            string
+	snapshotOptions         SnapshotOptions
+	// exported is set once the files of the run are written, they are rewritten after every re-evaluation from then on
+	exported   atomic.Bool
+	exportLock sync.Mutex
 
 	defaultPreferences []*golang.PreferenceItem
 
@@ -38,6 +76,13 @@ func NewComputeInstanceProcessor(
 	jobQueue *sdk.JobQueue,
 	client golang2.OptimizationClient,
 	defaultPreferences []*golang.PreferenceItem,
+	billingExport *gcp.BillingExport,
+	recommender *gcp.Recommender,
+	pricing *gcp.Pricing,
+	exportOptions ExportOptions,
+	applyOptions ApplyOptions,
+	summaryLabel string,
+	snapshotOptions SnapshotOptions,
 ) *ComputeInstanceProcessor {
 	r := &ComputeInstanceProcessor{
 		provider:                prv,
@@ -50,6 +95,13 @@ func NewComputeInstanceProcessor(
 		lazyloadCounter:         atomic.Uint32{},
 		client:                  client,
 		defaultPreferences:      defaultPreferences,
+		billingExport:           billingExport,
+		recommender:             recommender,
+		prices:                  newPriceBook(pricing),
+		exportOptions:           exportOptions,
+		applyOptions:            applyOptions,
+		summaryLabel:            summaryLabel,
+		snapshotOptions:         snapshotOptions,
 	}
 
 	jobQueue.Push(NewListComputeInstancesJob(r))
@@ -62,7 +114,9 @@ func (m *ComputeInstanceProcessor) ReEvaluate(id string, items []*golang.Prefere
 	m.items.Set(id, v)
 	v.OptimizationLoading = true
 	m.publishOptimizationItem(v.ToOptimizationItem())
-	m.jobQueue.Push(NewOptimizeComputeInstancesJob(m, id))
+	job := NewOptimizeComputeInstancesJob(m, id)
+	job.reEvaluation = true
+	m.jobQueue.Push(job)
 }
 
 func (m *ComputeInstanceProcessor) ExportNonInteractive() *golang.NonInteractiveExport {
@@ -71,22 +125,86 @@ func (m *ComputeInstanceProcessor) ExportNonInteractive() *golang.NonInteractive
 	}
 }
 
+// ExportFiles writes the local exports and the snapshot at the end of the run
+func (m *ComputeInstanceProcessor) ExportFiles() error {
+	m.exported.Store(true)
+	err := m.exportFiles()
+	if err != nil {
+		return err
+	}
+	if m.snapshotOptions.Dir != "" || m.snapshotOptions.DiffFile != "" {
+		err := m.saveSnapshots()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportFiles writes the local exports, again whenever an instance is re-evaluated after the end of the run.
+// The snapshot is only taken at the end of the run.
+func (m *ComputeInstanceProcessor) exportFiles() error {
+	m.exportLock.Lock()
+	defer m.exportLock.Unlock()
+
+	if m.exportOptions.JsonFile != "" {
+		err := m.exportJson(m.exportOptions.JsonFile)
+		if err != nil {
+			return err
+		}
+	}
+	if m.exportOptions.TerraformFile != "" {
+		err := m.exportTerraform(m.exportOptions.TerraformFile, m.exportOptions.TerraformDir)
+		if err != nil {
+			return err
+		}
+	}
+	if m.exportOptions.GcloudScriptDir != "" {
+		err := m.exportGcloudScripts(m.exportOptions.GcloudScriptDir)
+		if err != nil {
+			return err
+		}
+	}
+	if m.exportOptions.HtmlFile != "" {
+		err := m.exportHtml(m.exportOptions.HtmlFile)
+		if err != nil {
+			return err
+		}
+	}
+	if m.exportOptions.CommitmentsFile != "" {
+		err := writeCsv(m.exportOptions.CommitmentsFile, m.exportCommitmentsCsv())
+		if err != nil {
+			return err
+		}
+	}
+	if m.exportOptions.SummaryFile != "" {
+		err := writeCsv(m.exportOptions.SummaryFile, m.exportSummaryCsv())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *ComputeInstanceProcessor) exportCsv() []*golang.CSVRow {
 	headers := []string{
 		"Project ID", "Region", "Resource Type", "Resource ID", "Resource Name", "Platform",
 		"Device Runtime (Hrs)", "Current Cost", "Recommendation Cost", "Net Savings",
-		"Current Spec", "Suggested Spec", "Parent Device", "Justification", "Additional Details",
+		"Current Spec", "Suggested Spec", "Parent Device", "Justification", "Additional Details", "Savings Type",
+		"Actual Cost (30 days)", "Google Recommendation",
 	}
 	var rows []*golang.CSVRow
 	rows = append(rows, &golang.CSVRow{Row: headers})
 
 	m.items.Range(func(key string, value ComputeInstanceItem) bool {
 		var additionalDetails []string
-		var rightSizingCost, saving, recSpec string
+		var rightSizingCost, saving, recSpec, savingType string
+		currentCost, recommendedCost := value.InstanceCosts()
 		if value.Wastage.Rightsizing != nil && value.Wastage.Rightsizing.Recommended != nil {
-			rightSizingCost = utils.FormatPriceFloat(value.Wastage.Rightsizing.Recommended.Cost)
-			saving = utils.FormatPriceFloat(value.Wastage.Rightsizing.Current.Cost - value.Wastage.Rightsizing.Recommended.Cost)
+			rightSizingCost = utils.FormatPriceFloat(recommendedCost.Cost())
+			saving = utils.FormatPriceFloat(currentCost.Cost() - recommendedCost.Cost())
 			recSpec = value.Wastage.Rightsizing.Recommended.MachineType
+			savingType = value.SavingsType()
 
 			additionalDetails = append(additionalDetails,
 				fmt.Sprintf("Machine Type:: Current: %s - Recommended: %s", value.Wastage.Rightsizing.Current.MachineType,
@@ -100,20 +218,55 @@ func (m *ComputeInstanceProcessor) exportCsv() []*golang.CSVRow {
 			additionalDetails = append(additionalDetails,
 				fmt.Sprintf("Memory:: Current: %d - Recommended: %d", value.Wastage.Rightsizing.Current.MemoryMb,
 					value.Wastage.Rightsizing.Recommended.MemoryMb))
+			if len(value.Accelerators) > 0 {
+				additionalDetails = append(additionalDetails,
+					fmt.Sprintf("GPU:: Current: %s - Recommended: %s", acceleratorsString(value.Accelerators),
+						acceleratorsString(value.Wastage.Rightsizing.Recommended.Accelerators)))
+			}
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("List Price:: Current: %s - Recommended: %s", utils.FormatPriceFloat(value.Wastage.Rightsizing.Current.Cost),
+					utils.FormatPriceFloat(value.Wastage.Rightsizing.Recommended.Cost)))
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("Commitment Coverage:: %s", commitmentCoverageString(value.Commitment)))
 		}
 		computeRow := []string{
 			value.ProjectId, value.Region, "Compute Instance", value.Id, value.Name, value.Platform,
-			"730 Hrs", utils.FormatPriceFloat(value.Wastage.Rightsizing.Current.Cost), rightSizingCost, saving,
-			value.Wastage.Rightsizing.Current.MachineType, recSpec, "None", value.Wastage.Rightsizing.Description, strings.Join(additionalDetails, "---")}
+			runtimeHours(value.BilledCost), utils.FormatPriceFloat(currentCost.Cost()), rightSizingCost, saving,
+			value.Wastage.Rightsizing.Current.MachineType, recSpec, "None", value.Wastage.Rightsizing.Description, strings.Join(additionalDetails, "---"), savingType,
+			billedCost(value.BilledCost), value.googleRecommendationCsv()}
 
 		rows = append(rows, &golang.CSVRow{Row: computeRow})
 
+		if value.Schedule != nil {
+			scheduleSaving := value.ScheduleSaving()
+			rows = append(rows, &golang.CSVRow{Row: []string{
+				value.ProjectId, value.Region, "Instance Schedule", value.Id, value.Name, value.Platform,
+				runtimeHours(value.BilledCost), utils.FormatPriceFloat(currentCost.Cost()),
+				utils.FormatPriceFloat(currentCost.Cost() - scheduleSaving), utils.FormatPriceFloat(scheduleSaving),
+				"24/7", value.Schedule.String(), value.Id,
+				fmt.Sprintf("No activity outside of the schedule in %d observed hours", value.Schedule.ObservedHours),
+				fmt.Sprintf("Start:: %s---Stop:: %s", value.Schedule.StartCron(), value.Schedule.StopCron()), SavingsTypeRealCash,
+				"", ""}})
+		}
+
+		if value.Arm != nil {
+			rows = append(rows, &golang.CSVRow{Row: []string{
+				value.ProjectId, value.Region, "Arm Migration", value.Id, value.Name, value.Platform,
+				runtimeHours(value.BilledCost), utils.FormatPriceFloat(value.Wastage.Rightsizing.Current.Cost),
+				utils.FormatPriceFloat(value.Arm.Cost), utils.FormatPriceFloat(value.Arm.Saving),
+				value.Wastage.Rightsizing.Current.MachineType, value.Arm.MachineType, value.Id,
+				fmt.Sprintf("%s: %s", value.Arm.Compatibility, strings.Join(value.Arm.Risks, "; ")),
+				fmt.Sprintf("Price-Performance:: %s---Costs:: list prices", value.Arm.PricePerformanceString()), SavingsTypeRealCash,
+				"", ""}})
+		}
+
 		for _, d := range value.Disks {
 			dKey := strconv.FormatUint(d.Id, 10)
 			disk := value.Wastage.VolumesRightsizing[dKey]
 			var diskAdditionalDetails []string
-			var diskRightSizingCost, diskSaving, diskRecSpec string
+			var diskRightSizingCost, diskSaving, diskRecSpec, diskSavingType string
 			if disk.Recommended != nil {
+				diskSavingType = SavingsTypeRealCash
 				diskRightSizingCost = utils.FormatPriceFloat(disk.Recommended.Cost)
 				diskSaving = utils.FormatPriceFloat(disk.Current.Cost - disk.Recommended.Cost)
 				diskRecSpec = fmt.Sprintf("%s / %d GB", disk.Recommended.DiskType, disk.Recommended.DiskSize)
@@ -136,48 +289,133 @@ func (m *ComputeInstanceProcessor) exportCsv() []*golang.CSVRow {
 			}
 			diskRow := []string{
 				value.ProjectId, value.Region, "Compute Disk", dKey, d.Name, "N/A",
-				"730 Hrs", utils.FormatPriceFloat(disk.Current.Cost), diskRightSizingCost, diskSaving,
+				runtimeHours(value.DisksBilledCost[dKey]), utils.FormatPriceFloat(disk.Current.Cost), diskRightSizingCost, diskSaving,
 				fmt.Sprintf("%s / %d GB", disk.Current.DiskType, disk.Current.DiskSize), diskRecSpec,
-				"None", value.Wastage.Rightsizing.Description, strings.Join(diskAdditionalDetails, "---")}
+				"None", value.Wastage.Rightsizing.Description, strings.Join(diskAdditionalDetails, "---"), diskSavingType,
+				billedCost(value.DisksBilledCost[dKey]), ""}
 
 			rows = append(rows, &golang.CSVRow{Row: diskRow})
 		}
 
 		return true
 	})
+
+	rows = append(rows, &golang.CSVRow{Row: []string{}})
+	rows = append(rows, m.exportSummaryCsv()...)
 	return rows
 }
 
 func (m *ComputeInstanceProcessor) ResultsSummary() *golang.ResultSummary {
 	summary := &golang.ResultSummary{}
-	var totalCost, savings float64
+	var totalCost, savings, commitmentSavings float64
 	m.summary.Range(func(_ string, item ComputeInstanceSummary) bool {
 		totalCost += item.CurrentRuntimeCost
 		savings += item.Savings
+		commitmentSavings += item.CommitmentSavings
 		return true
 	})
 
 	summary.Message = fmt.Sprintf("Current runtime cost: %s, Savings: %s",
 		style.CostStyle.Render(fmt.Sprintf("%s", utils.FormatPriceFloat(totalCost))), style.SavingStyle.Render(fmt.Sprintf("%s", utils.FormatPriceFloat(savings))))
+	if commitmentSavings > 0 {
+		summary.Message += fmt.Sprintf(" (of which %s frees commitment capacity)",
+			style.SavingStyle.Render(utils.FormatPriceFloat(commitmentSavings)))
+	}
+	if breakdowns := m.breakdownsMessage(); breakdowns != "" {
+		summary.Message += "\n" + breakdowns
+	}
 	return summary
 }
 
 func (m *ComputeInstanceProcessor) UpdateSummary(itemId string) {
 	i, ok := m.items.Get(itemId)
-	if ok && i.Wastage != nil && i.Wastage.Rightsizing.Recommended != nil {
-		totalSaving := 0.0
+	if ok {
+		// the instances and disks without a recommendation are counted with their current cost and no saving
 		totalCurrentCost := 0.0
-		for _, v := range i.Wastage.VolumesRightsizing {
-			totalSaving += v.Current.Cost - v.Recommended.Cost
+		diskSaving := 0.0
+		for _, v := range i.Wastage.GetVolumesRightsizing() {
+			if v.GetCurrent() == nil {
+				continue
+			}
 			totalCurrentCost += v.Current.Cost
+			if v.Recommended != nil {
+				diskSaving += v.Current.Cost - v.Recommended.Cost
+			}
+		}
+		currentCost, recommendedCost := i.InstanceCosts()
+		var instanceSaving, commitmentSaving float64
+		switch {
+		case i.Idle:
+			// an idle instance is stopped, its whole cost is saved
+			instanceSaving = currentCost.Cost()
+			commitmentSaving = currentCost.CommittedCost
+		case i.Wastage.GetRightsizing().GetRecommended() != nil:
+			instanceSaving = currentCost.Cost() - recommendedCost.Cost()
+			commitmentSaving = max(currentCost.CommittedCost-recommendedCost.CommittedCost, 0)
 		}
-		totalSaving += i.Wastage.Rightsizing.Current.Cost - i.Wastage.Rightsizing.Recommended.Cost
-		totalCurrentCost += i.Wastage.Rightsizing.Current.Cost
+		totalCurrentCost += currentCost.Cost()
 
 		m.summary.Set(itemId, ComputeInstanceSummary{
 			CurrentRuntimeCost: totalCurrentCost,
-			Savings:            totalSaving,
+			Savings:            instanceSaving + diskSaving,
+			CommitmentSavings:  commitmentSaving,
+			ProjectId:          i.ProjectId,
+			Region:             zoneToRegion(i.Region),
+			MachineFamily:      machineFamily(i.MachineType),
+			Label:              i.Instance.GetLabels()[m.summaryLabel],
+			InstanceSavings:    instanceSaving,
+			DiskSavings:        diskSaving,
+			Idle:               i.Idle,
 		})
 	}
 	m.publishResultSummary(m.ResultsSummary())
 }
+
+// writeCsv writes the rows of an export that does not share the columns of the item CSV to its own file
+func writeCsv(path string, rows []*golang.CSVRow) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	for _, row := range rows {
+		err = w.Write(row.Row)
+		if err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// runtimeHours is the billed runtime of the resource, or a whole month when there is no billing export
+func runtimeHours(cost *gcp.ResourceCost) string {
+	if cost == nil {
+		return "730 Hrs"
+	}
+	return fmt.Sprintf("%d Hrs", cost.RuntimeHours)
+}
+
+func billedCost(cost *gcp.ResourceCost) string {
+	if cost == nil {
+		return ""
+	}
+	return utils.FormatPriceFloat(cost.Cost)
+}
+
+// billedCost looks the resource up in the billing export by id and falls back to its type (instances or disks)
+// and name
+func (m *ComputeInstanceProcessor) billedCost(id, resourceType, name string) *gcp.ResourceCost {
+	if m.billingCosts == nil {
+		return nil
+	}
+	if cost, ok := m.billingCosts[id]; ok {
+		return &cost
+	}
+	if cost, ok := m.billingCosts[gcp.ResourceCostKey(resourceType, name)]; ok {
+		return &cost
+	}
+	return nil
+}
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
 	"github.com/kaytu-io/kaytu/pkg/utils"
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+	"github.com/opengovern/plugin-gcp/plugin/processor/shared"
 	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	"maps"
 	"strconv"
+	"strings"
 )
 
 type ComputeInstanceItem struct {
@@ -28,9 +31,46 @@ type ComputeInstanceItem struct {
 	Instance            *computepb.Instance
 	Disks               []compute.Disk
 	InstanceOsLicense   string
+	Accelerators        []*golang2.GcpComputeAccelerator
 	Metrics             map[string][]*golang2.DataPoint
 	DisksMetrics        map[string]map[string][]*golang2.DataPoint
-	Wastage             *golang2.GCPComputeOptimizationResponse
+	Commitment          *CommitmentCoverage
+	BilledCost          *gcp.ResourceCost
+	DisksBilledCost     map[string]*gcp.ResourceCost
+	ApplyStatus         string
+	// ActivityMetrics are the hourly CPU and network datapoints of the schedule detection, over a longer
+	// window than Metrics
+	ActivityMetrics map[string][]*golang2.DataPoint
+	// Schedule is the start/stop schedule detected from the activity of the instance, nil when it has none
+	Schedule *InstanceSchedule
+	// Idle is set when the instance was not active in any hour of its activity metrics
+	Idle bool
+	// Arm is the assessment of moving the instance to an Arm machine type, nil when it was not requested
+	Arm *ArmAssessment
+	// GoogleRecommendations are the Recommender API recommendations, nil when they were not fetched
+	GoogleRecommendations []gcp.InstanceRecommendation
+	Wastage               *golang2.GCPComputeOptimizationResponse
+}
+
+// InstanceCosts returns the current and recommended cost of the instance after committed use and
+// sustained use discounts, the recommended cost is zero when there is no recommendation
+func (i ComputeInstanceItem) InstanceCosts() (CommitmentCost, CommitmentCost) {
+	var current, recommended CommitmentCost
+	if i.Wastage == nil || i.Wastage.Rightsizing == nil || i.Wastage.Rightsizing.Current == nil {
+		return current, recommended
+	}
+	c := i.Wastage.Rightsizing.Current
+	current = effectiveCost(c.MachineType, c.Cpu, c.MemoryMb, c.Cost, c.OsLicenseCost+c.AcceleratorsCost, c.Preemptible, i.Commitment)
+	if r := i.Wastage.Rightsizing.Recommended; r != nil {
+		recommended = effectiveCost(r.MachineType, r.Cpu, r.MemoryMb, r.Cost, r.OsLicenseCost+r.AcceleratorsCost, r.Preemptible, i.Commitment)
+	}
+	return current, recommended
+}
+
+// SavingsType tells whether the instance saving is real cash or only frees commitment capacity
+func (i ComputeInstanceItem) SavingsType() string {
+	current, recommended := i.InstanceCosts()
+	return savingsType(current.OnDemandCost-recommended.OnDemandCost, current.CommittedCost-recommended.CommittedCost)
 }
 
 func (i ComputeInstanceItem) ComputeInstanceDevice() (*golang.ChartRow, map[string]*golang.Properties) {
@@ -60,6 +100,18 @@ func (i ComputeInstanceItem) ComputeInstanceDevice() (*golang.ChartRow, map[stri
 	MachineFamilyProperty := &golang.Property{Key: "Machine Family"}
 	CPUProperty := &golang.Property{Key: "  CPU"}
 	MemoryProperty := &golang.Property{Key: "  MemoryMB"}
+	GPUProperty := &golang.Property{Key: "  GPU", Current: acceleratorsString(i.Accelerators)}
+	GPUMemoryProperty := &golang.Property{Key: "  GPU Memory"}
+	CPUPercentilesProperty := &golang.Property{Key: "  CPU Percentiles"}
+	MemoryPercentilesProperty := &golang.Property{Key: "  Memory Percentiles"}
+	ListPriceProperty := &golang.Property{Key: "  List Price"}
+	CommitmentCoverageProperty := &golang.Property{Key: "  Commitment Coverage", Current: commitmentCoverageString(i.Commitment)}
+	SavingsTypeProperty := &golang.Property{Key: "  Savings Type"}
+	ActualCostProperty := &golang.Property{Key: "  Actual Cost (30 days)", Current: billedCost(i.BilledCost)}
+	RuntimeProperty := &golang.Property{Key: "  Runtime (30 days)"}
+	if i.BilledCost != nil {
+		RuntimeProperty.Current = runtimeHours(i.BilledCost)
+	}
 
 	if i.Wastage != nil {
 		RegionProperty.Current = i.Wastage.Rightsizing.Current.Region
@@ -72,36 +124,51 @@ func (i ComputeInstanceItem) ComputeInstanceDevice() (*golang.ChartRow, map[stri
 		MachineFamilyProperty.Current = i.Wastage.Rightsizing.Current.MachineFamily
 
 		CPUProperty.Current = fmt.Sprintf("%d", i.Wastage.Rightsizing.Current.Cpu)
-		CPUProperty.Average = utils.Percentage(PWrapperDouble(i.Wastage.Rightsizing.Cpu.Avg))
-		CPUProperty.Max = utils.Percentage(PWrapperDouble(i.Wastage.Rightsizing.Cpu.Max))
+		CPUProperty.Average = utils.Percentage(shared.PWrapperDouble(i.Wastage.Rightsizing.Cpu.Avg))
+		CPUProperty.Max = utils.Percentage(shared.PWrapperDouble(i.Wastage.Rightsizing.Cpu.Max))
 
 		MemoryProperty.Current = fmt.Sprintf("%d MB", i.Wastage.Rightsizing.Current.MemoryMb)
-		if PWrapperDouble(i.Wastage.Rightsizing.Memory.Avg) == nil {
+		if shared.PWrapperDouble(i.Wastage.Rightsizing.Memory.Avg) == nil {
 			MemoryProperty.Average = ""
 		} else {
-			MemoryProperty.Average = fmt.Sprintf("%.0f MB", *PWrapperDouble(i.Wastage.Rightsizing.Memory.Avg)/(1024*1024))
+			MemoryProperty.Average = fmt.Sprintf("%.0f MB", *shared.PWrapperDouble(i.Wastage.Rightsizing.Memory.Avg)/(1024*1024))
 		}
-		if PWrapperDouble(i.Wastage.Rightsizing.Memory.Max) == nil {
+		if shared.PWrapperDouble(i.Wastage.Rightsizing.Memory.Max) == nil {
 			MemoryProperty.Max = ""
 		} else {
-			MemoryProperty.Max = fmt.Sprintf("%.0f MB", *PWrapperDouble(i.Wastage.Rightsizing.Memory.Max)/(1024*1024))
+			MemoryProperty.Max = fmt.Sprintf("%.0f MB", *shared.PWrapperDouble(i.Wastage.Rightsizing.Memory.Max)/(1024*1024))
 		}
 
-		row.Values["current_cost"] = &golang.ChartRowItem{
-			Value: utils.FormatPriceFloat(i.Wastage.Rightsizing.Current.Cost),
+		CPUPercentilesProperty.Current = percentilesString(i.Wastage.Rightsizing.Cpu, utils.Percentage)
+		MemoryPercentilesProperty.Current = percentilesString(i.Wastage.Rightsizing.Memory, megabytesString)
+
+		if len(i.Wastage.Rightsizing.Current.Accelerators) > 0 {
+			GPUProperty.Current = acceleratorsString(i.Wastage.Rightsizing.Current.Accelerators)
+		}
+		GPUProperty.Average = utils.Percentage(shared.PWrapperDouble(i.Wastage.Rightsizing.Gpu.GetAvg()))
+		GPUProperty.Max = utils.Percentage(shared.PWrapperDouble(i.Wastage.Rightsizing.Gpu.GetMax()))
+		if avg := shared.PWrapperDouble(i.Wastage.Rightsizing.GpuMemory.GetAvg()); avg != nil {
+			GPUMemoryProperty.Average = fmt.Sprintf("%.0f MB", *avg/(1024*1024))
+		}
+		if max := shared.PWrapperDouble(i.Wastage.Rightsizing.GpuMemory.GetMax()); max != nil {
+			GPUMemoryProperty.Max = fmt.Sprintf("%.0f MB", *max/(1024*1024))
 		}
 
+		currentCost, recommendedCost := i.InstanceCosts()
+		ListPriceProperty.Current = utils.FormatPriceFloat(i.Wastage.Rightsizing.Current.Cost)
 		row.Values["current_cost"] = &golang.ChartRowItem{
-			Value: utils.FormatPriceFloat(i.Wastage.Rightsizing.Current.Cost),
+			Value: utils.FormatPriceFloat(currentCost.Cost()),
 		}
 
 		if i.Wastage.Rightsizing.Recommended != nil {
 			row.Values["right_sized_cost"] = &golang.ChartRowItem{
-				Value: utils.FormatPriceFloat(i.Wastage.Rightsizing.Recommended.Cost),
+				Value: utils.FormatPriceFloat(recommendedCost.Cost()),
 			}
 			row.Values["savings"] = &golang.ChartRowItem{
-				Value: utils.FormatPriceFloat(i.Wastage.Rightsizing.Current.Cost - i.Wastage.Rightsizing.Recommended.Cost),
+				Value: utils.FormatPriceFloat(currentCost.Cost() - recommendedCost.Cost()),
 			}
+			ListPriceProperty.Recommended = utils.FormatPriceFloat(i.Wastage.Rightsizing.Recommended.Cost)
+			SavingsTypeProperty.Recommended = i.SavingsType()
 			RegionProperty.Recommended = i.Wastage.Rightsizing.Recommended.Region
 			provisioningModel := "Standard"
 			if i.Wastage.Rightsizing.Recommended.Preemptible {
@@ -111,6 +178,9 @@ func (i ComputeInstanceItem) ComputeInstanceDevice() (*golang.ChartRow, map[stri
 			MachineTypeProperty.Recommended = i.Wastage.Rightsizing.Recommended.MachineType
 			CPUProperty.Recommended = fmt.Sprintf("%d", i.Wastage.Rightsizing.Recommended.Cpu)
 			MemoryProperty.Recommended = fmt.Sprintf("%d MB", i.Wastage.Rightsizing.Recommended.MemoryMb)
+			if len(i.Accelerators) > 0 {
+				GPUProperty.Recommended = acceleratorsString(i.Wastage.Rightsizing.Recommended.Accelerators)
+			}
 		}
 	}
 	props := make(map[string]*golang.Properties)
@@ -124,7 +194,87 @@ func (i ComputeInstanceItem) ComputeInstanceDevice() (*golang.ChartRow, map[stri
 		Key: "Compute",
 	})
 	properties.Properties = append(properties.Properties, CPUProperty)
+	if CPUPercentilesProperty.Current != "" {
+		properties.Properties = append(properties.Properties, CPUPercentilesProperty)
+	}
+	if trend := shared.Sparkline(i.Metrics["cpuUtilization"], shared.SparklineWidth); trend != "" {
+		properties.Properties = append(properties.Properties, &golang.Property{Key: "  CPU Trend", Current: trend})
+	}
 	properties.Properties = append(properties.Properties, MemoryProperty)
+	if MemoryPercentilesProperty.Current != "" {
+		properties.Properties = append(properties.Properties, MemoryPercentilesProperty)
+	}
+	if trend := shared.Sparkline(i.Metrics["memoryUtilization"], shared.SparklineWidth); trend != "" {
+		properties.Properties = append(properties.Properties, &golang.Property{Key: "  Memory Trend", Current: trend})
+	}
+	if len(i.Accelerators) > 0 {
+		properties.Properties = append(properties.Properties, &golang.Property{
+			Key: "Accelerators",
+		})
+		properties.Properties = append(properties.Properties, GPUProperty)
+		properties.Properties = append(properties.Properties, GPUMemoryProperty)
+	}
+	properties.Properties = append(properties.Properties, &golang.Property{
+		Key: "Cost",
+	})
+	properties.Properties = append(properties.Properties, ListPriceProperty)
+	properties.Properties = append(properties.Properties, CommitmentCoverageProperty)
+	properties.Properties = append(properties.Properties, SavingsTypeProperty)
+	if i.BilledCost != nil {
+		properties.Properties = append(properties.Properties, ActualCostProperty)
+		properties.Properties = append(properties.Properties, RuntimeProperty)
+	}
+	if i.GoogleRecommendations != nil {
+		properties.Properties = append(properties.Properties, &golang.Property{
+			Key:         "Google Recommendation",
+			Current:     i.GoogleRecommendationString(),
+			Recommended: i.GoogleRecommendedMachineType(),
+		})
+		properties.Properties = append(properties.Properties, &golang.Property{
+			Key:     "  Agreement",
+			Current: i.GoogleAgreement(),
+		})
+	}
+	if i.Schedule != nil {
+		properties.Properties = append(properties.Properties, &golang.Property{
+			Key:         "Schedule",
+			Current:     "24/7",
+			Recommended: i.Schedule.String(),
+		})
+		properties.Properties = append(properties.Properties, &golang.Property{
+			Key:         "  Schedule Saving",
+			Recommended: utils.FormatPriceFloat(i.ScheduleSaving()),
+		})
+	}
+	if i.Arm != nil {
+		properties.Properties = append(properties.Properties, &golang.Property{
+			Key:         "Arm Alternative",
+			Current:     i.Wastage.Rightsizing.Current.MachineType,
+			Recommended: i.Arm.MachineType,
+		})
+		properties.Properties = append(properties.Properties, &golang.Property{
+			Key:         "  Arm Saving",
+			Recommended: utils.FormatPriceFloat(i.Arm.Saving),
+		})
+		properties.Properties = append(properties.Properties, &golang.Property{
+			Key:         "  Price-Performance",
+			Recommended: i.Arm.PricePerformanceString(),
+		})
+		properties.Properties = append(properties.Properties, &golang.Property{
+			Key:     "  Compatibility",
+			Current: i.Arm.Compatibility,
+		})
+		properties.Properties = append(properties.Properties, &golang.Property{
+			Key:     "  Risks",
+			Current: strings.Join(i.Arm.Risks, "; "),
+		})
+	}
+	if i.ApplyStatus != "" {
+		properties.Properties = append(properties.Properties, &golang.Property{
+			Key:     "Apply Status",
+			Current: i.ApplyStatus,
+		})
+	}
 
 	props[i.Id] = properties
 
@@ -163,6 +313,8 @@ func (i ComputeInstanceItem) ComputeDiskDevice() ([]*golang.ChartRow, map[string
 		DiskWriteIopsProperty := &golang.Property{Key: "  Write IOPS Expectation"}
 		DiskReadThroughputProperty := &golang.Property{Key: "  Read Throughput Expectation"}
 		DiskWriteThroughputProperty := &golang.Property{Key: "  Write Throughput Expectation"}
+		DiskReadIopsPercentilesProperty := &golang.Property{Key: "  Read IOPS Percentiles"}
+		DiskWriteIopsPercentilesProperty := &golang.Property{Key: "  Write IOPS Percentiles"}
 
 		if i.Wastage != nil {
 			disk := i.Wastage.VolumesRightsizing[key]
@@ -175,20 +327,23 @@ func (i ComputeInstanceItem) ComputeDiskDevice() ([]*golang.ChartRow, map[string
 			DiskSizeProperty.Current = fmt.Sprintf("%d GB", disk.Current.DiskSize)
 
 			DiskReadIopsProperty.Current = fmt.Sprintf("%d", disk.Current.ReadIopsLimit)
-			DiskReadIopsProperty.Average = utils.PFloat64ToString(PWrapperDouble(disk.ReadIops.Avg))
-			DiskReadIopsProperty.Max = utils.PFloat64ToString(PWrapperDouble(disk.ReadIops.Max))
+			DiskReadIopsProperty.Average = utils.PFloat64ToString(shared.PWrapperDouble(disk.ReadIops.Avg))
+			DiskReadIopsProperty.Max = utils.PFloat64ToString(shared.PWrapperDouble(disk.ReadIops.Max))
 
 			DiskWriteIopsProperty.Current = fmt.Sprintf("%d", disk.Current.WriteIopsLimit)
-			DiskWriteIopsProperty.Average = utils.PFloat64ToString(PWrapperDouble(disk.WriteIops.Avg))
-			DiskWriteIopsProperty.Max = utils.PFloat64ToString(PWrapperDouble(disk.WriteIops.Max))
+			DiskWriteIopsProperty.Average = utils.PFloat64ToString(shared.PWrapperDouble(disk.WriteIops.Avg))
+			DiskWriteIopsProperty.Max = utils.PFloat64ToString(shared.PWrapperDouble(disk.WriteIops.Max))
+
+			DiskReadIopsPercentilesProperty.Current = percentilesString(disk.ReadIops, utils.PFloat64ToString)
+			DiskWriteIopsPercentilesProperty.Current = percentilesString(disk.WriteIops, utils.PFloat64ToString)
 
 			DiskReadThroughputProperty.Current = fmt.Sprintf("%.2f Mb", disk.Current.ReadThroughputLimit)
-			DiskReadThroughputProperty.Average = utils.PFloat64ToString(PWrapperDouble(disk.ReadThroughput.Avg))
-			DiskReadThroughputProperty.Max = utils.PFloat64ToString(PWrapperDouble(disk.ReadThroughput.Max))
+			DiskReadThroughputProperty.Average = utils.PFloat64ToString(shared.PWrapperDouble(disk.ReadThroughput.Avg))
+			DiskReadThroughputProperty.Max = utils.PFloat64ToString(shared.PWrapperDouble(disk.ReadThroughput.Max))
 
 			DiskWriteThroughputProperty.Current = fmt.Sprintf("%.2f Mb", disk.Current.WriteThroughputLimit)
-			DiskWriteThroughputProperty.Average = utils.PFloat64ToString(PWrapperDouble(disk.WriteThroughput.Avg))
-			DiskWriteThroughputProperty.Max = utils.PFloat64ToString(PWrapperDouble(disk.WriteThroughput.Max))
+			DiskWriteThroughputProperty.Average = utils.PFloat64ToString(shared.PWrapperDouble(disk.WriteThroughput.Avg))
+			DiskWriteThroughputProperty.Max = utils.PFloat64ToString(shared.PWrapperDouble(disk.WriteThroughput.Max))
 
 			if disk.Recommended != nil {
 				row.Values["right_sized_cost"] = &golang.ChartRowItem{
@@ -217,11 +372,34 @@ func (i ComputeInstanceItem) ComputeDiskDevice() ([]*golang.ChartRow, map[string
 		})
 		properties.Properties = append(properties.Properties, DiskReadIopsProperty)
 		properties.Properties = append(properties.Properties, DiskWriteIopsProperty)
+		if DiskReadIopsPercentilesProperty.Current != "" {
+			properties.Properties = append(properties.Properties, DiskReadIopsPercentilesProperty)
+			properties.Properties = append(properties.Properties, DiskWriteIopsPercentilesProperty)
+		}
+		if trend := shared.Sparkline(i.DisksMetrics[key]["DiskReadIOPS"], shared.SparklineWidth); trend != "" {
+			properties.Properties = append(properties.Properties, &golang.Property{Key: "  Read IOPS Trend", Current: trend})
+		}
+		if trend := shared.Sparkline(i.DisksMetrics[key]["DiskWriteIOPS"], shared.SparklineWidth); trend != "" {
+			properties.Properties = append(properties.Properties, &golang.Property{Key: "  Write IOPS Trend", Current: trend})
+		}
 		properties.Properties = append(properties.Properties, &golang.Property{
 			Key: "Throughput",
 		})
 		properties.Properties = append(properties.Properties, DiskReadThroughputProperty)
 		properties.Properties = append(properties.Properties, DiskWriteThroughputProperty)
+		if cost := i.DisksBilledCost[key]; cost != nil {
+			properties.Properties = append(properties.Properties, &golang.Property{
+				Key: "Cost",
+			})
+			properties.Properties = append(properties.Properties, &golang.Property{
+				Key:     "  Actual Cost (30 days)",
+				Current: billedCost(cost),
+			})
+			properties.Properties = append(properties.Properties, &golang.Property{
+				Key:     "  Runtime (30 days)",
+				Current: runtimeHours(cost),
+			})
+		}
 
 		props[key] = properties
 		rows = append(rows, &row)
@@ -258,10 +436,11 @@ func (i ComputeInstanceItem) ToOptimizationItem() *golang.ChartOptimizationItem
 	} else if i.OptimizationLoading {
 		status = "loading"
 	} else if i.Wastage != nil && i.Wastage.Rightsizing.Recommended != nil {
+		currentCost, recommendedCost := i.InstanceCosts()
 		totalSaving := 0.0
 		totalCurrentCost := 0.0
-		totalSaving += i.Wastage.Rightsizing.Current.Cost - i.Wastage.Rightsizing.Recommended.Cost
-		totalCurrentCost += i.Wastage.Rightsizing.Current.Cost
+		totalSaving += currentCost.Cost() - recommendedCost.Cost()
+		totalCurrentCost += currentCost.Cost()
 		for _, d := range i.Wastage.VolumesRightsizing {
 			totalSaving += d.Current.Cost - d.Recommended.Cost
 			totalCurrentCost += d.Current.Cost
@@ -313,10 +492,42 @@ func (i ComputeInstanceItem) ToOptimizationItem() *golang.ChartOptimizationItem
 	return coi
 }
 
-func PWrapperDouble(v *wrapperspb.DoubleValue) *float64 {
+func acceleratorsString(accelerators []*golang2.GcpComputeAccelerator) string {
+	if len(accelerators) == 0 {
+		return "None"
+	}
+	var as []string
+	for _, a := range accelerators {
+		as = append(as, fmt.Sprintf("%d x %s", a.Count, a.AcceleratorType))
+	}
+	return strings.Join(as, ", ")
+}
+
+func commitmentCoverageString(coverage *CommitmentCoverage) string {
+	if coverage == nil {
+		return "None"
+	}
+	return fmt.Sprintf("%d vCPU / %d MB (%.0f%% off)", coverage.Cpu, coverage.MemoryMb, coverage.Discount*100)
+}
+
+// percentilesString renders the p50, p90, p95 and p99 of the usage and the statistic the rightsizing is
+// sized on, empty when they are not known
+func percentilesString(usage *golang2.Usage, format func(*float64) string) string {
+	if usage.GetP50() == nil {
+		return ""
+	}
+	s := fmt.Sprintf("p50 %s, p90 %s, p95 %s, p99 %s",
+		format(shared.PWrapperDouble(usage.GetP50())), format(shared.PWrapperDouble(usage.GetP90())),
+		format(shared.PWrapperDouble(usage.GetP95())), format(shared.PWrapperDouble(usage.GetP99())))
+	if usage.GetStatistic() != "" {
+		s += fmt.Sprintf(" (sized on %s)", usage.GetStatistic())
+	}
+	return s
+}
+
+func megabytesString(v *float64) string {
 	if v == nil {
-		return nil
+		return ""
 	}
-	value := v.GetValue()
-	return &value
+	return fmt.Sprintf("%.0f MB", *v/(1024*1024))
 }
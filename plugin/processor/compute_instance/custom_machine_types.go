@@ -0,0 +1,212 @@
+package compute_instance
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/kaytu-io/kaytu/preferences"
+	"github.com/opengovern/plugin-gcp/plugin/processor/shared"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+)
+
+// customMachineFamily are the shapes a custom machine type of a family can have
+type customMachineFamily struct {
+	validCpu func(cpu int64) bool
+	maxCpu   int64
+	// memory per vCPU of a custom machine type, more memory is extended memory
+	minMemoryPerCpuMb int64
+	maxMemoryPerCpuMb int64
+	// maxMemoryMb is the memory limit including extended memory
+	maxMemoryMb int64
+	// extendedMemory is set when the family supports memory above maxMemoryPerCpuMb
+	extendedMemory bool
+}
+
+// customMachineMemoryStepMb is the granularity of the memory of custom machine types
+const customMachineMemoryStepMb = 256
+
+var customMachineFamilies = map[string]customMachineFamily{
+	"n1": {
+		validCpu: func(cpu int64) bool {
+			return cpu == 1 || cpu%2 == 0
+		},
+		maxCpu:            96,
+		minMemoryPerCpuMb: 922,
+		maxMemoryPerCpuMb: 6656,
+		maxMemoryMb:       624 * 1024,
+		extendedMemory:    true,
+	},
+	"n2": {
+		validCpu: func(cpu int64) bool {
+			switch {
+			case cpu <= 32:
+				return cpu%2 == 0
+			case cpu <= 48:
+				return cpu%4 == 0
+			case cpu <= 80:
+				return cpu%8 == 0
+			}
+			return cpu%16 == 0
+		},
+		maxCpu:            128,
+		minMemoryPerCpuMb: 512,
+		maxMemoryPerCpuMb: 8192,
+		maxMemoryMb:       864 * 1024,
+		extendedMemory:    true,
+	},
+	"n2d": {
+		validCpu: func(cpu int64) bool {
+			switch cpu {
+			case 2, 4, 8, 16, 32, 48, 64, 80, 96:
+				return true
+			}
+			return false
+		},
+		maxCpu:            96,
+		minMemoryPerCpuMb: 512,
+		maxMemoryPerCpuMb: 8192,
+		maxMemoryMb:       768 * 1024,
+		extendedMemory:    true,
+	},
+	"e2": {
+		validCpu: func(cpu int64) bool {
+			return cpu%2 == 0
+		},
+		maxCpu:            32,
+		minMemoryPerCpuMb: 512,
+		maxMemoryPerCpuMb: 8192,
+		maxMemoryMb:       128 * 1024,
+	},
+}
+
+// customMachineTypeName returns the name of the custom machine type, N1 custom types have no family prefix
+func customMachineTypeName(family string, cpu, memoryMb int64, extended bool) string {
+	name := fmt.Sprintf("%s-custom-%d-%d", family, cpu, memoryMb)
+	if family == "n1" {
+		name = fmt.Sprintf("custom-%d-%d", cpu, memoryMb)
+	}
+	if extended {
+		name += "-ext"
+	}
+	return name
+}
+
+// customMachineType returns the cheapest custom machine type of the family of the instance with at least
+// the needed vCPUs and memory, nil when the family has no custom machine types or none fits. The vCPUs and
+// memory are priced with the custom and extended memory prices of the family in the region of the instance.
+func customMachineType(ctx context.Context, prices *priceBook, instance *golang2.RightsizingGcpComputeInstance, neededCpu, neededMemoryMb float64) *golang2.RightsizingGcpComputeInstance {
+	family := machineFamily(instance.MachineType)
+	limits, ok := customMachineFamilies[family]
+	if !ok {
+		return nil
+	}
+	region := instance.Region
+	if region == "" {
+		region = zoneToRegion(instance.Zone)
+	}
+	customPrices := prices.Custom(ctx, family, region, instance.Preemptible)
+
+	var best *golang2.RightsizingGcpComputeInstance
+	for cpu := max(int64(math.Ceil(neededCpu)), 1); cpu <= limits.maxCpu; cpu++ {
+		if !limits.validCpu(cpu) {
+			continue
+		}
+		memoryMb := max(int64(math.Ceil(neededMemoryMb)), cpu*limits.minMemoryPerCpuMb)
+		memoryMb = (memoryMb + customMachineMemoryStepMb - 1) / customMachineMemoryStepMb * customMachineMemoryStepMb
+		if memoryMb > limits.maxMemoryMb {
+			continue
+		}
+
+		standardMb, extendedMb := memoryMb, int64(0)
+		if memoryMb > cpu*limits.maxMemoryPerCpuMb {
+			if !limits.extendedMemory {
+				continue
+			}
+			standardMb = cpu * limits.maxMemoryPerCpuMb / customMachineMemoryStepMb * customMachineMemoryStepMb
+			extendedMb = memoryMb - standardMb
+		}
+
+		hourly := customPrices.Hourly(cpu, standardMb)
+		if extendedMb > 0 {
+			hourly += float64(extendedMb) / 1024 * prices.ExtendedMemory(ctx, family, region, instance.Preemptible)
+		}
+		cost := hourly*monthHours + instance.OsLicenseCost + instance.AcceleratorsCost
+		if best != nil && best.Cost <= cost {
+			continue
+		}
+		best = &golang2.RightsizingGcpComputeInstance{
+			Zone:             instance.Zone,
+			Region:           instance.Region,
+			MachineType:      customMachineTypeName(family, cpu, memoryMb, extendedMb > 0),
+			MachineFamily:    instance.MachineFamily,
+			Cpu:              cpu,
+			MemoryMb:         memoryMb,
+			Preemptible:      instance.Preemptible,
+			Cost:             cost,
+			OsLicenseCost:    instance.OsLicenseCost,
+			Accelerators:     instance.Accelerators,
+			AcceleratorsCost: instance.AcceleratorsCost,
+		}
+	}
+	return best
+}
+
+// recommendCustomMachineType replaces the recommendation of the instance with a custom machine type of
+// the current or recommended family when the CustomMachineTypes preference allows it and it costs less.
+// The needed vCPUs and memory are the usage statistics chosen by the preferences plus the breathing room.
+// ExcludeCustomInstances overrides CustomMachineTypes, no custom machine type is recommended when it is set.
+func recommendCustomMachineType(ctx context.Context, prices *priceBook, item *ComputeInstanceItem) {
+	rightsizing := item.Wastage.GetRightsizing()
+	if rightsizing == nil || rightsizing.Current == nil {
+		return
+	}
+	prefs := preferences.Export(item.Preferences)
+	preference := func(key string) string {
+		if v := prefs[key]; v != nil {
+			return *v
+		}
+		return ""
+	}
+	if preference("CustomMachineTypes") != "Yes" || preference("ExcludeCustomInstances") == "Yes" {
+		return
+	}
+
+	current := rightsizing.Current
+	cpuUsage := shared.Percentile(shared.CapDataPoints(item.Metrics["cpuUtilization"], preference("CPUUsageStatistic")), 100)
+	if cpuUsage == nil {
+		return
+	}
+	cpuBreathingRoom, _ := strconv.ParseFloat(preference("CPUBreathingRoom"), 64)
+	neededCpu := float64(current.Cpu) * *cpuUsage * (1 + cpuBreathingRoom/100)
+
+	neededMemoryMb := float64(current.MemoryMb)
+	if memoryUsage := shared.Percentile(shared.CapDataPoints(item.Metrics["memoryUtilization"], preference("MemoryUsageStatistic")), 100); memoryUsage != nil {
+		memoryBreathingRoom, _ := strconv.ParseFloat(preference("MemoryBreathingRoom"), 64)
+		neededMemoryMb = *memoryUsage / (1024 * 1024) * (1 + memoryBreathingRoom/100)
+	}
+
+	best := rightsizing.Recommended
+	if best == nil {
+		best = current
+	}
+	baseline := best
+	for _, instance := range []*golang2.RightsizingGcpComputeInstance{current, rightsizing.Recommended} {
+		if instance == nil {
+			continue
+		}
+		if custom := customMachineType(ctx, prices, instance, neededCpu, neededMemoryMb); custom != nil && custom.Cost < best.Cost-0.01 {
+			best = custom
+		}
+	}
+	if best == baseline {
+		return
+	}
+
+	rightsizing.Recommended = best
+	rightsizing.Description = strings.TrimSpace(rightsizing.Description + fmt.Sprintf(
+		"\nThe custom machine type %s fits the needed %.1f vCPUs and %.0f MB for less than the predefined machine types.",
+		best.MachineType, neededCpu, neededMemoryMb))
+}
@@ -0,0 +1,99 @@
+package compute_instance
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestCustomMachineType(t *testing.T) {
+	tests := []struct {
+		name           string
+		machineType    string
+		neededCpu      float64
+		neededMemoryMb float64
+		want           string
+		wantCost       float64
+	}{
+		{name: "even vCPUs", machineType: "n2-standard-8", neededCpu: 3, neededMemoryMb: 4096, want: "n2-custom-4-4096",
+			wantCost: (4*0.033174 + 4*0.004446) * monthHours},
+		{name: "vCPUs above 32 in steps of 4", machineType: "n2-standard-64", neededCpu: 33.5, neededMemoryMb: 65536, want: "n2-custom-36-65536"},
+		{name: "vCPUs above 80 in steps of 16", machineType: "n2-standard-128", neededCpu: 81, neededMemoryMb: 65536, want: "n2-custom-96-65536"},
+		{name: "minimum memory per vCPU", machineType: "n2-standard-8", neededCpu: 2, neededMemoryMb: 100, want: "n2-custom-2-1024"},
+		{name: "memory in steps of 256 MB", machineType: "n2-standard-8", neededCpu: 2, neededMemoryMb: 1500, want: "n2-custom-2-1536"},
+		// 3840 MB of extended memory cost less than 2 more vCPUs
+		{name: "extended memory", machineType: "n2-standard-8", neededCpu: 2, neededMemoryMb: 20000, want: "n2-custom-2-20224-ext",
+			wantCost: (2*0.033174 + 16*0.004446 + 3.75*0.00955) * monthHours},
+		{name: "no extended memory", machineType: "e2-standard-8", neededCpu: 2, neededMemoryMb: 20000, want: "e2-custom-4-20224"},
+		{name: "n1 without prefix", machineType: "n1-standard-4", neededCpu: 0.5, neededMemoryMb: 0, want: "custom-1-1024"},
+		{name: "n1 custom", machineType: "custom-4-8192", neededCpu: 3, neededMemoryMb: 5000, want: "custom-4-5120"},
+		{name: "n2d shapes", machineType: "n2d-standard-32", neededCpu: 50, neededMemoryMb: 1024, want: "n2d-custom-64-32768"},
+		{name: "too many vCPUs", machineType: "n2-standard-128", neededCpu: 129},
+		{name: "too much memory", machineType: "e2-standard-32", neededCpu: 2, neededMemoryMb: 200 * 1024},
+		{name: "no custom machine types", machineType: "c2-standard-8", neededCpu: 2, neededMemoryMb: 4096},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &golang2.RightsizingGcpComputeInstance{MachineType: tt.machineType, Zone: "us-central1-a"}
+			custom := customMachineType(context.Background(), nil, instance, tt.neededCpu, tt.neededMemoryMb)
+			got := ""
+			if custom != nil {
+				got = custom.MachineType
+			}
+			if got != tt.want {
+				t.Fatalf("machine type %q, want %q", got, tt.want)
+			}
+			if tt.wantCost != 0 && math.Abs(custom.Cost-tt.wantCost) > 1e-9 {
+				t.Errorf("cost %v, want %v", custom.Cost, tt.wantCost)
+			}
+		})
+	}
+}
+
+func TestRecommendCustomMachineType(t *testing.T) {
+	tests := []struct {
+		name                   string
+		customMachineTypes     string
+		excludeCustomInstances string
+		want                   string
+	}{
+		{name: "custom machine types", customMachineTypes: "Yes", excludeCustomInstances: "No", want: "n2-custom-2-3584"},
+		{name: "not enabled", customMachineTypes: "No", excludeCustomInstances: "No", want: "n2-standard-4"},
+		{name: "custom instances excluded", customMachineTypes: "Yes", excludeCustomInstances: "Yes", want: "n2-standard-4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cpu, memory []*golang2.DataPoint
+			for i := int64(0); i < 10; i++ {
+				cpu = append(cpu, &golang2.DataPoint{StartTime: wrapperspb.Int64(i * 60), EndTime: wrapperspb.Int64(i*60 + 60), Value: 0.2})
+				memory = append(memory, &golang2.DataPoint{StartTime: wrapperspb.Int64(i * 60), EndTime: wrapperspb.Int64(i*60 + 60), Value: 3 * 1024 * 1024 * 1024})
+			}
+			item := ComputeInstanceItem{
+				Preferences: []*golang.PreferenceItem{
+					{Key: "CustomMachineTypes", Value: wrapperspb.String(tt.customMachineTypes)},
+					{Key: "ExcludeCustomInstances", Value: wrapperspb.String(tt.excludeCustomInstances)},
+					{Key: "CPUBreathingRoom", Value: wrapperspb.String("10")},
+					{Key: "MemoryBreathingRoom", Value: wrapperspb.String("10")},
+				},
+				Metrics: map[string][]*golang2.DataPoint{"cpuUtilization": cpu, "memoryUtilization": memory},
+				Wastage: &golang2.GCPComputeOptimizationResponse{
+					Rightsizing: &golang2.GcpComputeInstanceRightsizingRecommendation{
+						Current: &golang2.RightsizingGcpComputeInstance{MachineType: "n2-standard-8", Region: "us-central1",
+							Cpu: 8, MemoryMb: 32768, Cost: 280},
+						Recommended: &golang2.RightsizingGcpComputeInstance{MachineType: "n2-standard-4", Region: "us-central1",
+							Cpu: 4, MemoryMb: 16384, Cost: 140},
+					},
+				},
+			}
+
+			recommendCustomMachineType(context.Background(), nil, &item)
+			if got := item.Wastage.Rightsizing.Recommended.MachineType; got != tt.want {
+				t.Errorf("recommended %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,491 @@
+package compute_instance
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/kaytu-io/kaytu/preferences"
+	"github.com/opengovern/plugin-gcp/plugin/processor/shared"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	util "github.com/opengovern/plugin-gcp/utils"
+	"google.golang.org/api/compute/v1"
+)
+
+const (
+	// diskMetricsPeriodSeconds is the alignment period of the disk datapoints, each one is the mean of the
+	// operations and bytes counted per minute so they are divided by it to get IOPS and throughput
+	diskMetricsPeriodSeconds = 60
+	// diskUsagePercentile is the percentile of the IOPS and throughput a disk type has to sustain
+	diskUsagePercentile = 99
+
+	// provisioned IOPS and throughput are rounded up to these steps
+	diskIopsStep       = 100
+	diskThroughputStep = 10
+)
+
+// diskType is the performance and us-central1 monthly price of a disk type. The IOPS and throughput of the
+// pd types grow with the size up to a per disk maximum, pd-extreme and hyperdisk IOPS and throughput are
+// provisioned and priced on top of the capacity, above the included baseline.
+type diskType struct {
+	gbPrice float64
+	// prices per provisioned IOPS and MiB/s above the baseline
+	iopsPrice       float64
+	throughputPrice float64
+
+	readIopsPerGb  float64
+	writeIopsPerGb float64
+	baseIops       float64
+	// minIops is the fewest IOPS that can be provisioned
+	minIops float64
+	maxIops float64
+
+	throughputPerGb float64
+	baseThroughput  float64
+	maxThroughput   float64
+
+	provisionedIops       bool
+	provisionedThroughput bool
+	minSizeGb             int64
+	boot                  bool
+}
+
+var diskTypes = map[string]diskType{
+	"pd-standard": {
+		gbPrice:         0.04,
+		readIopsPerGb:   0.75,
+		writeIopsPerGb:  1.5,
+		maxIops:         7500,
+		throughputPerGb: 0.12,
+		maxThroughput:   1200,
+		minSizeGb:       10,
+		boot:            true,
+	},
+	"pd-balanced": {
+		gbPrice:         0.1,
+		readIopsPerGb:   6,
+		writeIopsPerGb:  6,
+		baseIops:        3000,
+		maxIops:         80000,
+		throughputPerGb: 0.28,
+		baseThroughput:  140,
+		maxThroughput:   1200,
+		minSizeGb:       10,
+		boot:            true,
+	},
+	"pd-ssd": {
+		gbPrice:         0.17,
+		readIopsPerGb:   30,
+		writeIopsPerGb:  30,
+		baseIops:        6000,
+		maxIops:         100000,
+		throughputPerGb: 0.48,
+		baseThroughput:  240,
+		maxThroughput:   1200,
+		minSizeGb:       10,
+		boot:            true,
+	},
+	"pd-extreme": {
+		gbPrice:         0.125,
+		iopsPrice:       0.065,
+		minIops:         2500,
+		maxIops:         120000,
+		maxThroughput:   2200,
+		provisionedIops: true,
+		minSizeGb:       500,
+		boot:            true,
+	},
+	"hyperdisk-balanced": {
+		gbPrice:               0.08,
+		iopsPrice:             0.005,
+		throughputPrice:       0.04,
+		readIopsPerGb:         500,
+		writeIopsPerGb:        500,
+		baseIops:              3000,
+		minIops:               3000,
+		maxIops:               160000,
+		baseThroughput:        140,
+		maxThroughput:         2400,
+		provisionedIops:       true,
+		provisionedThroughput: true,
+		minSizeGb:             4,
+		boot:                  true,
+	},
+	"hyperdisk-throughput": {
+		gbPrice:         0.005,
+		throughputPrice: 0.25,
+		// 10 to 90 MiB/s per TiB provisioned, with 4 IOPS per provisioned MiB/s
+		throughputPerGb:       90.0 / 1024,
+		baseThroughput:        20,
+		maxThroughput:         600,
+		provisionedThroughput: true,
+		minSizeGb:             2048,
+	},
+}
+
+// hyperdiskThroughputIopsPerMb is the IOPS of a hyperdisk-throughput disk per provisioned MiB/s
+const hyperdiskThroughputIopsPerMb = 4
+
+// diskInstanceCap is the IOPS and throughput an instance with at least minCpu vCPUs can drive to the
+// disks of a type, the caps are shared by all the disks of the type attached to the instance
+type diskInstanceCap struct {
+	minCpu     int64
+	iops       float64
+	throughput float64
+}
+
+// diskInstanceCaps are the per instance limits by vCPU count, an instance with less vCPUs than the
+// first entry can not use the disk type
+var diskInstanceCaps = map[string][]diskInstanceCap{
+	"pd-standard":          {{1, 7500, 240}, {8, 7500, 400}, {16, 7500, 1200}},
+	"pd-balanced":          {{1, 15000, 240}, {8, 15000, 800}, {16, 20000, 1200}, {32, 50000, 1200}, {64, 80000, 1200}},
+	"pd-ssd":               {{1, 15000, 240}, {8, 15000, 800}, {16, 25000, 1200}, {32, 60000, 1200}, {64, 100000, 1200}},
+	"pd-extreme":           {{64, 120000, 2200}},
+	"hyperdisk-balanced":   {{1, 25000, 400}, {8, 50000, 800}, {22, 120000, 1800}, {44, 160000, 2400}},
+	"hyperdisk-throughput": {{1, 2400, 240}, {8, 3200, 800}, {16, 4800, 1200}, {32, 9600, 2400}},
+}
+
+// machineFamilyDiskTypes are the disk types the machine families support, families missing here
+// support the pd types other than pd-extreme
+var machineFamilyDiskTypes = map[string][]string{
+	"n2":  {"pd-standard", "pd-balanced", "pd-ssd", "pd-extreme", "hyperdisk-throughput"},
+	"n2d": {"pd-standard", "pd-balanced", "pd-ssd", "hyperdisk-throughput"},
+	"t2d": {"pd-standard", "pd-balanced", "pd-ssd", "hyperdisk-throughput"},
+	"t2a": {"pd-standard", "pd-balanced", "pd-ssd", "hyperdisk-throughput"},
+	"c3":  {"pd-balanced", "pd-ssd", "hyperdisk-balanced", "hyperdisk-throughput"},
+	"c3d": {"pd-balanced", "pd-ssd", "hyperdisk-balanced", "hyperdisk-throughput"},
+	"m3":  {"pd-balanced", "pd-ssd", "pd-extreme", "hyperdisk-balanced", "hyperdisk-throughput"},
+	"c4":  {"hyperdisk-balanced"},
+	"n4":  {"hyperdisk-balanced"},
+	"c4a": {"hyperdisk-balanced", "hyperdisk-throughput"},
+}
+
+var defaultDiskTypes = []string{"pd-standard", "pd-balanced", "pd-ssd"}
+
+// diskUsage is the p99 IOPS and throughput (MiB/s) of a disk
+type diskUsage struct {
+	readIops, writeIops             float64
+	readThroughput, writeThroughput float64
+}
+
+// diskConfig is a disk type with its size and provisioned IOPS and throughput, and the resulting limits
+type diskConfig struct {
+	diskType              string
+	sizeGb                int64
+	provisionedIops       int64
+	provisionedThroughput int64
+
+	readIops, writeIops float64
+	throughput          float64
+}
+
+// add returns the usage of both disks
+func (u diskUsage) add(other diskUsage) diskUsage {
+	return diskUsage{
+		readIops:        u.readIops + other.readIops,
+		writeIops:       u.writeIops + other.writeIops,
+		readThroughput:  u.readThroughput + other.readThroughput,
+		writeThroughput: u.writeThroughput + other.writeThroughput,
+	}
+}
+
+// without returns the caps left to a disk once the usage of the other disks of the type is taken out,
+// their reads and writes both count against the caps
+func (c diskInstanceCap) without(usage diskUsage) diskInstanceCap {
+	c.iops = max(c.iops-usage.readIops-usage.writeIops, 0)
+	c.throughput = max(c.throughput-usage.readThroughput-usage.writeThroughput, 0)
+	return c
+}
+
+func diskInstanceLimit(diskTypeName string, cpu int64) *diskInstanceCap {
+	var limit *diskInstanceCap
+	for _, c := range diskInstanceCaps[diskTypeName] {
+		if cpu >= c.minCpu {
+			c := c
+			limit = &c
+		}
+	}
+	return limit
+}
+
+// newDiskConfig returns the limits of a disk of the type, size and provisioned performance, capped by the
+// per instance limits
+func newDiskConfig(name string, sizeGb, provisionedIops, provisionedThroughput int64, limit diskInstanceCap) diskConfig {
+	t := diskTypes[name]
+	config := diskConfig{
+		diskType:              name,
+		sizeGb:                sizeGb,
+		provisionedIops:       provisionedIops,
+		provisionedThroughput: provisionedThroughput,
+	}
+	size := float64(sizeGb)
+	switch {
+	case name == "hyperdisk-throughput":
+		config.readIops = float64(provisionedThroughput * hyperdiskThroughputIopsPerMb)
+		config.writeIops = config.readIops
+		config.throughput = float64(provisionedThroughput)
+	case t.provisionedIops:
+		config.readIops = float64(provisionedIops)
+		config.writeIops = config.readIops
+		config.throughput = t.maxThroughput
+		if t.provisionedThroughput {
+			config.throughput = float64(provisionedThroughput)
+		}
+	default:
+		config.readIops = min(t.baseIops+t.readIopsPerGb*size, t.maxIops)
+		config.writeIops = min(t.baseIops+t.writeIopsPerGb*size, t.maxIops)
+		config.throughput = min(t.baseThroughput+t.throughputPerGb*size, t.maxThroughput)
+	}
+	config.readIops = min(config.readIops, limit.iops)
+	config.writeIops = min(config.writeIops, limit.iops)
+	config.throughput = min(config.throughput, limit.throughput)
+	return config
+}
+
+// cost is the us-central1 monthly price of the disk
+func (c diskConfig) cost() float64 {
+	t := diskTypes[c.diskType]
+	cost := float64(c.sizeGb) * t.gbPrice
+	if t.provisionedIops {
+		cost += float64(max(c.provisionedIops-int64(t.baseIops), 0)) * t.iopsPrice
+	}
+	if t.provisionedThroughput {
+		cost += float64(max(c.provisionedThroughput-int64(t.baseThroughput), 0)) * t.throughputPrice
+	}
+	return cost
+}
+
+// fits reports whether the disk sustains the usage, the provisioned IOPS and throughput of the provisioned
+// types are shared by reads and writes
+func (c diskConfig) fits(usage diskUsage) bool {
+	t := diskTypes[c.diskType]
+	if t.provisionedIops || t.provisionedThroughput {
+		return usage.readIops+usage.writeIops <= c.readIops &&
+			usage.readThroughput+usage.writeThroughput <= c.throughput
+	}
+	return usage.readIops <= c.readIops && usage.writeIops <= c.writeIops &&
+		usage.readThroughput <= c.throughput && usage.writeThroughput <= c.throughput
+}
+
+func roundUp(value float64, step int64) int64 {
+	return int64(math.Ceil(value/float64(step))) * step
+}
+
+// sizeDiskConfig returns the disk of the type with at least the size of the current disk and the IOPS and
+// throughput provisioned to the usage, false when the type can not sustain it
+func sizeDiskConfig(name string, sizeGb int64, usage diskUsage, limit diskInstanceCap) (diskConfig, bool) {
+	t := diskTypes[name]
+	sizeGb = max(sizeGb, t.minSizeGb)
+	size := float64(sizeGb)
+
+	var iops, throughput int64
+	switch {
+	case name == "hyperdisk-throughput":
+		throughput = roundUp(max(usage.readThroughput+usage.writeThroughput,
+			(usage.readIops+usage.writeIops)/hyperdiskThroughputIopsPerMb), diskThroughputStep)
+		throughput = max(throughput, int64(t.baseThroughput), int64(math.Ceil(size*10/1024)))
+		throughput = min(throughput, int64(min(t.throughputPerGb*size, t.maxThroughput)))
+	case t.provisionedIops:
+		iops = max(roundUp(usage.readIops+usage.writeIops, diskIopsStep), int64(t.minIops))
+		iops = min(iops, int64(t.maxIops))
+		if t.readIopsPerGb > 0 {
+			iops = min(iops, int64(max(t.readIopsPerGb*size, t.minIops)))
+		}
+		if t.provisionedThroughput {
+			throughput = max(roundUp(usage.readThroughput+usage.writeThroughput, diskThroughputStep), int64(t.baseThroughput))
+			throughput = min(throughput, int64(t.maxThroughput))
+		}
+	}
+
+	config := newDiskConfig(name, sizeGb, iops, throughput, limit)
+	return config, config.fits(usage)
+}
+
+// p99DiskUsage returns the p99 IOPS and throughput of the disk from its datapoints, nil without datapoints
+func p99DiskUsage(metrics map[string][]*golang2.DataPoint) *diskUsage {
+	rate := func(key string, unit float64) (float64, bool) {
+		value := shared.Percentile(metrics[key], diskUsagePercentile)
+		if value == nil {
+			return 0, false
+		}
+		return *value / diskMetricsPeriodSeconds / unit, true
+	}
+	var usage diskUsage
+	var ok [4]bool
+	usage.readIops, ok[0] = rate("DiskReadIOPS", 1)
+	usage.writeIops, ok[1] = rate("DiskWriteIOPS", 1)
+	usage.readThroughput, ok[2] = rate("DiskReadThroughput", 1024*1024)
+	usage.writeThroughput, ok[3] = rate("DiskWriteThroughput", 1024*1024)
+	if ok == [4]bool{} {
+		return nil
+	}
+	return &usage
+}
+
+// adviseDisk returns the cheapest disk type supported by the machine type sustaining the p99 usage of the
+// disk within the per instance caps, with the cost of the current disk for reference. The caps are shared,
+// others is the p99 usage of the other disks of the instance by disk type. The size is never decreased,
+// disks can not shrink.
+func adviseDisk(disk compute.Disk, boot bool, machineType string, cpu int64, usage diskUsage, pinnedType string, others map[string]diskUsage) (*diskConfig, *diskConfig) {
+	currentType := util.TrimmedString(disk.Type, "/")
+	if _, ok := diskTypes[currentType]; !ok {
+		return nil, nil
+	}
+	currentLimit := diskInstanceLimit(currentType, cpu)
+	if currentLimit == nil {
+		currentLimit = &diskInstanceCap{iops: math.Inf(1), throughput: math.Inf(1)}
+	}
+	current := newDiskConfig(currentType, disk.SizeGb, disk.ProvisionedIops, disk.ProvisionedThroughput, currentLimit.without(others[currentType]))
+
+	candidates := defaultDiskTypes
+	if types, ok := machineFamilyDiskTypes[machineFamily(machineType)]; ok {
+		candidates = types
+	}
+	var best *diskConfig
+	for _, name := range candidates {
+		if (pinnedType != "" && name != pinnedType) || (boot && !diskTypes[name].boot) {
+			continue
+		}
+		limit := diskInstanceLimit(name, cpu)
+		if limit == nil {
+			continue
+		}
+		config, ok := sizeDiskConfig(name, disk.SizeGb, usage, limit.without(others[name]))
+		if ok && (best == nil || config.cost() < best.cost()) {
+			best = &config
+		}
+	}
+	return &current, best
+}
+
+// adviseDisks replaces the disk recommendations with the cheapest disk types sustaining the p99 IOPS and
+// throughput of the disks on the recommended machine type. The prices are the us-central1 ones scaled by
+// the cost of the current disk over its us-central1 price, for the region. The recommendation is kept
+// when no disk type fits, or when the one that does costs more and upsizing is excluded. The disks are
+// advised in order, each within the caps left by the others on their recommended or current types.
+func adviseDisks(item *ComputeInstanceItem) {
+	rightsizing := item.Wastage.GetRightsizing()
+	if rightsizing == nil || rightsizing.Current == nil {
+		return
+	}
+	machine := rightsizing.Current
+	if rightsizing.Recommended != nil {
+		machine = rightsizing.Recommended
+	}
+	prefs := preferences.Export(item.Preferences)
+	pinnedType, pinCurrentType, excludeUpsizing := "", false, false
+	if v := prefs["DiskType"]; v != nil {
+		pinnedType = *v
+	}
+	for _, p := range item.Preferences {
+		if p.Key == "DiskType" && p.Pinned {
+			pinCurrentType = true
+		}
+	}
+	if v := prefs["ExcludeUpsizingFeature"]; v != nil {
+		excludeUpsizing = *v == "Yes"
+	}
+
+	bootDisk := ""
+	for _, d := range item.Instance.GetDisks() {
+		if d.GetBoot() {
+			bootDisk = util.TrimmedString(d.GetSource(), "/")
+		}
+	}
+
+	usages := make(map[string]*diskUsage)
+	types := make(map[string]string)
+	for _, d := range item.Disks {
+		id := strconv.FormatUint(d.Id, 10)
+		usages[id] = p99DiskUsage(item.DisksMetrics[id])
+		types[id] = util.TrimmedString(d.Type, "/")
+	}
+	// othersUsage is the usage of the disks other than the one with the id, by disk type
+	othersUsage := func(id string) map[string]diskUsage {
+		others := make(map[string]diskUsage)
+		for other, usage := range usages {
+			if other != id && usage != nil {
+				others[types[other]] = others[types[other]].add(*usage)
+			}
+		}
+		return others
+	}
+
+	for _, d := range item.Disks {
+		id := strconv.FormatUint(d.Id, 10)
+		rs := item.Wastage.GetVolumesRightsizing()[id]
+		if rs == nil || rs.Current == nil {
+			continue
+		}
+		usage := usages[id]
+		if usage == nil {
+			continue
+		}
+		diskPinnedType := pinnedType
+		if pinCurrentType {
+			diskPinnedType = util.TrimmedString(d.Type, "/")
+		}
+		current, best := adviseDisk(d, d.Name == bootDisk, machine.MachineType, machine.Cpu, *usage, diskPinnedType, othersUsage(id))
+		if best == nil || current.cost() <= 0 {
+			continue
+		}
+		regionFactor := rs.Current.Cost / current.cost()
+		cost := best.cost() * regionFactor
+		if excludeUpsizing && cost > rs.Current.Cost {
+			continue
+		}
+
+		types[id] = best.diskType
+		rs.Recommended = &golang2.RightsizingGcpComputeDisk{
+			Zone:                 rs.Current.Zone,
+			Region:               rs.Current.Region,
+			DiskType:             best.diskType,
+			DiskSize:             best.sizeGb,
+			ReadIopsLimit:        int64(best.readIops),
+			WriteIopsLimit:       int64(best.writeIops),
+			ReadThroughputLimit:  best.throughput,
+			WriteThroughputLimit: best.throughput,
+			Cost:                 cost,
+			// the limits above are capped by the instance, the provisioned values are the priced ones
+			ProvisionedIops:       best.provisionedIops,
+			ProvisionedThroughput: best.provisionedThroughput,
+		}
+		description := fmt.Sprintf("%s sustains the p99 usage of %.0f read and %.0f write IOPS, %.1f read and %.1f write MiB/s",
+			best.diskType, usage.readIops, usage.writeIops, usage.readThroughput, usage.writeThroughput)
+		if best.provisionedIops > 0 || best.provisionedThroughput > 0 {
+			description += fmt.Sprintf(" with %s", best.provisionedString())
+		}
+		description += fmt.Sprintf(" within the limits of %s.", machine.MachineType)
+		rs.Description = description
+	}
+}
+
+func (c diskConfig) provisionedString() string {
+	var parts []string
+	if c.provisionedIops > 0 {
+		parts = append(parts, fmt.Sprintf("%d provisioned IOPS", c.provisionedIops))
+	}
+	if c.provisionedThroughput > 0 {
+		parts = append(parts, fmt.Sprintf("%d MiB/s provisioned throughput", c.provisionedThroughput))
+	}
+	return strings.Join(parts, " and ")
+}
+
+// provisionedChanged reports whether the recommended disk provisions other IOPS or throughput than the disk has
+func provisionedChanged(d compute.Disk, rec *golang2.RightsizingGcpComputeDisk) bool {
+	return (rec.ProvisionedIops > 0 && rec.ProvisionedIops != d.ProvisionedIops) ||
+		(rec.ProvisionedThroughput > 0 && rec.ProvisionedThroughput != d.ProvisionedThroughput)
+}
+
+// provisionedFlags are the gcloud flags setting the provisioned IOPS and throughput of the recommended disk
+func provisionedFlags(rec *golang2.RightsizingGcpComputeDisk) string {
+	flags := ""
+	if rec.ProvisionedIops > 0 {
+		flags += fmt.Sprintf(" --provisioned-iops=%d", rec.ProvisionedIops)
+	}
+	if rec.ProvisionedThroughput > 0 {
+		flags += fmt.Sprintf(" --provisioned-throughput=%d", rec.ProvisionedThroughput)
+	}
+	return flags
+}
@@ -0,0 +1,213 @@
+package compute_instance
+
+import (
+	"math"
+	"testing"
+
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestSizeDiskConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		diskType       string
+		sizeGb         int64
+		cpu            int64
+		usage          diskUsage
+		others         diskUsage
+		wantOk         bool
+		wantSizeGb     int64
+		wantIops       int64
+		wantThroughput int64
+	}{
+		{name: "pd-balanced", diskType: "pd-balanced", sizeGb: 100, cpu: 8,
+			usage: diskUsage{readIops: 1000, writeIops: 500, readThroughput: 50, writeThroughput: 50}, wantOk: true, wantSizeGb: 100},
+		{name: "pd-balanced below the usage", diskType: "pd-balanced", sizeGb: 100, cpu: 8,
+			usage: diskUsage{readIops: 5000}, wantSizeGb: 100},
+		{name: "pd-extreme minimum size", diskType: "pd-extreme", sizeGb: 100, cpu: 64,
+			usage: diskUsage{readIops: 3000, writeIops: 950, readThroughput: 100}, wantOk: true, wantSizeGb: 500, wantIops: 4000},
+		{name: "hyperdisk-throughput minimum size", diskType: "hyperdisk-throughput", sizeGb: 100, cpu: 8,
+			usage: diskUsage{readIops: 100, writeIops: 100, readThroughput: 30, writeThroughput: 20}, wantOk: true, wantSizeGb: 2048, wantThroughput: 50},
+		{name: "hyperdisk-balanced", diskType: "hyperdisk-balanced", sizeGb: 10, cpu: 8,
+			usage: diskUsage{readIops: 4000, writeIops: 1000, readThroughput: 100, writeThroughput: 100}, wantOk: true, wantSizeGb: 10,
+			wantIops: 5000, wantThroughput: 200},
+		{name: "hyperdisk-balanced IOPS per GB", diskType: "hyperdisk-balanced", sizeGb: 10, cpu: 8,
+			usage: diskUsage{readIops: 6000}, wantSizeGb: 10, wantIops: 5000, wantThroughput: 140},
+		{name: "pd-ssd", diskType: "pd-ssd", sizeGb: 1000, cpu: 8,
+			usage: diskUsage{readIops: 10000}, wantOk: true, wantSizeGb: 1000},
+		{name: "pd-ssd caps shared with other disks", diskType: "pd-ssd", sizeGb: 1000, cpu: 8,
+			usage: diskUsage{readIops: 10000}, others: diskUsage{readIops: 4000, writeIops: 4000}, wantSizeGb: 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := diskInstanceLimit(tt.diskType, tt.cpu)
+			if limit == nil {
+				t.Fatalf("%s is not supported with %d vCPUs", tt.diskType, tt.cpu)
+			}
+			config, ok := sizeDiskConfig(tt.diskType, tt.sizeGb, tt.usage, limit.without(tt.others))
+			if ok != tt.wantOk {
+				t.Errorf("fits %v, want %v: %+v", ok, tt.wantOk, config)
+			}
+			if config.sizeGb != tt.wantSizeGb || config.provisionedIops != tt.wantIops || config.provisionedThroughput != tt.wantThroughput {
+				t.Errorf("%d GB with %d IOPS and %d MiB/s, want %d GB with %d IOPS and %d MiB/s", config.sizeGb,
+					config.provisionedIops, config.provisionedThroughput, tt.wantSizeGb, tt.wantIops, tt.wantThroughput)
+			}
+		})
+	}
+}
+
+func TestDiskConfigFits(t *testing.T) {
+	tests := []struct {
+		name     string
+		diskType string
+		usage    diskUsage
+		want     bool
+	}{
+		// the limits of the pd types are separate for reads and writes
+		{name: "pd reads and writes", diskType: "pd-balanced", usage: diskUsage{readIops: 2000, writeIops: 2000, readThroughput: 80, writeThroughput: 80}, want: true},
+		{name: "pd throughput", diskType: "pd-balanced", usage: diskUsage{readThroughput: 120}},
+		{name: "pd write IOPS", diskType: "pd-standard", usage: diskUsage{writeIops: 3500}},
+		// provisioned IOPS and throughput are shared by reads and writes
+		{name: "provisioned reads and writes", diskType: "hyperdisk-balanced", usage: diskUsage{readIops: 2000, writeIops: 2000}},
+		{name: "provisioned within", diskType: "hyperdisk-balanced", usage: diskUsage{readIops: 1500, writeIops: 1500, readThroughput: 50, writeThroughput: 50}, want: true},
+		{name: "provisioned throughput", diskType: "hyperdisk-balanced", usage: diskUsage{readThroughput: 60, writeThroughput: 60}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := diskConfig{diskType: tt.diskType, readIops: 3000, writeIops: 3000, throughput: 100}
+			if got := config.fits(tt.usage); got != tt.want {
+				t.Errorf("fits %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdviseDisk(t *testing.T) {
+	lowUsage := diskUsage{readIops: 50, writeIops: 50, readThroughput: 5, writeThroughput: 5}
+
+	tests := []struct {
+		name        string
+		diskType    string
+		sizeGb      int64
+		boot        bool
+		machineType string
+		cpu         int64
+		usage       diskUsage
+		pinnedType  string
+		others      map[string]diskUsage
+		want        string
+		wantSizeGb  int64
+	}{
+		{name: "hyperdisk-throughput for a large disk", diskType: "pd-ssd", sizeGb: 4096, machineType: "n2-standard-8", cpu: 8,
+			usage: lowUsage, want: "hyperdisk-throughput", wantSizeGb: 4096},
+		{name: "boot disk", diskType: "pd-ssd", sizeGb: 4096, boot: true, machineType: "n2-standard-8", cpu: 8,
+			usage: lowUsage, want: "pd-standard", wantSizeGb: 4096},
+		{name: "pinned type", diskType: "pd-balanced", sizeGb: 4096, machineType: "n2-standard-8", cpu: 8,
+			usage: lowUsage, pinnedType: "pd-ssd", want: "pd-ssd", wantSizeGb: 4096},
+		{name: "hyperdisk-throughput at its 2 TiB minimum", diskType: "pd-ssd", sizeGb: 100, machineType: "n2-standard-8", cpu: 8,
+			usage: lowUsage, pinnedType: "hyperdisk-throughput", want: "hyperdisk-throughput", wantSizeGb: 2048},
+		{name: "pd-extreme with 64 vCPUs", diskType: "pd-ssd", sizeGb: 1000, machineType: "n2-standard-64", cpu: 64,
+			usage: diskUsage{readIops: 90000, writeIops: 20000}, want: "pd-extreme", wantSizeGb: 1000},
+		{name: "no pd-extreme below 64 vCPUs", diskType: "pd-ssd", sizeGb: 1000, machineType: "n2-standard-32", cpu: 32,
+			usage: diskUsage{readIops: 90000, writeIops: 20000}},
+		{name: "family disk types", diskType: "pd-ssd", sizeGb: 100, machineType: "c3-standard-8", cpu: 8,
+			usage: diskUsage{readIops: 10000, writeIops: 1000}, want: "hyperdisk-balanced", wantSizeGb: 100},
+		{name: "within the caps", diskType: "pd-ssd", sizeGb: 1000, machineType: "n2-standard-8", cpu: 8,
+			usage: diskUsage{readIops: 10000}, want: "pd-ssd", wantSizeGb: 1000},
+		{name: "caps shared with the other disks", diskType: "pd-ssd", sizeGb: 1000, machineType: "n2-standard-8", cpu: 8,
+			usage: diskUsage{readIops: 10000}, others: map[string]diskUsage{"pd-ssd": {readIops: 8000}}},
+		{name: "other disks on another type", diskType: "pd-ssd", sizeGb: 1000, machineType: "n2-standard-8", cpu: 8,
+			usage: diskUsage{readIops: 10000}, others: map[string]diskUsage{"pd-balanced": {readIops: 8000}}, want: "pd-ssd", wantSizeGb: 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			disk := compute.Disk{Type: "projects/p/zones/us-central1-a/diskTypes/" + tt.diskType, SizeGb: tt.sizeGb}
+			current, best := adviseDisk(disk, tt.boot, tt.machineType, tt.cpu, tt.usage, tt.pinnedType, tt.others)
+			if current == nil || current.diskType != tt.diskType {
+				t.Fatalf("current disk %+v", current)
+			}
+			got, gotSizeGb := "", int64(0)
+			if best != nil {
+				got, gotSizeGb = best.diskType, best.sizeGb
+			}
+			if got != tt.want || gotSizeGb != tt.wantSizeGb {
+				t.Errorf("%s of %d GB, want %s of %d GB", got, gotSizeGb, tt.want, tt.wantSizeGb)
+			}
+			if tt.want == "pd-extreme" && best.provisionedIops != 110000 {
+				t.Errorf("%d provisioned IOPS, want 110000", best.provisionedIops)
+			}
+		})
+	}
+
+	current, best := adviseDisk(compute.Disk{Type: "local-ssd", SizeGb: 375}, false, "n2-standard-8", 8, lowUsage, "", nil)
+	if current != nil || best != nil {
+		t.Errorf("unknown disk type advised: %+v %+v", current, best)
+	}
+}
+
+func TestP99DiskUsage(t *testing.T) {
+	constant := func(value float64) []*golang2.DataPoint {
+		var dps []*golang2.DataPoint
+		for i := int64(0); i < 100; i++ {
+			dps = append(dps, &golang2.DataPoint{StartTime: wrapperspb.Int64(i * 60), EndTime: wrapperspb.Int64(i*60 + 60), Value: value})
+		}
+		return dps
+	}
+	spiky := constant(600)
+	spiky[0].Value = 600000
+
+	tests := []struct {
+		name    string
+		metrics map[string][]*golang2.DataPoint
+		want    *diskUsage
+	}{
+		{name: "no datapoints"},
+		{name: "per minute counts", metrics: map[string][]*golang2.DataPoint{
+			"DiskReadIOPS":        constant(6000),
+			"DiskWriteIOPS":       constant(3000),
+			"DiskReadThroughput":  constant(60 * 1024 * 1024),
+			"DiskWriteThroughput": constant(30 * 1024 * 1024),
+		}, want: &diskUsage{readIops: 100, writeIops: 50, readThroughput: 1, writeThroughput: 0.5}},
+		{name: "missing metrics", metrics: map[string][]*golang2.DataPoint{"DiskWriteIOPS": constant(3000)},
+			want: &diskUsage{writeIops: 50}},
+		// the single spike is above the p99 of the 100 datapoints
+		{name: "p99", metrics: map[string][]*golang2.DataPoint{"DiskReadIOPS": spiky},
+			want: &diskUsage{readIops: (600 + 0.01*(600000-600)) / 60}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p99DiskUsage(tt.metrics)
+			switch {
+			case got == nil && tt.want == nil:
+			case got == nil || tt.want == nil:
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			case math.Abs(got.readIops-tt.want.readIops) > 1e-9 || math.Abs(got.writeIops-tt.want.writeIops) > 1e-9 ||
+				math.Abs(got.readThroughput-tt.want.readThroughput) > 1e-9 || math.Abs(got.writeThroughput-tt.want.writeThroughput) > 1e-9:
+				t.Errorf("got %+v, want %+v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestDiskConfigCost(t *testing.T) {
+	tests := []struct {
+		name   string
+		config diskConfig
+		want   float64
+	}{
+		{name: "pd-ssd", config: diskConfig{diskType: "pd-ssd", sizeGb: 100}, want: 17},
+		// every provisioned pd-extreme IOPS is billed
+		{name: "pd-extreme", config: diskConfig{diskType: "pd-extreme", sizeGb: 500, provisionedIops: 4000}, want: 500*0.125 + 4000*0.065},
+		{name: "hyperdisk-balanced baseline", config: diskConfig{diskType: "hyperdisk-balanced", sizeGb: 100, provisionedIops: 5000,
+			provisionedThroughput: 200}, want: 100*0.08 + 2000*0.005 + 60*0.04},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.cost(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("cost %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
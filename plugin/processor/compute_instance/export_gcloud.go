@@ -0,0 +1,218 @@
+package compute_instance
+
+import (
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/utils"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+	"github.com/opengovern/plugin-gcp/plugin/processor/shared"
+	util "github.com/opengovern/plugin-gcp/utils"
+)
+
+// GcloudCommands returns the commented gcloud commands applying the recommendations of the instance and its disks,
+// the instance is stopped once for all the changes that need it
+func (i ComputeInstanceItem) GcloudCommands() []string {
+	if i.Wastage == nil || i.Wastage.Rightsizing == nil || i.Wastage.Rightsizing.Current == nil {
+		return nil
+	}
+	target := fmt.Sprintf("--project=%s --zone=%s", i.ProjectId, i.Region)
+	current, recommended := i.Wastage.Rightsizing.Current, i.Wastage.Rightsizing.Recommended
+
+	var comments, stopped, online []string
+
+	if recommended != nil && (current.MachineType != recommended.MachineType || current.Preemptible != recommended.Preemptible) {
+		currentCost, recommendedCost := i.InstanceCosts()
+		comments = append(comments,
+			fmt.Sprintf("# Current: %s (%d vCPU, %d MB)%s, cost %s per month", current.MachineType, current.Cpu, current.MemoryMb,
+				provisioningModelSuffix(current.Preemptible), utils.FormatPriceFloat(currentCost.Cost())),
+			fmt.Sprintf("# Recommended: %s (%d vCPU, %d MB)%s, saving %s per month (%s)", recommended.MachineType, recommended.Cpu,
+				recommended.MemoryMb, provisioningModelSuffix(recommended.Preemptible),
+				utils.FormatPriceFloat(currentCost.Cost()-recommendedCost.Cost()), i.SavingsType()),
+			fmt.Sprintf("# CPU usage: avg %s, max %s", utils.Percentage(shared.PWrapperDouble(i.Wastage.Rightsizing.Cpu.GetAvg())),
+				utils.Percentage(shared.PWrapperDouble(i.Wastage.Rightsizing.Cpu.GetMax()))),
+		)
+		if current.MachineType != recommended.MachineType {
+			stopped = append(stopped, fmt.Sprintf("gcloud compute instances set-machine-type %s %s --machine-type=%s",
+				i.Name, target, recommended.MachineType))
+		}
+		if current.Preemptible != recommended.Preemptible {
+			if recommended.Preemptible {
+				stopped = append(stopped,
+					"# Spot VMs can be preempted at any time, make sure the workload tolerates it",
+					fmt.Sprintf("gcloud compute instances set-scheduling %s %s --provisioning-model=SPOT --instance-termination-action=STOP",
+						i.Name, target))
+			} else {
+				stopped = append(stopped, fmt.Sprintf("gcloud compute instances set-scheduling %s %s --provisioning-model=STANDARD",
+					i.Name, target))
+			}
+		}
+	}
+
+	bootDisk := ""
+	deviceNames := make(map[string]string)
+	if i.Instance != nil {
+		for _, d := range i.Instance.Disks {
+			if d.GetBoot() {
+				bootDisk = util.TrimmedString(d.GetSource(), "/")
+			}
+			deviceNames[util.TrimmedString(d.GetSource(), "/")] = d.GetDeviceName()
+		}
+	}
+
+	for _, d := range i.Disks {
+		disk, ok := i.Wastage.VolumesRightsizing[strconv.FormatUint(d.Id, 10)]
+		if !ok || disk == nil || disk.Current == nil || disk.Recommended == nil {
+			continue
+		}
+		cur, rec := disk.Current, disk.Recommended
+		zone := util.TrimmedString(d.Zone, "/")
+		diskTarget := fmt.Sprintf("--project=%s --zone=%s", i.ProjectId, zone)
+
+		var diskComments []string
+		diskComments = append(diskComments,
+			fmt.Sprintf("# Disk %s: %s / %d GB -> %s / %d GB, saving %s per month", d.Name, cur.DiskType, cur.DiskSize,
+				rec.DiskType, rec.DiskSize, utils.FormatPriceFloat(cur.Cost-rec.Cost)))
+
+		switch {
+		case cur.DiskType != rec.DiskType:
+			size := rec.DiskSize
+			if size < cur.DiskSize {
+				diskComments = append(diskComments, fmt.Sprintf(
+					"# A disk restored from a snapshot can not be smaller than the snapshot, keeping %d GB instead of %d GB",
+					cur.DiskSize, rec.DiskSize))
+				size = cur.DiskSize
+			}
+			snapshot := gcp.ComputeResourceName(fmt.Sprintf("%s-%s", d.Name, time.Now().Format("20060102")))
+			newDisk := gcp.ComputeResourceName(fmt.Sprintf("%s-%s", d.Name, rec.DiskType))
+			// keep the device name so the disk is mounted at the same /dev/disk/by-id path
+			attachFlags := ""
+			if deviceName := deviceNames[d.Name]; deviceName != "" {
+				attachFlags = " --device-name=" + deviceName
+			}
+			if d.Name == bootDisk {
+				attachFlags += " --boot"
+			}
+			comments = append(comments, diskComments...)
+			stopped = append(stopped,
+				fmt.Sprintf("gcloud compute snapshots create %s --project=%s --source-disk=%s --source-disk-zone=%s",
+					snapshot, i.ProjectId, d.Name, zone),
+				fmt.Sprintf("gcloud compute disks create %s %s --source-snapshot=%s --type=%s --size=%dGB%s",
+					newDisk, diskTarget, snapshot, rec.DiskType, size, provisionedFlags(rec)),
+				fmt.Sprintf("gcloud compute instances detach-disk %s %s --disk=%s", i.Name, target, d.Name),
+				fmt.Sprintf("gcloud compute instances attach-disk %s %s --disk=%s%s", i.Name, target, newDisk, attachFlags),
+				"# The old disk is billed until it is deleted, the saving is only realized once it is. It is deleted after the",
+				"# new disk is verified to be attached, set KEEP_OLD_DISKS=yes to keep it. The snapshot above is kept as a backup.",
+				fmt.Sprintf("if [ \"${KEEP_OLD_DISKS:-no}\" != \"yes\" ] && gcloud compute instances describe %s %s "+
+					"--format=\"value(disks[].source)\" | tr ';' '\\n' | grep -q '/disks/%s$'; then", i.Name, target, newDisk),
+				fmt.Sprintf("  gcloud compute disks delete %s %s --quiet", d.Name, diskTarget),
+				"else",
+				fmt.Sprintf("  echo \"Kept the old disk %s, it is billed until it is deleted\" >&2", d.Name),
+				"fi",
+			)
+		case rec.DiskSize > cur.DiskSize:
+			comments = append(comments, diskComments...)
+			online = append(online, fmt.Sprintf("gcloud compute disks resize %s %s --size=%dGB --quiet",
+				d.Name, diskTarget, rec.DiskSize))
+		case rec.DiskSize < cur.DiskSize:
+			comments = append(comments, diskComments...)
+			comments = append(comments, "# Disks can not shrink, copy the data to a new smaller disk to apply this recommendation")
+		case provisionedChanged(d, rec):
+			comments = append(comments, diskComments...)
+			online = append(online, fmt.Sprintf("gcloud compute disks update %s %s%s",
+				d.Name, diskTarget, provisionedFlags(rec)))
+		}
+	}
+
+	// the schedule is an alternative to the changes above, it is left commented out
+	var scheduled []string
+	if i.Schedule != nil {
+		scheduled = append(scheduled,
+			fmt.Sprintf("# Alternative: run on the schedule %s instead of 24/7, saving %s per month",
+				i.Schedule.String(), utils.FormatPriceFloat(i.ScheduleSaving())),
+			"# The Compute Engine service agent needs the permission to start and stop the instance")
+		for _, command := range i.scheduleGcloudCommands(target, zoneToRegion(i.Region)) {
+			scheduled = append(scheduled, "# "+command)
+		}
+	}
+
+	if len(stopped) == 0 && len(online) == 0 && len(comments) == 0 && len(scheduled) == 0 {
+		return nil
+	}
+
+	commands := []string{fmt.Sprintf("# ---- %s (%s) ----", i.Name, i.Region)}
+	commands = append(commands, comments...)
+	if i.Wastage.Rightsizing.Description != "" {
+		for _, line := range strings.Split(strings.TrimSpace(i.Wastage.Rightsizing.Description), "\n") {
+			commands = append(commands, "# "+line)
+		}
+	}
+	commands = append(commands, online...)
+	if len(stopped) > 0 {
+		commands = append(commands, "# The instance is stopped while the changes below are applied")
+		commands = append(commands, fmt.Sprintf("gcloud compute instances stop %s %s", i.Name, target))
+		commands = append(commands, stopped...)
+		commands = append(commands, fmt.Sprintf("gcloud compute instances start %s %s", i.Name, target))
+		commands = append(commands, fmt.Sprintf("gcloud compute instances describe %s %s --format=\"value(status,machineType.basename())\"",
+			i.Name, target))
+	}
+	commands = append(commands, scheduled...)
+	return commands
+}
+
+func provisioningModelSuffix(preemptible bool) string {
+	if preemptible {
+		return " preemptible"
+	}
+	return ""
+}
+
+// exportGcloudScripts writes a remediation shell script per project to dir
+func (m *ComputeInstanceProcessor) exportGcloudScripts(dir string) error {
+	projects := make(map[string][]ComputeInstanceItem)
+	m.items.Range(func(_ string, item ComputeInstanceItem) bool {
+		projects[item.ProjectId] = append(projects[item.ProjectId], item)
+		return true
+	})
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	for project, items := range projects {
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].Name < items[j].Name
+		})
+
+		lines := []string{
+			"#!/usr/bin/env bash",
+			fmt.Sprintf("# Compute Engine rightsizing of project %s, generated on %s", project, time.Now().Format(time.RFC3339)),
+			"# Review every command before running this script, instances are stopped while their machine type and disks change.",
+			"# Replaced disks are deleted once their new disk is attached, set KEEP_OLD_DISKS=yes to keep them (and keep paying for them).",
+			"set -euo pipefail",
+			"",
+		}
+		for _, item := range items {
+			commands := item.GcloudCommands()
+			if len(commands) == 0 {
+				continue
+			}
+			lines = append(lines, commands...)
+			lines = append(lines, "")
+		}
+
+		path := filepath.Join(dir, fmt.Sprintf("remediate-%s.sh", project))
+		err = os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0755)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
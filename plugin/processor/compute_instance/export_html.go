@@ -0,0 +1,396 @@
+package compute_instance
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kaytu-io/kaytu/pkg/utils"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+)
+
+type htmlGroup struct {
+	Name        string
+	Resources   int
+	CurrentCost float64
+	Savings     float64
+}
+
+type htmlUsage struct {
+	Name string
+	Avg  string
+	Max  string
+}
+
+type htmlChart struct {
+	Title string
+	Svg   template.HTML
+}
+
+type htmlResource struct {
+	Anchor          string
+	Type            string
+	ProjectId       string
+	Zone            string
+	Id              string
+	Name            string
+	Parent          string
+	CurrentSpec     string
+	RecommendedSpec string
+	CurrentCost     float64
+	RecommendedCost float64
+	Savings         float64
+	SavingsType     string
+	Description     string
+	Usage           []htmlUsage
+	Charts          []htmlChart
+}
+
+type htmlGroupTable struct {
+	Title  string
+	Groups []htmlGroup
+}
+
+type htmlReport struct {
+	GeneratedAt string
+	CurrentCost float64
+	Savings     float64
+	Groups      []htmlGroupTable
+	Resources   []htmlResource
+}
+
+// htmlGroups adds the resource to the group of name, creating the group when needed
+func htmlGroups(groups map[string]*htmlGroup, name string, r htmlResource) {
+	g, ok := groups[name]
+	if !ok {
+		g = &htmlGroup{Name: name}
+		groups[name] = g
+	}
+	g.Resources++
+	g.CurrentCost += r.CurrentCost
+	g.Savings += r.Savings
+}
+
+func sortedHtmlGroups(groups map[string]*htmlGroup) []htmlGroup {
+	var sorted []htmlGroup
+	for _, g := range groups {
+		sorted = append(sorted, *g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Savings != sorted[j].Savings {
+			return sorted[i].Savings > sorted[j].Savings
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+func percentage(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.1f%%", *v)
+}
+
+func megabytes(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.0f MB", *v/(1024*1024))
+}
+
+func number(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.2f", *v)
+}
+
+// htmlResources returns the instance and its disks as report resources
+func (i ComputeInstanceItem) htmlResources() []htmlResource {
+	record := i.Record()
+	current, recommended := record.Specs()
+
+	instance := htmlResource{
+		Anchor:      "instance-" + i.Id,
+		Type:        "Compute Instance",
+		ProjectId:   i.ProjectId,
+		Zone:        i.Region,
+		Id:          i.Id,
+		Name:        i.Name,
+		CurrentSpec: i.MachineType,
+		CurrentCost: record.Cost.Current,
+		Savings:     record.Cost.Savings,
+		SavingsType: record.Cost.SavingsType,
+		Description: record.Description,
+	}
+	if recommended != nil {
+		instance.RecommendedSpec = recommended.MachineType
+		instance.RecommendedCost = *record.Cost.Recommended
+	}
+	if u, ok := record.Usage["cpu"]; ok {
+		instance.Usage = append(instance.Usage, htmlUsage{Name: "CPU", Avg: percentage(u.Avg), Max: percentage(u.Max)})
+	}
+	if u, ok := record.Usage["memory"]; ok {
+		instance.Usage = append(instance.Usage, htmlUsage{Name: "Memory", Avg: megabytes(u.Avg), Max: megabytes(u.Max)})
+	}
+	if u, ok := record.Usage["gpu"]; ok {
+		instance.Usage = append(instance.Usage, htmlUsage{Name: "GPU", Avg: percentage(u.Avg), Max: percentage(u.Max)})
+	}
+	if points := i.Metrics["cpuUtilization"]; len(points) > 0 {
+		instance.Charts = append(instance.Charts, htmlChart{
+			Title: "CPU utilization (%)",
+			Svg:   svgChart(points, 100, 100),
+		})
+	}
+	if points := i.Metrics["memoryUtilization"]; len(points) > 0 {
+		var limit float64
+		if current != nil {
+			limit = float64(current.MemoryMb)
+		}
+		instance.Charts = append(instance.Charts, htmlChart{
+			Title: "Memory used (MB)",
+			Svg:   svgChart(points, 1.0/(1024*1024), limit),
+		})
+	}
+	resources := []htmlResource{instance}
+
+	for _, d := range record.Disks {
+		disk := htmlResource{
+			Anchor:      "disk-" + d.Id,
+			Type:        "Compute Disk",
+			ProjectId:   i.ProjectId,
+			Zone:        i.Region,
+			Id:          d.Id,
+			Name:        d.Name,
+			Parent:      i.Name,
+			CurrentCost: d.Cost.Current,
+			Savings:     d.Cost.Savings,
+			SavingsType: d.Cost.SavingsType,
+			Description: d.Description,
+		}
+		diskCurrent, diskRecommended := d.Specs()
+		if diskCurrent != nil {
+			disk.CurrentSpec = fmt.Sprintf("%s / %d GB", diskCurrent.DiskType, diskCurrent.DiskSize)
+		}
+		if diskRecommended != nil {
+			disk.RecommendedSpec = fmt.Sprintf("%s / %d GB", diskRecommended.DiskType, diskRecommended.DiskSize)
+			disk.RecommendedCost = *d.Cost.Recommended
+		}
+		for _, u := range []struct{ key, name string }{
+			{"read_iops", "Read IOPS"}, {"write_iops", "Write IOPS"},
+			{"read_throughput", "Read Throughput"}, {"write_throughput", "Write Throughput"},
+		} {
+			if usage, ok := d.Usage[u.key]; ok {
+				disk.Usage = append(disk.Usage, htmlUsage{Name: u.name, Avg: number(usage.Avg), Max: number(usage.Max)})
+			}
+		}
+		resources = append(resources, disk)
+	}
+
+	return resources
+}
+
+// svgChart draws the datapoints, multiplied by scale, as an inline SVG line chart with the
+// limit drawn as a dashed line when it is set
+func svgChart(points []*golang2.DataPoint, scale, limit float64) template.HTML {
+	const width, height, padding = 600.0, 160.0, 30.0
+
+	sorted := make([]*golang2.DataPoint, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].GetStartTime().GetValue() < sorted[j].GetStartTime().GetValue()
+	})
+
+	start, end := sorted[0].GetStartTime().GetValue(), sorted[len(sorted)-1].GetStartTime().GetValue()
+	maxValue := limit
+	for _, p := range sorted {
+		maxValue = max(maxValue, p.Value*scale)
+	}
+	if maxValue <= 0 {
+		maxValue = 1
+	}
+
+	x := func(t int64) float64 {
+		if end == start {
+			return padding
+		}
+		return padding + float64(t-start)/float64(end-start)*(width-2*padding)
+	}
+	y := func(v float64) float64 {
+		return height - padding - v/maxValue*(height-2*padding)
+	}
+
+	var line []string
+	for _, p := range sorted {
+		line = append(line, fmt.Sprintf("%.1f,%.1f", x(p.GetStartTime().GetValue()), y(p.Value*scale)))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">`,
+		width, height, width, height))
+	sb.WriteString(fmt.Sprintf(`<line x1="%.0f" y1="%.0f" x2="%.0f" y2="%.0f" class="axis"/>`,
+		padding, height-padding, width-padding, height-padding))
+	sb.WriteString(fmt.Sprintf(`<line x1="%.0f" y1="%.0f" x2="%.0f" y2="%.0f" class="axis"/>`,
+		padding, padding, padding, height-padding))
+	sb.WriteString(fmt.Sprintf(`<text x="2" y="%.0f">%.0f</text>`, padding, maxValue))
+	sb.WriteString(fmt.Sprintf(`<text x="2" y="%.0f">0</text>`, height-padding))
+	sb.WriteString(fmt.Sprintf(`<text x="%.0f" y="%.0f">%s</text>`, padding, height-8,
+		time.Unix(start, 0).UTC().Format("Jan 2 15:04")))
+	sb.WriteString(fmt.Sprintf(`<text x="%.0f" y="%.0f" text-anchor="end">%s</text>`, width-padding, height-8,
+		time.Unix(end, 0).UTC().Format("Jan 2 15:04")))
+	if limit > 0 {
+		sb.WriteString(fmt.Sprintf(`<line x1="%.0f" y1="%.1f" x2="%.0f" y2="%.1f" class="limit"/>`,
+			padding, y(limit), width-padding, y(limit)))
+	}
+	sb.WriteString(fmt.Sprintf(`<polyline points="%s" class="series"/>`, strings.Join(line, " ")))
+	sb.WriteString(`</svg>`)
+
+	return template.HTML(sb.String())
+}
+
+// exportHtml writes the report of the items as a single offline HTML file
+func (m *ComputeInstanceProcessor) exportHtml(path string) error {
+	report := htmlReport{
+		GeneratedAt: time.Now().Format(time.RFC1123),
+	}
+	projects := make(map[string]*htmlGroup)
+	regions := make(map[string]*htmlGroup)
+	families := make(map[string]*htmlGroup)
+
+	m.items.Range(func(_ string, item ComputeInstanceItem) bool {
+		for _, r := range item.htmlResources() {
+			report.Resources = append(report.Resources, r)
+			report.CurrentCost += r.CurrentCost
+			report.Savings += r.Savings
+			htmlGroups(projects, r.ProjectId, r)
+			htmlGroups(regions, zoneToRegion(r.Zone), r)
+			htmlGroups(families, machineFamily(item.MachineType), r)
+		}
+		return true
+	})
+	sort.SliceStable(report.Resources, func(i, j int) bool {
+		return report.Resources[i].Savings > report.Resources[j].Savings
+	})
+	report.Groups = []htmlGroupTable{
+		{Title: "Project", Groups: sortedHtmlGroups(projects)},
+		{Title: "Region", Groups: sortedHtmlGroups(regions)},
+		{Title: "Machine Family", Groups: sortedHtmlGroups(families)},
+	}
+
+	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"price": utils.FormatPriceFloat,
+	}).Parse(htmlReportTemplate)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return tmpl.Execute(f, report)
+}
+
+const htmlReportTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Compute Engine Optimization Report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+th { background: #f0f0f0; }
+table.sortable th { cursor: pointer; }
+td.number { text-align: right; }
+.summary span { display: inline-block; margin-right: 3em; font-size: 1.3em; }
+.saving { color: #1a7f37; }
+.groups { display: flex; gap: 2em; flex-wrap: wrap; }
+section.resource { border-top: 1px solid #ccc; padding-top: 1em; }
+svg text { font-size: 10px; fill: #555; }
+svg .axis { stroke: #999; }
+svg .limit { stroke: #d1242f; stroke-dasharray: 4 4; }
+svg .series { fill: none; stroke: #0969da; stroke-width: 1.5; }
+</style>
+</head>
+<body>
+<h1>Compute Engine Optimization Report</h1>
+<p>Generated on {{.GeneratedAt}}</p>
+
+<div class="summary">
+<span>Current cost: {{price .CurrentCost}}</span>
+<span class="saving">Savings: {{price .Savings}}</span>
+</div>
+
+<h2>Fleet Summary</h2>
+<div class="groups">
+{{range .Groups}}
+<table>
+<tr><th>{{.Title}}</th><th>Resources</th><th>Current Cost</th><th>Savings</th></tr>
+{{range .Groups}}<tr><td>{{.Name}}</td><td class="number">{{.Resources}}</td><td class="number">{{price .CurrentCost}}</td><td class="number">{{price .Savings}}</td></tr>
+{{end}}</table>
+{{end}}
+</div>
+
+<h2>Resources</h2>
+<table class="sortable">
+<thead><tr><th>Type</th><th>Project</th><th>Zone</th><th>Name</th><th>Current Spec</th><th>Recommended Spec</th>
+<th>Current Cost</th><th>Recommended Cost</th><th>Savings</th><th>Savings Type</th></tr></thead>
+<tbody>
+{{range .Resources}}<tr><td>{{.Type}}</td><td>{{.ProjectId}}</td><td>{{.Zone}}</td><td><a href="#{{.Anchor}}">{{.Name}}</a></td>
+<td>{{.CurrentSpec}}</td><td>{{.RecommendedSpec}}</td>
+<td class="number" data-value="{{.CurrentCost}}">{{price .CurrentCost}}</td>
+<td class="number" data-value="{{.RecommendedCost}}">{{if .RecommendedSpec}}{{price .RecommendedCost}}{{end}}</td>
+<td class="number" data-value="{{.Savings}}">{{price .Savings}}</td><td>{{.SavingsType}}</td></tr>
+{{end}}</tbody>
+</table>
+
+<h2>Details</h2>
+{{range .Resources}}
+<section class="resource" id="{{.Anchor}}">
+<h3>{{.Type}} {{.Name}}</h3>
+<p>Project {{.ProjectId}}, zone {{.Zone}}, id {{.Id}}{{if .Parent}}, attached to {{.Parent}}{{end}}</p>
+<table>
+<tr><th></th><th>Current</th><th>Recommended</th></tr>
+<tr><td>Spec</td><td>{{.CurrentSpec}}</td><td>{{.RecommendedSpec}}</td></tr>
+<tr><td>Cost</td><td>{{price .CurrentCost}}</td><td>{{if .RecommendedSpec}}{{price .RecommendedCost}}{{end}}</td></tr>
+</table>
+{{if .Usage}}<table>
+<tr><th>Usage</th><th>Average</th><th>Max</th></tr>
+{{range .Usage}}<tr><td>{{.Name}}</td><td class="number">{{.Avg}}</td><td class="number">{{.Max}}</td></tr>
+{{end}}</table>{{end}}
+{{range .Charts}}<h4>{{.Title}}</h4>
+{{.Svg}}
+{{end}}
+{{if .Description}}<pre>{{.Description}}</pre>{{end}}
+<p><a href="#">Back to top</a></p>
+</section>
+{{end}}
+
+<script>
+document.querySelectorAll("table.sortable th").forEach(function (th, column) {
+  th.addEventListener("click", function () {
+    var tbody = th.closest("table").querySelector("tbody");
+    var ascending = th.dataset.order !== "asc";
+    th.dataset.order = ascending ? "asc" : "desc";
+    var rows = Array.from(tbody.rows);
+    rows.sort(function (a, b) {
+      var x = a.cells[column], y = b.cells[column];
+      var result = x.dataset.value !== undefined
+        ? parseFloat(x.dataset.value) - parseFloat(y.dataset.value)
+        : x.textContent.localeCompare(y.textContent);
+      return ascending ? result : -result;
+    });
+    rows.forEach(function (row) { tbody.appendChild(row); });
+  });
+});
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,243 @@
+package compute_instance
+
+import (
+	"encoding/json"
+	"github.com/kaytu-io/kaytu/preferences"
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+	"github.com/opengovern/plugin-gcp/plugin/processor/shared"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"strconv"
+)
+
+type UsageRecord struct {
+	Avg *float64 `json:"avg"`
+	Min *float64 `json:"min"`
+	Max *float64 `json:"max"`
+	P50 *float64 `json:"p50,omitempty"`
+	P90 *float64 `json:"p90,omitempty"`
+	P95 *float64 `json:"p95,omitempty"`
+	P99 *float64 `json:"p99,omitempty"`
+	// Statistic is the percentile the rightsizing capped the datapoints at, the other values are uncapped
+	Statistic string `json:"statistic,omitempty"`
+}
+
+type CostRecord struct {
+	Current          float64  `json:"current"`
+	Recommended      *float64 `json:"recommended"`
+	Savings          float64  `json:"savings"`
+	ListCurrent      float64  `json:"list_current"`
+	ListRecommended  *float64 `json:"list_recommended"`
+	SavingsType      string   `json:"savings_type,omitempty"`
+	ActualCost30Days *float64 `json:"actual_cost_30_days"`
+	RuntimeHours     *int64   `json:"runtime_hours"`
+}
+
+type ComputeDiskRecord struct {
+	Id          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Disk        compute.Disk           `json:"disk"`
+	Usage       map[string]UsageRecord `json:"usage"`
+	Current     json.RawMessage        `json:"current"`
+	Recommended json.RawMessage        `json:"recommended"`
+	Cost        CostRecord             `json:"cost"`
+	Description string                 `json:"description,omitempty"`
+}
+
+// ComputeInstanceRecord is the structured export of an instance, its disks and their recommendations
+type ComputeInstanceRecord struct {
+	ProjectId   string                       `json:"project_id"`
+	Id          string                       `json:"id"`
+	Name        string                       `json:"name"`
+	Zone        string                       `json:"zone"`
+	MachineType string                       `json:"machine_type"`
+	Platform    string                       `json:"platform"`
+	Preemptible bool                         `json:"preemptible"`
+	OsLicense   string                       `json:"os_license"`
+	Instance    json.RawMessage              `json:"instance"`
+	Usage       map[string]UsageRecord       `json:"usage"`
+	Current     json.RawMessage              `json:"current"`
+	Recommended json.RawMessage              `json:"recommended"`
+	Commitment  *CommitmentCoverage          `json:"commitment"`
+	Cost        CostRecord                   `json:"cost"`
+	Preferences map[string]*string           `json:"preferences"`
+	Description string                       `json:"description,omitempty"`
+	Google      []gcp.InstanceRecommendation `json:"google_recommendations,omitempty"`
+	Agreement   string                       `json:"google_agreement,omitempty"`
+	Disks       []ComputeDiskRecord          `json:"disks"`
+	// Schedule is the start/stop schedule alternative to running the instance 24/7
+	Schedule       *InstanceSchedule `json:"schedule,omitempty"`
+	ScheduleSaving float64           `json:"schedule_saving,omitempty"`
+	// Arm is the alternative Arm machine type of the instance
+	Arm *ArmAssessment `json:"arm_assessment,omitempty"`
+}
+
+// protoRecord renders a proto message with protojson, the way every proto field of the records is rendered,
+// and null for a nil message
+func protoRecord(m proto.Message) json.RawMessage {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return json.RawMessage("null")
+	}
+	content, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(m)
+	if err != nil {
+		return json.RawMessage("null")
+	}
+	return content
+}
+
+// unmarshalProtoRecord reads a proto field of a record back, false when it is null or invalid
+func unmarshalProtoRecord(content json.RawMessage, m proto.Message) bool {
+	if len(content) == 0 || string(content) == "null" {
+		return false
+	}
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(content, m) == nil
+}
+
+// Specs returns the current and recommended instance of the record, nil when they are not set
+func (r ComputeInstanceRecord) Specs() (*golang2.RightsizingGcpComputeInstance, *golang2.RightsizingGcpComputeInstance) {
+	current, recommended := &golang2.RightsizingGcpComputeInstance{}, &golang2.RightsizingGcpComputeInstance{}
+	if !unmarshalProtoRecord(r.Current, current) {
+		current = nil
+	}
+	if !unmarshalProtoRecord(r.Recommended, recommended) {
+		recommended = nil
+	}
+	return current, recommended
+}
+
+// Specs returns the current and recommended disk of the record, nil when they are not set
+func (r ComputeDiskRecord) Specs() (*golang2.RightsizingGcpComputeDisk, *golang2.RightsizingGcpComputeDisk) {
+	current, recommended := &golang2.RightsizingGcpComputeDisk{}, &golang2.RightsizingGcpComputeDisk{}
+	if !unmarshalProtoRecord(r.Current, current) {
+		current = nil
+	}
+	if !unmarshalProtoRecord(r.Recommended, recommended) {
+		recommended = nil
+	}
+	return current, recommended
+}
+
+func usageRecord(u *golang2.Usage) UsageRecord {
+	return UsageRecord{
+		Avg:       shared.PWrapperDouble(u.GetAvg()),
+		Min:       shared.PWrapperDouble(u.GetMin()),
+		Max:       shared.PWrapperDouble(u.GetMax()),
+		P50:       shared.PWrapperDouble(u.GetP50()),
+		P90:       shared.PWrapperDouble(u.GetP90()),
+		P95:       shared.PWrapperDouble(u.GetP95()),
+		P99:       shared.PWrapperDouble(u.GetP99()),
+		Statistic: u.GetStatistic(),
+	}
+}
+
+func billedCostRecord(record *CostRecord, cost *gcp.ResourceCost) {
+	if cost == nil {
+		return
+	}
+	record.ActualCost30Days = &cost.Cost
+	record.RuntimeHours = &cost.RuntimeHours
+}
+
+func (i ComputeInstanceItem) Record() ComputeInstanceRecord {
+	record := ComputeInstanceRecord{
+		ProjectId:   i.ProjectId,
+		Id:          i.Id,
+		Name:        i.Name,
+		Zone:        i.Region,
+		MachineType: i.MachineType,
+		Platform:    i.Platform,
+		Preemptible: i.Preemptible,
+		OsLicense:   i.InstanceOsLicense,
+		Usage:       make(map[string]UsageRecord),
+		Commitment:  i.Commitment,
+		Preferences: preferences.Export(i.Preferences),
+		Google:      i.GoogleRecommendations,
+		Agreement:   i.GoogleAgreement(),
+	}
+	record.Arm = i.Arm
+	if i.Schedule != nil {
+		record.Schedule = i.Schedule
+		record.ScheduleSaving = i.ScheduleSaving()
+	}
+	if i.Instance != nil {
+		record.Instance = protoRecord(i.Instance)
+	}
+	billedCostRecord(&record.Cost, i.BilledCost)
+
+	var rightsizing *golang2.GcpComputeInstanceRightsizingRecommendation
+	if i.Wastage != nil {
+		rightsizing = i.Wastage.Rightsizing
+	}
+	if rightsizing != nil {
+		record.Usage["cpu"] = usageRecord(rightsizing.Cpu)
+		record.Usage["memory"] = usageRecord(rightsizing.Memory)
+		if len(i.Accelerators) > 0 {
+			record.Usage["gpu"] = usageRecord(rightsizing.Gpu)
+			record.Usage["gpu_memory"] = usageRecord(rightsizing.GpuMemory)
+		}
+		record.Current = protoRecord(rightsizing.Current)
+		record.Recommended = protoRecord(rightsizing.Recommended)
+		record.Description = rightsizing.Description
+
+		currentCost, recommendedCost := i.InstanceCosts()
+		record.Cost.Current = currentCost.Cost()
+		record.Cost.ListCurrent = rightsizing.Current.GetCost()
+		if rightsizing.Recommended != nil {
+			recommended := recommendedCost.Cost()
+			listRecommended := rightsizing.Recommended.Cost
+			record.Cost.Recommended = &recommended
+			record.Cost.ListRecommended = &listRecommended
+			record.Cost.Savings = record.Cost.Current - recommended
+			record.Cost.SavingsType = i.SavingsType()
+		}
+	}
+
+	for _, d := range i.Disks {
+		key := strconv.FormatUint(d.Id, 10)
+		disk := ComputeDiskRecord{
+			Id:    key,
+			Name:  d.Name,
+			Disk:  d,
+			Usage: make(map[string]UsageRecord),
+		}
+		billedCostRecord(&disk.Cost, i.DisksBilledCost[key])
+		if i.Wastage != nil {
+			if rs, ok := i.Wastage.VolumesRightsizing[key]; ok && rs != nil {
+				disk.Usage["read_iops"] = usageRecord(rs.ReadIops)
+				disk.Usage["write_iops"] = usageRecord(rs.WriteIops)
+				disk.Usage["read_throughput"] = usageRecord(rs.ReadThroughput)
+				disk.Usage["write_throughput"] = usageRecord(rs.WriteThroughput)
+				disk.Current = protoRecord(rs.Current)
+				disk.Recommended = protoRecord(rs.Recommended)
+				disk.Description = rs.Description
+				disk.Cost.Current = rs.Current.GetCost()
+				disk.Cost.ListCurrent = rs.Current.GetCost()
+				if rs.Recommended != nil {
+					recommended := rs.Recommended.Cost
+					disk.Cost.Recommended = &recommended
+					disk.Cost.ListRecommended = &recommended
+					disk.Cost.Savings = disk.Cost.Current - recommended
+					disk.Cost.SavingsType = SavingsTypeRealCash
+				}
+			}
+		}
+		record.Disks = append(record.Disks, disk)
+	}
+
+	return record
+}
+
+func (m *ComputeInstanceProcessor) exportJson(path string) error {
+	var records []ComputeInstanceRecord
+	m.items.Range(func(_ string, item ComputeInstanceItem) bool {
+		records = append(records, item.Record())
+		return true
+	})
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Id < records[j].Id
+	})
+	return shared.WriteJson(path, records)
+}
@@ -0,0 +1,53 @@
+package compute_instance
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestRecordProtoFields(t *testing.T) {
+	item := ComputeInstanceItem{
+		Id:          "1",
+		MachineType: "n2-standard-4",
+		Disks:       []compute.Disk{{Id: 2, Name: "disk-1"}},
+		Wastage: &golang2.GCPComputeOptimizationResponse{
+			Rightsizing: &golang2.GcpComputeInstanceRightsizingRecommendation{
+				Current:     &golang2.RightsizingGcpComputeInstance{MachineType: "n2-standard-4", Cpu: 4, MemoryMb: 16384, Cost: 100},
+				Recommended: &golang2.RightsizingGcpComputeInstance{MachineType: "n2-standard-2", Cpu: 2, MemoryMb: 8192, Cost: 50},
+				Cpu:         &golang2.Usage{Avg: wrapperspb.Double(10), Max: wrapperspb.Double(20)},
+			},
+			VolumesRightsizing: map[string]*golang2.GcpComputeDiskRecommendation{
+				"2": {Current: &golang2.RightsizingGcpComputeDisk{DiskType: "pd-ssd", DiskSize: 100}},
+			},
+		},
+	}
+
+	content, err := json.Marshal(item.Record())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), `"value"`) {
+		t.Errorf("record has wrapper objects: %s", content)
+	}
+	if !strings.Contains(string(content), `"machine_type":"n2-standard-2"`) || !strings.Contains(string(content), `"memory_mb":"8192"`) {
+		t.Errorf("record proto fields are not rendered with protojson: %s", content)
+	}
+
+	var record ComputeInstanceRecord
+	if err := json.Unmarshal(content, &record); err != nil {
+		t.Fatal(err)
+	}
+	current, recommended := record.Specs()
+	if current.GetMachineType() != "n2-standard-4" || recommended.GetMemoryMb() != 8192 {
+		t.Errorf("specs = %v, %v", current, recommended)
+	}
+	diskCurrent, diskRecommended := record.Disks[0].Specs()
+	if diskCurrent.GetDiskSize() != 100 || diskRecommended != nil {
+		t.Errorf("disk specs = %v, %v", diskCurrent, diskRecommended)
+	}
+}
@@ -0,0 +1,296 @@
+package compute_instance
+
+import (
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/utils"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	util "github.com/opengovern/plugin-gcp/utils"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// TerraformChanges returns the changes to the google_compute_instance and google_compute_disk resources
+// of the instance that apply its recommendations. The changes forcing a resource to be recreated are left
+// out as guidance comments unless allowRecreate is set.
+func (i ComputeInstanceItem) TerraformChanges(allowRecreate bool) []terraformChange {
+	if i.Wastage == nil || i.Wastage.Rightsizing == nil {
+		return nil
+	}
+	var changes []terraformChange
+
+	current, recommended := i.Wastage.Rightsizing.Current, i.Wastage.Rightsizing.Recommended
+	if current != nil && recommended != nil &&
+		(current.MachineType != recommended.MachineType || current.Preemptible != recommended.Preemptible) {
+		currentCost, recommendedCost := i.InstanceCosts()
+		change := terraformChange{
+			ResourceType: "google_compute_instance",
+			Name:         i.Name,
+			Zone:         i.Region,
+			Project:      i.ProjectId,
+			Comments: []string{
+				fmt.Sprintf("%s (%s/%s): %s -> %s, saving %s per month (%s)", i.Name, i.ProjectId, i.Region,
+					current.MachineType, recommended.MachineType,
+					utils.FormatPriceFloat(currentCost.Cost()-recommendedCost.Cost()), i.SavingsType()),
+			},
+		}
+		if current.MachineType != recommended.MachineType {
+			change.Attributes = append(change.Attributes,
+				terraformAttribute{Path: []string{"machine_type"}, Value: cty.StringVal(recommended.MachineType),
+					Comment: fmt.Sprintf("was %s", current.MachineType)},
+				terraformAttribute{Path: []string{"allow_stopping_for_update"}, Value: cty.True,
+					Comment: "the instance is stopped to change its machine type"},
+			)
+		}
+		if current.Preemptible != recommended.Preemptible {
+			onHostMaintenance, automaticRestart := "MIGRATE", true
+			if recommended.Preemptible {
+				onHostMaintenance, automaticRestart = "TERMINATE", false
+			}
+			if allowRecreate {
+				change.Attributes = append(change.Attributes,
+					terraformAttribute{Path: []string{"scheduling", "preemptible"}, Value: cty.BoolVal(recommended.Preemptible),
+						Comment: "forces the instance to be recreated"},
+					terraformAttribute{Path: []string{"scheduling", "automatic_restart"}, Value: cty.BoolVal(automaticRestart)},
+					terraformAttribute{Path: []string{"scheduling", "on_host_maintenance"}, Value: cty.StringVal(onHostMaintenance)},
+				)
+			} else {
+				change.Guidance = append(change.Guidance, fmt.Sprintf(
+					"recommended: scheduling preemptible = %t, automatic_restart = %t, on_host_maintenance = %q, "+
+						"not set as it forces the instance to be recreated", recommended.Preemptible, automaticRestart, onHostMaintenance))
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	bootDisk := ""
+	if i.Instance != nil {
+		for _, d := range i.Instance.Disks {
+			if d.GetBoot() {
+				bootDisk = util.TrimmedString(d.GetSource(), "/")
+			}
+		}
+	}
+
+	for _, d := range i.Disks {
+		disk, ok := i.Wastage.VolumesRightsizing[strconv.FormatUint(d.Id, 10)]
+		if !ok || disk == nil || disk.Current == nil || disk.Recommended == nil {
+			continue
+		}
+		if disk.Current.DiskType == disk.Recommended.DiskType && disk.Current.DiskSize == disk.Recommended.DiskSize {
+			continue
+		}
+		change := terraformChange{
+			ResourceType: "google_compute_disk",
+			Name:         d.Name,
+			Zone:         util.TrimmedString(d.Zone, "/"),
+			Project:      i.ProjectId,
+			Comments: []string{
+				fmt.Sprintf("%s (%s/%s) of %s: %s / %d GB -> %s / %d GB, saving %s per month", d.Name, i.ProjectId,
+					util.TrimmedString(d.Zone, "/"), i.Name, disk.Current.DiskType, disk.Current.DiskSize,
+					disk.Recommended.DiskType, disk.Recommended.DiskSize,
+					utils.FormatPriceFloat(disk.Current.Cost-disk.Recommended.Cost)),
+			},
+		}
+		if disk.Current.DiskType != disk.Recommended.DiskType {
+			if allowRecreate {
+				change.Attributes = append(change.Attributes, terraformAttribute{
+					Path: []string{"type"}, Value: cty.StringVal(disk.Recommended.DiskType),
+					Comment:         fmt.Sprintf("was %s, forces the disk to be recreated", disk.Current.DiskType),
+					BootDiskComment: fmt.Sprintf("was %s, forces the instance to be recreated", disk.Current.DiskType),
+				})
+			} else {
+				change.Guidance = append(change.Guidance, fmt.Sprintf(
+					"recommended: type = %q (was %s), not set as it forces the disk to be recreated",
+					disk.Recommended.DiskType, disk.Current.DiskType))
+			}
+		}
+		switch {
+		case disk.Recommended.DiskSize > disk.Current.DiskSize:
+			change.Attributes = append(change.Attributes, terraformAttribute{
+				Path: []string{"size"}, Value: cty.NumberIntVal(disk.Recommended.DiskSize),
+				Comment: fmt.Sprintf("was %d", disk.Current.DiskSize),
+			})
+		case disk.Recommended.DiskSize < disk.Current.DiskSize && allowRecreate:
+			change.Attributes = append(change.Attributes, terraformAttribute{
+				Path: []string{"size"}, Value: cty.NumberIntVal(disk.Recommended.DiskSize),
+				Comment:         fmt.Sprintf("was %d, disks can not shrink so this forces the disk to be recreated", disk.Current.DiskSize),
+				BootDiskComment: fmt.Sprintf("was %d, disks can not shrink so this forces the instance to be recreated", disk.Current.DiskSize),
+			})
+		case disk.Recommended.DiskSize < disk.Current.DiskSize:
+			change.Guidance = append(change.Guidance, fmt.Sprintf(
+				"recommended: size = %d (was %d), not set as disks can not shrink and it forces the disk to be recreated",
+				disk.Recommended.DiskSize, disk.Current.DiskSize))
+		}
+		if d.Name == bootDisk {
+			change.BootDiskOf = i.Name
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+func (m *ComputeInstanceProcessor) terraformChanges() []terraformChange {
+	var changes []terraformChange
+	m.items.Range(func(_ string, item ComputeInstanceItem) bool {
+		changes = append(changes, item.TerraformChanges(m.exportOptions.TerraformAllowRecreate)...)
+		return true
+	})
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].ResourceType != changes[j].ResourceType {
+			return changes[i].ResourceType > changes[j].ResourceType
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// exportTerraform writes the terraform changes as HCL snippets, or as a patch against the
+// terraform files of dir when it is provided
+func (m *ComputeInstanceProcessor) exportTerraform(path, dir string) error {
+	changes := m.terraformChanges()
+
+	if dir == "" {
+		var snippets []string
+		for _, c := range changes {
+			snippets = append(snippets, c.snippet())
+		}
+		return os.WriteFile(path, []byte(strings.Join(snippets, "\n")), 0644)
+	}
+
+	patch, unmatched, err := terraformPatch(dir, changes)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Apply from %s with: git apply %s\n", dir, path))
+	if len(unmatched) > 0 {
+		sb.WriteString("#\n# Resources not found in the terraform files:\n#\n")
+		for _, c := range unmatched {
+			for _, line := range strings.Split(strings.TrimSuffix(c.snippet(), "\n"), "\n") {
+				sb.WriteString("# " + line + "\n")
+			}
+			sb.WriteString("#\n")
+		}
+	}
+	sb.WriteString(patch)
+
+	return os.WriteFile(path, []byte(sb.String()), 0644)
+}
+
+type terraformFile struct {
+	Path     string
+	Original string
+	File     *hclwrite.File
+}
+
+// terraformPatch matches the changes to the resources in the terraform files of dir and returns the
+// unified diff of the files along with the changes of resources that are not found
+func terraformPatch(dir string, changes []terraformChange) (string, []terraformChange, error) {
+	var files []*terraformFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".terraform" {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || filepath.Ext(path) != ".tf" {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		f, diags := hclwrite.ParseConfig(content, rel, hcl.InitialPos)
+		if diags.HasErrors() {
+			return fmt.Errorf("failed to parse %s: %s", path, diags.Error())
+		}
+		files = append(files, &terraformFile{
+			Path:     filepath.ToSlash(rel),
+			Original: string(content),
+			File:     f,
+		})
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	apply := func(c terraformChange) bool {
+		for _, f := range files {
+			for _, block := range resourceBlocks(f.File) {
+				if c.matches(block) {
+					c.apply(block)
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	var unmatched []terraformChange
+	for _, c := range changes {
+		if apply(c) {
+			continue
+		}
+		if c.BootDiskOf != "" && applyBootDisk(files, c, changes) {
+			continue
+		}
+		unmatched = append(unmatched, c)
+	}
+
+	var sb strings.Builder
+	for _, f := range files {
+		patched := string(f.File.Bytes())
+		if patched == f.Original {
+			continue
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(f.Original),
+			B:        difflib.SplitLines(patched),
+			FromFile: "a/" + f.Path,
+			ToFile:   "b/" + f.Path,
+			Context:  3,
+		})
+		if err != nil {
+			return "", nil, err
+		}
+		sb.WriteString(diff)
+	}
+	return sb.String(), unmatched, nil
+}
+
+// applyBootDisk applies the disk change to the boot_disk block of its instance's resource
+func applyBootDisk(files []*terraformFile, disk terraformChange, changes []terraformChange) bool {
+	instance := terraformChange{ResourceType: "google_compute_instance", Name: disk.BootDiskOf, Zone: disk.Zone, Project: disk.Project}
+	for _, c := range changes {
+		if c.ResourceType == instance.ResourceType && c.Name == instance.Name {
+			instance = c
+		}
+	}
+	change := disk.bootDiskChange(instance)
+
+	for _, f := range files {
+		for _, block := range resourceBlocks(f.File) {
+			if change.matches(block) && block.Body().FirstMatchingBlock("boot_disk", nil) != nil {
+				change.apply(block)
+				return true
+			}
+		}
+	}
+	return false
+}
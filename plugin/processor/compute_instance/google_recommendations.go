@@ -0,0 +1,91 @@
+package compute_instance
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+)
+
+// GoogleRecommendationString summarizes the Recommender API recommendations of the instance
+func (i ComputeInstanceItem) GoogleRecommendationString() string {
+	if len(i.GoogleRecommendations) == 0 {
+		return "None"
+	}
+	var recommendations []string
+	for _, r := range i.GoogleRecommendations {
+		action := r.MachineType
+		switch {
+		case r.Idle():
+			action = "stop idle instance"
+		case action == "":
+			action = strings.ToLower(strings.ReplaceAll(r.Subtype, "_", " "))
+		}
+		if r.MonthlySaving > 0 {
+			action += fmt.Sprintf(" (saves %.2f %s per month)", r.MonthlySaving, r.CurrencyCode)
+		}
+		recommendations = append(recommendations, action)
+	}
+	return strings.Join(recommendations, ", ")
+}
+
+// GoogleRecommendedMachineType is the machine type recommended by the Recommender API, if any
+func (i ComputeInstanceItem) GoogleRecommendedMachineType() string {
+	for _, r := range i.GoogleRecommendations {
+		if r.MachineType != "" {
+			return r.MachineType
+		}
+	}
+	return ""
+}
+
+// GoogleAgreement tells whether our recommendation agrees with the Recommender API, it is empty
+// when the Recommender API recommendations were not fetched
+func (i ComputeInstanceItem) GoogleAgreement() string {
+	if i.GoogleRecommendations == nil || i.Wastage == nil || i.Wastage.Rightsizing == nil || i.Wastage.Rightsizing.Current == nil {
+		return ""
+	}
+	current := i.Wastage.Rightsizing.Current.MachineType
+	recommended := current
+	if i.Wastage.Rightsizing.Recommended != nil {
+		recommended = i.Wastage.Rightsizing.Recommended.MachineType
+	}
+
+	for _, r := range i.GoogleRecommendations {
+		if r.Idle() {
+			return "Disagree: Google reports the instance as idle"
+		}
+	}
+	if machineType := i.GoogleRecommendedMachineType(); machineType != "" {
+		if machineType != recommended {
+			return fmt.Sprintf("Disagree: Google recommends %s", machineType)
+		}
+		return "Agree"
+	}
+	if recommended != current {
+		return "Disagree: Google has no machine type recommendation"
+	}
+	return "Agree"
+}
+
+// googleRecommendations returns the fetched Recommender API recommendations of the instance in zone, an empty
+// (non nil) slice when there are none
+func (m *ComputeInstanceProcessor) googleRecommendations(zone, name string) []gcp.InstanceRecommendation {
+	if m.recommendations == nil {
+		return nil
+	}
+	recommendations := m.recommendations[zone+"/"+name]
+	if recommendations == nil {
+		recommendations = []gcp.InstanceRecommendation{}
+	}
+	return recommendations
+}
+
+// googleRecommendationCsv is the CSV column of the Recommender API recommendations
+func (i ComputeInstanceItem) googleRecommendationCsv() string {
+	agreement := i.GoogleAgreement()
+	if agreement == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s [%s]", i.GoogleRecommendationString(), agreement)
+}
@@ -0,0 +1,168 @@
+package compute_instance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+	"github.com/opengovern/plugin-gcp/plugin/processor/shared"
+	"io/fs"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ApplyOptions enables applying the recommendations of the confirmed instances, the changes are
+// only planned in a dry run and a rollback record of every apply is appended to RollbackFile
+type ApplyOptions struct {
+	Enabled      bool
+	DryRun       bool
+	Instances    map[string]bool
+	RollbackFile string
+}
+
+func NewApplyOptions(enabled, dryRun, instances, rollbackFile string) ApplyOptions {
+	options := ApplyOptions{
+		Enabled:      enabled == "true",
+		DryRun:       dryRun != "false",
+		Instances:    make(map[string]bool),
+		RollbackFile: rollbackFile,
+	}
+	for _, name := range strings.Split(instances, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			options.Instances[name] = true
+		}
+	}
+	if options.RollbackFile == "" {
+		options.RollbackFile = "gcp-rightsizing-rollback.json"
+	}
+	return options
+}
+
+type ApplyComputeInstanceJob struct {
+	processor *ComputeInstanceProcessor
+	itemId    string
+}
+
+func NewApplyComputeInstanceJob(processor *ComputeInstanceProcessor, itemId string) *ApplyComputeInstanceJob {
+	return &ApplyComputeInstanceJob{
+		processor: processor,
+		itemId:    itemId,
+	}
+}
+
+func (job *ApplyComputeInstanceJob) Properties() sdk.JobProperties {
+	return sdk.JobProperties{
+		ID:          fmt.Sprintf("apply_compute_instance_%s", job.itemId),
+		Description: fmt.Sprintf("Applying recommendation of %s", job.itemId),
+		MaxRetry:    0,
+	}
+}
+
+func (job *ApplyComputeInstanceJob) Run(ctx context.Context) error {
+	item, ok := job.processor.items.Get(job.itemId)
+	if !ok {
+		return fmt.Errorf("item not found %s", job.itemId)
+	}
+
+	plan, ok := item.RightsizingPlan()
+	if !ok {
+		item.ApplyStatus = "nothing to apply"
+		job.processor.items.Set(item.Id, item)
+		job.processor.publishOptimizationItem(item.ToOptimizationItem())
+		return nil
+	}
+
+	dryRun := job.processor.applyOptions.DryRun
+	record, applyErr := job.processor.provider.ApplyRightsizing(ctx, plan, dryRun)
+
+	switch {
+	case applyErr != nil && record.RolledBack:
+		item.ApplyStatus = fmt.Sprintf("failed and rolled back: %s", applyErr.Error())
+	case applyErr != nil && record.RollbackError != "":
+		item.ApplyStatus = fmt.Sprintf("failed: %s, rollback failed: %s", applyErr.Error(), record.RollbackError)
+	case applyErr != nil:
+		item.ApplyStatus = fmt.Sprintf("failed: %s", applyErr.Error())
+	case dryRun:
+		item.ApplyStatus = fmt.Sprintf("dry run: %s", strings.Join(record.Steps, ", "))
+	default:
+		item.ApplyStatus = "applied"
+	}
+	job.processor.items.Set(item.Id, item)
+	job.processor.publishOptimizationItem(item.ToOptimizationItem())
+
+	err := job.processor.saveRollbackRecord(record)
+	if err != nil {
+		return err
+	}
+	return applyErr
+}
+
+// RightsizingPlan returns the machine type, disk type and provisioned IOPS and throughput changes of the
+// recommendations, disks are never shrunk since a disk restored from a snapshot can not be smaller than its source
+func (i ComputeInstanceItem) RightsizingPlan() (gcp.RightsizingPlan, bool) {
+	plan := gcp.RightsizingPlan{
+		Zone:     i.Region,
+		Instance: i.Name,
+	}
+	if i.Wastage == nil || i.Wastage.Rightsizing == nil {
+		return plan, false
+	}
+
+	current, recommended := i.Wastage.Rightsizing.Current, i.Wastage.Rightsizing.Recommended
+	if current != nil && recommended != nil && current.MachineType != recommended.MachineType {
+		plan.MachineType = recommended.MachineType
+	}
+
+	for _, d := range i.Disks {
+		disk, ok := i.Wastage.VolumesRightsizing[strconv.FormatUint(d.Id, 10)]
+		if !ok || disk == nil || disk.Current == nil || disk.Recommended == nil {
+			continue
+		}
+		iops, throughput := disk.Recommended.ProvisionedIops, disk.Recommended.ProvisionedThroughput
+		if disk.Current.DiskType == disk.Recommended.DiskType {
+			if provisionedChanged(d, disk.Recommended) {
+				plan.Provisioning = append(plan.Provisioning, gcp.DiskProvisioningChange{
+					Name:                  d.Name,
+					ProvisionedIops:       iops,
+					ProvisionedThroughput: throughput,
+				})
+			}
+			continue
+		}
+		plan.Disks = append(plan.Disks, gcp.DiskTypeChange{
+			Name:                  d.Name,
+			Type:                  disk.Recommended.DiskType,
+			SizeGb:                max(disk.Recommended.DiskSize, disk.Current.DiskSize),
+			ProvisionedIops:       iops,
+			ProvisionedThroughput: throughput,
+		})
+	}
+
+	return plan, plan.MachineType != "" || len(plan.Disks) > 0 || len(plan.Provisioning) > 0
+}
+
+// saveRollbackRecord appends the record to the local rollback file
+func (m *ComputeInstanceProcessor) saveRollbackRecord(record *gcp.RollbackRecord) error {
+	m.rollbackLock.Lock()
+	defer m.rollbackLock.Unlock()
+
+	var records []*gcp.RollbackRecord
+	content, err := os.ReadFile(m.applyOptions.RollbackFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if len(content) > 0 {
+		err = json.Unmarshal(content, &records)
+		if err != nil {
+			return fmt.Errorf("invalid rollback file %s: %s", m.applyOptions.RollbackFile, err.Error())
+		}
+	}
+
+	records = append(records, record)
+	log.Printf("rollback record of %s saved to %s", record.Instance, m.applyOptions.RollbackFile)
+	return shared.WriteJson(m.applyOptions.RollbackFile, records)
+}
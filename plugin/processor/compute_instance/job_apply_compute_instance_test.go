@@ -0,0 +1,55 @@
+package compute_instance
+
+import (
+	"testing"
+
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestRightsizingPlan(t *testing.T) {
+	item := ComputeInstanceItem{
+		Name:   "vm-1",
+		Region: "us-central1-a",
+		Disks:  []compute.Disk{{Id: 1, Name: "boot"}, {Id: 2, Name: "data", ProvisionedIops: 10000, ProvisionedThroughput: 400}, {Id: 3, Name: "logs"}},
+		Wastage: &golang2.GCPComputeOptimizationResponse{
+			Rightsizing: &golang2.GcpComputeInstanceRightsizingRecommendation{
+				Current:     &golang2.RightsizingGcpComputeInstance{MachineType: "n2-standard-8"},
+				Recommended: &golang2.RightsizingGcpComputeInstance{MachineType: "n2-standard-8"},
+			},
+			VolumesRightsizing: map[string]*golang2.GcpComputeDiskRecommendation{
+				"1": {
+					Current:     &golang2.RightsizingGcpComputeDisk{DiskType: "pd-ssd", DiskSize: 100},
+					Recommended: &golang2.RightsizingGcpComputeDisk{DiskType: "pd-balanced", DiskSize: 50},
+				},
+				"2": {
+					Current: &golang2.RightsizingGcpComputeDisk{DiskType: "hyperdisk-balanced", DiskSize: 500, ReadIopsLimit: 10000, ReadThroughputLimit: 400},
+					// the limits are capped by the instance, the provisioned values are applied
+					Recommended: &golang2.RightsizingGcpComputeDisk{DiskType: "hyperdisk-balanced", DiskSize: 500, ReadIopsLimit: 4000, ReadThroughputLimit: 200,
+						ProvisionedIops: 5000, ProvisionedThroughput: 200},
+				},
+				"3": {
+					Current:     &golang2.RightsizingGcpComputeDisk{DiskType: "pd-balanced", DiskSize: 100, ReadIopsLimit: 3000},
+					Recommended: &golang2.RightsizingGcpComputeDisk{DiskType: "pd-balanced", DiskSize: 100, ReadIopsLimit: 6000},
+				},
+			},
+		},
+	}
+
+	plan, ok := item.RightsizingPlan()
+	if !ok {
+		t.Fatal("expected a plan")
+	}
+	if plan.MachineType != "" {
+		t.Errorf("machine type %s, want none", plan.MachineType)
+	}
+	if len(plan.Disks) != 1 || plan.Disks[0] != (gcp.DiskTypeChange{Name: "boot", Type: "pd-balanced", SizeGb: 100}) {
+		t.Errorf("unexpected disk changes %+v", plan.Disks)
+	}
+	// the IOPS of pd-balanced are not provisioned, they can not be changed in place
+	if len(plan.Provisioning) != 1 ||
+		plan.Provisioning[0] != (gcp.DiskProvisioningChange{Name: "data", ProvisionedIops: 5000, ProvisionedThroughput: 200}) {
+		t.Errorf("unexpected provisioning changes %+v", plan.Provisioning)
+	}
+}
@@ -1,8 +1,12 @@
 package compute_instance
 
 import (
+	"cloud.google.com/go/compute/apiv1/computepb"
 	"context"
+	"fmt"
 	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
 	"google.golang.org/api/compute/v1"
 	"log"
 	"strconv"
@@ -32,6 +36,32 @@ func (job *ListComputeInstancesJob) Properties() sdk.JobProperties {
 func (job *ListComputeInstancesJob) Run(ctx context.Context) error {
 	log.Println("Running list compute instance job")
 
+	// the disks are listed once while the rest of the inventory is read, and joined to the instances
+	// by their self-link
+	type diskList struct {
+		disks map[string]*compute.Disk
+		err   error
+	}
+	disksCh := make(chan diskList, 1)
+	go func() {
+		disks, err := job.processor.provider.GetAllDisks(ctx)
+		disksCh <- diskList{disks: disks, err: err}
+	}()
+
+	commitments, err := job.processor.provider.GetAllCommitments(ctx)
+	if err != nil {
+		// costs fall back to list price when commitments can not be read
+		log.Printf("failed to list commitments: %s", err.Error())
+	}
+	job.processor.commitments = newCommitmentAllocator(ctx, commitments, job.processor.prices)
+
+	if job.processor.billingExport != nil {
+		job.processor.billingCosts, err = job.processor.billingExport.GetResourceCosts(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	instances, err := job.processor.provider.GetAllInstances(ctx)
 	if err != nil {
 		return err
@@ -39,28 +69,68 @@ func (job *ListComputeInstancesJob) Run(ctx context.Context) error {
 
 	log.Printf("# of instances: %d", len(instances))
 
-	var instanceOsLicense string
+	if job.processor.recommender != nil {
+		zones := make(map[string]bool)
+		for _, instance := range instances {
+			zones[util.TrimmedString(instance.GetZone(), "/")] = true
+		}
+		var zoneList []string
+		for zone := range zones {
+			zoneList = append(zoneList, zone)
+		}
+		job.processor.recommendations, err = job.processor.recommender.GetInstanceRecommendations(ctx, zoneList)
+		if err != nil {
+			// the comparison is left out when the Recommender API can not be read
+			log.Printf("failed to list google recommendations: %s", err.Error())
+		}
+	}
+
+	job.processor.commitments.AllocateAll(job.commitmentDemands(ctx, instances))
+
+	listedDisks := <-disksCh
+	if listedDisks.err != nil {
+		return listedDisks.err
+	}
+	log.Printf("# of disks: %d", len(listedDisks.disks))
 
 	for _, instance := range instances {
 		var disks []compute.Disk
+		var instanceOsLicense string
 		for _, attachedDisk := range instance.Disks {
 			if *attachedDisk.Boot {
 				image := attachedDisk.Licenses[0]
 				instanceOsLicense = mapImageToOS(image)
 			}
-			diskURLParts := strings.Split(*attachedDisk.Source, "/")
-			diskName := diskURLParts[len(diskURLParts)-1]
-
-			zoneURLParts := strings.Split(*instance.Zone, "/")
-			instanceZone := zoneURLParts[len(zoneURLParts)-1]
-
-			diskDetails, err := job.processor.provider.GetDiskDetails(ctx, instanceZone, diskName)
-			if err != nil {
-				return err
+			diskDetails, ok := listedDisks.disks[attachedDisk.GetSource()]
+			if !ok {
+				// the disk was created after the disks were listed
+				diskURLParts := strings.Split(*attachedDisk.Source, "/")
+				diskName := diskURLParts[len(diskURLParts)-1]
+
+				zoneURLParts := strings.Split(*instance.Zone, "/")
+				instanceZone := zoneURLParts[len(zoneURLParts)-1]
+
+				diskDetails, err = job.processor.provider.GetDiskDetails(ctx, instanceZone, diskName)
+				if err != nil {
+					return err
+				}
 			}
 			disks = append(disks, *diskDetails)
 		}
 
+		var accelerators []*golang2.GcpComputeAccelerator
+		for _, accelerator := range instance.GuestAccelerators {
+			accelerators = append(accelerators, &golang2.GcpComputeAccelerator{
+				AcceleratorType: util.TrimmedString(accelerator.GetAcceleratorType(), "/"),
+				Count:           accelerator.GetAcceleratorCount(),
+			})
+		}
+
+		disksBilledCost := make(map[string]*gcp.ResourceCost)
+		for _, d := range disks {
+			disksBilledCost[strconv.FormatUint(d.Id, 10)] = job.processor.billedCost(strconv.FormatUint(d.Id, 10), "disks", d.Name)
+		}
+
 		oi := ComputeInstanceItem{
 			ProjectId:           job.processor.provider.ProjectID,
 			Name:                *instance.Name,
@@ -70,6 +140,7 @@ func (job *ListComputeInstancesJob) Run(ctx context.Context) error {
 			Platform:            instance.GetCpuPlatform(),
 			Preemptible:         *instance.Scheduling.Preemptible,
 			InstanceOsLicense:   instanceOsLicense,
+			Accelerators:        accelerators,
 			OptimizationLoading: true,
 			Preferences:         job.processor.defaultPreferences,
 			Skipped:             false,
@@ -77,10 +148,15 @@ func (job *ListComputeInstancesJob) Run(ctx context.Context) error {
 			SkipReason:          "NA",
 			Instance:            instance,
 			Disks:               disks,
+			BilledCost:          job.processor.billedCost(strconv.FormatUint(instance.GetId(), 10), "instances", instance.GetName()),
+			DisksBilledCost:     disksBilledCost,
 			Metrics:             nil,
 			DisksMetrics:        nil,
 		}
 
+		oi.GoogleRecommendations = job.processor.googleRecommendations(oi.Region, oi.Name)
+		oi.Commitment = job.processor.commitments.Coverage(oi.Id)
+
 		if !oi.Skipped {
 			job.processor.lazyloadCounter.Add(1)
 			if job.processor.lazyloadCounter.Load() > uint32(1) {
@@ -99,6 +175,41 @@ func (job *ListComputeInstancesJob) Run(ctx context.Context) error {
 
 }
 
+// commitmentDemands returns the vCPUs and memory of the running, non-preemptible instances, the only usage
+// commitments apply to
+func (job *ListComputeInstancesJob) commitmentDemands(ctx context.Context, instances []*computepb.Instance) []commitmentDemand {
+	machineTypes := make(map[string]*computepb.MachineType)
+	var demands []commitmentDemand
+	for _, instance := range instances {
+		if instance.GetStatus() != "RUNNING" || instance.GetScheduling().GetPreemptible() ||
+			instance.GetScheduling().GetProvisioningModel() == "SPOT" {
+			continue
+		}
+		zone := util.TrimmedString(instance.GetZone(), "/")
+		machineTypeName := util.TrimmedString(instance.GetMachineType(), "/")
+		key := fmt.Sprintf("%s/%s", zone, machineTypeName)
+		machineType, ok := machineTypes[key]
+		if !ok {
+			var err error
+			machineType, err = job.processor.provider.GetMachineType(ctx, machineTypeName, zone)
+			if err != nil {
+				// the instance is priced without commitment coverage
+				log.Printf("failed to get machine type %s: %s", key, err.Error())
+				continue
+			}
+			machineTypes[key] = machineType
+		}
+		demands = append(demands, commitmentDemand{
+			ItemId:      strconv.FormatUint(instance.GetId(), 10),
+			Zone:        zone,
+			MachineType: machineTypeName,
+			Cpu:         int64(machineType.GetGuestCpus()),
+			MemoryMb:    int64(machineType.GetMemoryMb()),
+		})
+	}
+	return demands
+}
+
 func mapImageToOS(image string) string {
 	if strings.Contains(image, "rhel-") {
 		if strings.Contains(image, "sap") {
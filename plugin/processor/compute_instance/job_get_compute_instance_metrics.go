@@ -89,6 +89,58 @@ func (job *GetComputeInstanceMetricsJob) Run(ctx context.Context) error {
 		return err
 	}
 
+	// gpu metrics are only reported by the ops agent, instances without it will have no datapoints
+	var gpuMetric, gpuMemoryMetric []*golang2.DataPoint
+	if len(item.Accelerators) > 0 {
+		gpuRequest := job.processor.metricProvider.NewTimeSeriesRequest(
+			fmt.Sprintf(
+				`metric.type="%s" AND resource.labels.instance_id="%s"`,
+				"agent.googleapis.com/gpu/utilization",
+				fmt.Sprint(item.Instance.GetId()),
+			),
+			&monitoringpb.TimeInterval{
+				EndTime:   timestamppb.New(endTime),
+				StartTime: timestamppb.New(startTime),
+			},
+			&monitoringpb.Aggregation{
+				AlignmentPeriod: &durationpb.Duration{
+					Seconds: 60,
+				},
+				PerSeriesAligner:   monitoringpb.Aggregation_ALIGN_MEAN, // will represent all the datapoints in the above period, with a mean
+				CrossSeriesReducer: monitoringpb.Aggregation_REDUCE_MAX, // the busiest gpu of the instance
+			},
+		)
+
+		gpuMetric, err = job.processor.metricProvider.GetMetric(ctx, gpuRequest)
+		if err != nil {
+			return err
+		}
+
+		gpuMemoryRequest := job.processor.metricProvider.NewTimeSeriesRequest(
+			fmt.Sprintf(
+				`metric.type="%s" AND resource.labels.instance_id="%s" AND metric.labels.memory_state="used"`,
+				"agent.googleapis.com/gpu/memory/bytes_used",
+				fmt.Sprint(item.Instance.GetId()),
+			),
+			&monitoringpb.TimeInterval{
+				EndTime:   timestamppb.New(endTime),
+				StartTime: timestamppb.New(startTime),
+			},
+			&monitoringpb.Aggregation{
+				AlignmentPeriod: &durationpb.Duration{
+					Seconds: 60,
+				},
+				PerSeriesAligner:   monitoringpb.Aggregation_ALIGN_MEAN, // will represent all the datapoints in the above period, with a mean
+				CrossSeriesReducer: monitoringpb.Aggregation_REDUCE_MAX, // the gpu with the most memory in use
+			},
+		)
+
+		gpuMemoryMetric, err = job.processor.metricProvider.GetMetric(ctx, gpuMemoryRequest)
+		if err != nil {
+			return err
+		}
+	}
+
 	disksMetrics := make(map[string]map[string][]*golang2.DataPoint)
 	for _, disk := range item.Disks {
 		id := strconv.FormatUint(disk.Id, 10)
@@ -191,10 +243,20 @@ func (job *GetComputeInstanceMetricsJob) Run(ctx context.Context) error {
 		disksMetrics[id]["DiskWriteThroughput"] = diskWriteThroughputMetrics
 	}
 
+	activityMetrics, err := job.activityMetrics(ctx, item, endTime)
+	if err != nil {
+		// the schedule detection is left out when the activity can not be read
+		log.Printf("failed to get activity metrics of %s: %s", item.Name, err.Error())
+	}
+
 	instanceMetrics := make(map[string][]*golang2.DataPoint)
 
 	instanceMetrics["cpuUtilization"] = cpumetric
 	instanceMetrics["memoryUtilization"] = memoryMetric
+	if len(item.Accelerators) > 0 {
+		instanceMetrics["gpuUtilization"] = gpuMetric
+		instanceMetrics["gpuMemoryUsage"] = gpuMemoryMetric
+	}
 
 	item.OptimizationLoading = true
 	item.Skipped = false
@@ -202,6 +264,7 @@ func (job *GetComputeInstanceMetricsJob) Run(ctx context.Context) error {
 	item.LazyLoadingEnabled = false
 	item.Metrics = instanceMetrics
 	item.DisksMetrics = disksMetrics
+	item.ActivityMetrics = activityMetrics
 
 	for d, v := range item.DisksMetrics {
 		for k, v := range v {
@@ -218,3 +281,58 @@ func (job *GetComputeInstanceMetricsJob) Run(ctx context.Context) error {
 	return nil
 
 }
+
+// activityMetrics returns the hourly peak CPU utilization and network traffic (bytes per second, sent and
+// received on all interfaces) of the instance over the schedule detection window
+func (job *GetComputeInstanceMetricsJob) activityMetrics(ctx context.Context, item ComputeInstanceItem, endTime time.Time) (map[string][]*golang2.DataPoint, error) {
+	interval := &monitoringpb.TimeInterval{
+		EndTime:   timestamppb.New(endTime.Truncate(time.Hour)),
+		StartTime: timestamppb.New(endTime.Truncate(time.Hour).Add(-scheduleLookback)),
+	}
+
+	cpuRequest := job.processor.metricProvider.NewTimeSeriesRequest(
+		fmt.Sprintf(
+			`metric.type="%s" AND resource.labels.instance_id="%s"`,
+			"compute.googleapis.com/instance/cpu/utilization",
+			fmt.Sprint(item.Instance.GetId()),
+		),
+		interval,
+		&monitoringpb.Aggregation{
+			AlignmentPeriod: &durationpb.Duration{
+				Seconds: 3600,
+			},
+			PerSeriesAligner: monitoringpb.Aggregation_ALIGN_MAX, // the busiest minute of the hour
+		},
+	)
+	cpuMetric, err := job.processor.metricProvider.GetMetric(ctx, cpuRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	networkRequest := job.processor.metricProvider.NewTimeSeriesRequest(
+		fmt.Sprintf(
+			`metric.type=one_of("%s", "%s") AND resource.labels.instance_id="%s"`,
+			"compute.googleapis.com/instance/network/received_bytes_count",
+			"compute.googleapis.com/instance/network/sent_bytes_count",
+			fmt.Sprint(item.Instance.GetId()),
+		),
+		interval,
+		&monitoringpb.Aggregation{
+			AlignmentPeriod: &durationpb.Duration{
+				Seconds: 3600,
+			},
+			PerSeriesAligner: monitoringpb.Aggregation_ALIGN_RATE,
+			// the received and sent series of every interface are summed, the threshold applies to the total traffic
+			CrossSeriesReducer: monitoringpb.Aggregation_REDUCE_SUM,
+		},
+	)
+	networkMetric, err := job.processor.metricProvider.GetMetric(ctx, networkRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]*golang2.DataPoint{
+		"cpuUtilization":   cpuMetric,
+		"networkBytesRate": networkMetric,
+	}, nil
+}
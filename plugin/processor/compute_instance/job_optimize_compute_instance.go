@@ -8,6 +8,7 @@ import (
 	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	"log"
 	"strconv"
 	"strings"
 
@@ -20,6 +21,8 @@ import (
 type OptimizeComputeInstancesJob struct {
 	processor *ComputeInstanceProcessor
 	itemId    string
+	// reEvaluation is set when the preferences of the item were changed by the user
+	reEvaluation bool
 }
 
 func NewOptimizeComputeInstancesJob(processor *ComputeInstanceProcessor, itemId string) *OptimizeComputeInstancesJob {
@@ -80,10 +83,15 @@ func (job *OptimizeComputeInstancesJob) Run(ctx context.Context) error {
 		}
 	}
 
+	// the usage statistic preferences cap the datapoints the rightsizing is based on
+	usageStatistics := map[string]string{
+		"cpuUtilization":    preferencesMap["CPUUsageStatistic"].GetValue(),
+		"memoryUtilization": preferencesMap["MemoryUsageStatistic"].GetValue(),
+	}
 	metrics := make(map[string]*golang2.Metric)
 	for k, v := range item.Metrics {
 		metrics[k] = &golang2.Metric{
-			Data: v,
+			Data: shared.CapDataPoints(v, usageStatistics[k]),
 		}
 	}
 
@@ -113,6 +121,7 @@ func (job *OptimizeComputeInstancesJob) Run(ctx context.Context) error {
 			MachineType:       item.MachineType,
 			Preemptible:       item.Preemptible,
 			InstanceOsLicense: item.InstanceOsLicense,
+			Accelerators:      item.Accelerators,
 		},
 		Disks:        disks,
 		Preferences:  preferencesMap,
@@ -130,10 +139,58 @@ func (job *OptimizeComputeInstancesJob) Run(ctx context.Context) error {
 	item.SkipReason = "N/A"
 	item.LazyLoadingEnabled = false
 	item.Wastage = response
+	addUsagePercentiles(item, metrics, usageStatistics)
+	detectActivity(&item)
+	recommendCustomMachineType(ctx, job.processor.prices, &item)
+	recommendAccelerators(&item)
+	adviseDisks(&item)
+	item.Arm = assessArm(ctx, job.processor.prices, item)
 
 	job.processor.items.Set(job.itemId, item)
 	job.processor.publishOptimizationItem(item.ToOptimizationItem())
 	job.processor.UpdateSummary(item.Id)
 
+	if job.processor.applyOptions.Enabled && job.processor.applyOptions.Instances[item.Name] && item.ApplyStatus == "" {
+		job.processor.jobQueue.Push(NewApplyComputeInstanceJob(job.processor, item.Id))
+	}
+
+	if job.reEvaluation && job.processor.exported.Load() {
+		err = job.processor.exportFiles()
+		if err != nil {
+			log.Printf("failed to export files: %v", err)
+		}
+	}
+
 	return nil
 }
+
+// detectActivity detects the schedule and idleness of the instance from its activity metrics, with the
+// activity thresholds of its preferences
+func detectActivity(item *ComputeInstanceItem) {
+	thresholds := activityThresholds(preferences.Export(item.Preferences))
+	item.Schedule = DetectSchedule(item.ActivityMetrics["cpuUtilization"], item.ActivityMetrics["networkBytesRate"], thresholds)
+	item.Idle = DetectIdle(item.ActivityMetrics["cpuUtilization"], item.ActivityMetrics["networkBytesRate"], thresholds)
+}
+
+// addUsagePercentiles sets the percentiles of the usage the rightsizing reported, from the collected datapoints
+// and the ones sent capped at the usage statistics
+func addUsagePercentiles(item ComputeInstanceItem, sent map[string]*golang2.Metric, statistics map[string]string) {
+	rightsizing := item.Wastage.GetRightsizing()
+	if rightsizing != nil {
+		for metric, usage := range map[string]*golang2.Usage{
+			"cpuUtilization":    rightsizing.Cpu,
+			"memoryUtilization": rightsizing.Memory,
+		} {
+			shared.AddPercentiles(usage, item.Metrics[metric], sent[metric].GetData(), statistics[metric])
+		}
+	}
+
+	// the disk datapoints are sent as they are
+	for id, disk := range item.Wastage.GetVolumesRightsizing() {
+		dps := item.DisksMetrics[id]
+		shared.AddPercentiles(disk.ReadIops, dps["DiskReadIOPS"], dps["DiskReadIOPS"], "")
+		shared.AddPercentiles(disk.WriteIops, dps["DiskWriteIOPS"], dps["DiskWriteIOPS"], "")
+		shared.AddPercentiles(disk.ReadThroughput, dps["DiskReadThroughput"], dps["DiskReadThroughput"], "")
+		shared.AddPercentiles(disk.WriteThroughput, dps["DiskWriteThroughput"], dps["DiskWriteThroughput"], "")
+	}
+}
@@ -0,0 +1,247 @@
+package compute_instance
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+)
+
+const monthHours = 730
+
+// skuFamily is how the Compute Engine SKU descriptions of the Cloud Billing Catalog name the vCPUs
+// and memory of a machine family
+type skuFamily struct {
+	// Name prefixes the on-demand SKUs, e.g. "N2 Instance Core running in Americas"
+	Name string
+	// Commitment names the family in the commitment SKUs, e.g. "Commitment v1: N2 Cpu in Americas for 1 Year",
+	// N1 commitments have no family name
+	Commitment string
+}
+
+var skuFamilies = map[string]skuFamily{
+	"n1":  {Name: "N1 Predefined Instance", Commitment: ""},
+	"n2":  {Name: "N2 Instance", Commitment: "N2 "},
+	"n2d": {Name: "N2D AMD Instance", Commitment: "N2D AMD "},
+	"n4":  {Name: "N4 Instance", Commitment: "N4 "},
+	"e2":  {Name: "E2 Instance", Commitment: "E2 "},
+	"t2d": {Name: "T2D AMD Instance", Commitment: "T2D AMD "},
+	"t2a": {Name: "T2A Arm Instance"},
+	"c4a": {Name: "C4A Arm Instance"},
+	"c2":  {Name: "Compute optimized", Commitment: "Compute optimized "},
+	"c2d": {Name: "C2D AMD Instance", Commitment: "C2D AMD "},
+	"c3":  {Name: "C3 Instance", Commitment: "C3 "},
+	"c3d": {Name: "C3D Instance", Commitment: "C3D "},
+	"m1":  {Name: "Memory-optimized Instance", Commitment: "Memory-optimized "},
+	"m2":  {Name: "Memory-optimized Instance", Commitment: "Memory-optimized "},
+	"m3":  {Name: "M3 Memory-optimized Instance", Commitment: "M3 Memory-optimized "},
+}
+
+// customSkuFamilies name the families with custom machine types in the custom SKU descriptions, e.g.
+// "N2 Custom Instance Core running in Americas", N1 custom SKUs have no family name
+var customSkuFamilies = map[string]string{
+	"n1":  "",
+	"n2":  "N2 ",
+	"n2d": "N2D AMD ",
+	"e2":  "E2 ",
+}
+
+// resourcePrices is the hourly price of a vCPU and of a GB of memory
+type resourcePrices struct {
+	Cpu      float64
+	MemoryGb float64
+}
+
+// Hourly returns the hourly price of the vCPUs and memory
+func (p resourcePrices) Hourly(cpu, memoryMb int64) float64 {
+	return float64(cpu)*p.Cpu + float64(memoryMb)/1024*p.MemoryGb
+}
+
+// fallbackPrices are the N2 on-demand prices in us-central1 (https://cloud.google.com/compute/vm-instance-pricing),
+// used when the Cloud Billing Catalog can not be read or has no price for the family or region
+var fallbackPrices = resourcePrices{Cpu: 0.031611, MemoryGb: 0.004237}
+
+// fallbackCustomPrices and fallbackExtendedMemoryGbPrice are the N2 custom on-demand prices in us-central1
+// (https://cloud.google.com/compute/vm-instance-pricing), used like fallbackPrices for custom machine types
+var fallbackCustomPrices = resourcePrices{Cpu: 0.033174, MemoryGb: 0.004446}
+
+const fallbackExtendedMemoryGbPrice = 0.00955
+
+// fallbackCommitmentDiscount is the published resource-based committed use discount of a plan
+// (https://cloud.google.com/compute/docs/instances/committed-use-discounts-overview), used with fallbackPrices
+func fallbackCommitmentDiscount(family, plan string) float64 {
+	memoryOptimized := family == "m1" || family == "m2" || family == "m3"
+	switch plan {
+	case "TWELVE_MONTH":
+		if memoryOptimized {
+			return 0.41
+		}
+		return 0.37
+	case "THIRTY_SIX_MONTH":
+		if memoryOptimized {
+			return 0.70
+		}
+		return 0.55
+	}
+	return 0
+}
+
+// commitmentFamily returns a machine family covered by the commitment type, the families sharing a
+// commitment type share its prices
+func commitmentFamily(commitmentType string) string {
+	var families []string
+	for family, t := range commitmentTypes {
+		if t == commitmentType {
+			families = append(families, family)
+		}
+	}
+	if len(families) == 0 {
+		return ""
+	}
+	sort.Strings(families)
+	return families[0]
+}
+
+// priceBook caches the prices of the machine families per region, read from the Cloud Billing Catalog.
+// A nil priceBook always returns the fallback prices.
+type priceBook struct {
+	pricing *gcp.Pricing
+
+	lock   sync.Mutex
+	prices map[string]catalogPrices
+}
+
+// catalogPrices are cached prices, Found is not set when they are the fallback prices
+type catalogPrices struct {
+	resourcePrices
+	Found bool
+}
+
+func newPriceBook(pricing *gcp.Pricing) *priceBook {
+	return &priceBook{
+		pricing: pricing,
+		prices:  make(map[string]catalogPrices),
+	}
+}
+
+// OnDemand returns the on-demand prices of the machine family in the region
+func (b *priceBook) OnDemand(ctx context.Context, family, region string) resourcePrices {
+	prices, _ := b.Instance(ctx, family, region, false)
+	return prices
+}
+
+// Instance returns the on-demand or spot prices of the machine family in the region, and whether the Cloud
+// Billing Catalog has them, a family is not offered in the regions it has no price for
+func (b *priceBook) Instance(ctx context.Context, family, region string, preemptible bool) (resourcePrices, bool) {
+	sku, ok := skuFamilies[family]
+	if b == nil || !ok {
+		return fallbackPrices, false
+	}
+	prefix, usageType := sku.Name, gcp.UsageOnDemand
+	if preemptible {
+		prefix, usageType = "Spot Preemptible "+sku.Name, gcp.UsagePreemptible
+	}
+	return b.lookup(ctx, fmt.Sprintf("%s/%s/%s", family, region, usageType), func() (resourcePrices, error) {
+		return b.skuPrices(ctx, prefix+" Core running in", prefix+" Ram running in", usageType, region)
+	}, fallbackPrices)
+}
+
+// Committed returns the prices of a resource-based commitment of the plan for the machine family in the region
+func (b *priceBook) Committed(ctx context.Context, family, region, plan string) resourcePrices {
+	fallback := resourcePrices{
+		Cpu:      fallbackPrices.Cpu * (1 - fallbackCommitmentDiscount(family, plan)),
+		MemoryGb: fallbackPrices.MemoryGb * (1 - fallbackCommitmentDiscount(family, plan)),
+	}
+	sku, ok := skuFamilies[family]
+	if b == nil || !ok {
+		return fallback
+	}
+	usageType := gcp.UsageCommit1Yr
+	if plan == "THIRTY_SIX_MONTH" {
+		usageType = gcp.UsageCommit3Yr
+	}
+	prefix := "Commitment v1: " + sku.Commitment
+	prices, _ := b.lookup(ctx, fmt.Sprintf("%s/%s/%s", family, region, usageType), func() (resourcePrices, error) {
+		return b.skuPrices(ctx, prefix+"Cpu in", prefix+"Ram in", usageType, region)
+	}, fallback)
+	return prices
+}
+
+// Custom returns the prices of custom vCPUs and memory of the machine family in the region, for on-demand or
+// spot instances
+func (b *priceBook) Custom(ctx context.Context, family, region string, preemptible bool) resourcePrices {
+	name, ok := customSkuFamilies[family]
+	if b == nil || !ok {
+		return fallbackCustomPrices
+	}
+	prefix, usageType := customSkuPrefix(name, preemptible)
+	prices, _ := b.lookup(ctx, fmt.Sprintf("%s/%s/%s/custom", family, region, usageType), func() (resourcePrices, error) {
+		return b.skuPrices(ctx, prefix+"Custom Instance Core running in", prefix+"Custom Instance Ram running in", usageType, region)
+	}, fallbackCustomPrices)
+	return prices
+}
+
+// ExtendedMemory returns the price of a GB of extended memory of the machine family in the region, for on-demand
+// or spot instances
+func (b *priceBook) ExtendedMemory(ctx context.Context, family, region string, preemptible bool) float64 {
+	name, ok := customSkuFamilies[family]
+	if b == nil || !ok {
+		return fallbackExtendedMemoryGbPrice
+	}
+	prefix, usageType := customSkuPrefix(name, preemptible)
+	prices, _ := b.lookup(ctx, fmt.Sprintf("%s/%s/%s/extended", family, region, usageType), func() (resourcePrices, error) {
+		memoryGb, err := b.pricing.HourlyPrice(ctx, prefix+"Custom Extended Instance Ram running in", usageType, region)
+		return resourcePrices{MemoryGb: memoryGb}, err
+	}, resourcePrices{MemoryGb: fallbackExtendedMemoryGbPrice})
+	return prices.MemoryGb
+}
+
+// customSkuPrefix returns the description prefix and usage type of the custom SKUs of a family, spot SKUs are
+// named e.g. "Spot Preemptible N2 Custom Instance Core running in Americas"
+func customSkuPrefix(name string, preemptible bool) (string, string) {
+	if preemptible {
+		return "Spot Preemptible " + name, gcp.UsagePreemptible
+	}
+	return name, gcp.UsageOnDemand
+}
+
+// CommitmentDiscount returns the discount of a commitment of the plan over the on-demand price of its vCPUs and memory
+func (b *priceBook) CommitmentDiscount(ctx context.Context, family, region, plan string, cpu, memoryMb int64) float64 {
+	onDemand := b.OnDemand(ctx, family, region).Hourly(cpu, memoryMb)
+	if onDemand == 0 {
+		return 0
+	}
+	return 1 - b.Committed(ctx, family, region, plan).Hourly(cpu, memoryMb)/onDemand
+}
+
+func (b *priceBook) lookup(ctx context.Context, key string, fetch func() (resourcePrices, error), fallback resourcePrices) (resourcePrices, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if prices, ok := b.prices[key]; ok {
+		return prices.resourcePrices, prices.Found
+	}
+	prices, err := fetch()
+	if err != nil {
+		log.Printf("using list prices of N2 in us-central1 for %s: %s", key, err.Error())
+		b.prices[key] = catalogPrices{resourcePrices: fallback}
+		return fallback, false
+	}
+	b.prices[key] = catalogPrices{resourcePrices: prices, Found: true}
+	return prices, true
+}
+
+func (b *priceBook) skuPrices(ctx context.Context, cpuPrefix, memoryPrefix, usageType, region string) (resourcePrices, error) {
+	cpu, err := b.pricing.HourlyPrice(ctx, cpuPrefix, usageType, region)
+	if err != nil {
+		return resourcePrices{}, err
+	}
+	memoryGb, err := b.pricing.HourlyPrice(ctx, memoryPrefix, usageType, region)
+	if err != nil {
+		return resourcePrices{}, err
+	}
+	return resourcePrices{Cpu: cpu, MemoryGb: memoryGb}, nil
+}
@@ -0,0 +1,246 @@
+package compute_instance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+)
+
+const (
+	// scheduleLookback is the window of the hourly activity datapoints a schedule is detected from
+	scheduleLookback = 14 * 24 * time.Hour
+	// scheduleMinObservedHours is the hours of activity datapoints needed to detect a schedule, a bit less
+	// than a week so a few missing datapoints do not prevent it
+	scheduleMinObservedHours = 160
+	// scheduleMinOffFraction is the part of the week an instance has to be stopped by its schedule
+	// for the schedule to be recommended
+	scheduleMinOffFraction = 0.25
+
+	// an hour is active when the CPU utilization or the network traffic of the instance exceeds the
+	// ActiveCPUUtilization (%) and ActiveNetworkRate (KiB/s) preferences, these are their defaults
+	defaultActiveCpuUtilization = 10
+	defaultActiveNetworkRate    = 50
+)
+
+// ActivityThresholds are the CPU utilization (a ratio) and network traffic (bytes per second) of the
+// instance above which an hour is active
+type ActivityThresholds struct {
+	CpuUtilization float64
+	NetworkRate    float64
+}
+
+// activityThresholds reads the thresholds from the ActiveCPUUtilization and ActiveNetworkRate preferences,
+// the defaults are kept for a missing or invalid preference
+func activityThresholds(prefs map[string]*string) ActivityThresholds {
+	thresholds := ActivityThresholds{
+		CpuUtilization: defaultActiveCpuUtilization / 100.0,
+		NetworkRate:    defaultActiveNetworkRate * 1024,
+	}
+	if v := prefs["ActiveCPUUtilization"]; v != nil {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(*v), 64); err == nil && f >= 0 {
+			thresholds.CpuUtilization = f / 100
+		}
+	}
+	if v := prefs["ActiveNetworkRate"]; v != nil {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(*v), 64); err == nil && f >= 0 {
+			thresholds.NetworkRate = f * 1024
+		}
+	}
+	return thresholds
+}
+
+// InstanceSchedule is the start/stop schedule of an instance only used at given hours of given days,
+// the hours are in UTC and the instance runs from StartHour until StopHour on every day of Days
+type InstanceSchedule struct {
+	Days      []time.Weekday `json:"days"`
+	StartHour int            `json:"start_hour"`
+	StopHour  int            `json:"stop_hour"`
+	TimeZone  string         `json:"time_zone"`
+	// RunningHours is the hours per week the instance runs on the schedule
+	RunningHours  int `json:"running_hours"`
+	ObservedHours int `json:"observed_hours"`
+}
+
+// DetectSchedule looks for the days and hours of the week the instance is active in its hourly CPU and
+// network datapoints, an hour of the week is active when it is active in any of the observed weeks. It
+// returns nil when less than a week was observed, the instance is never active or the schedule would
+// not stop it for long enough.
+func DetectSchedule(cpu, network []*golang2.DataPoint, thresholds ActivityThresholds) *InstanceSchedule {
+	active, observed := weeklyActivity(cpu, network, thresholds)
+	if observed < scheduleMinObservedHours {
+		return nil
+	}
+
+	schedule := &InstanceSchedule{TimeZone: "UTC", StartHour: 24, ObservedHours: observed}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		dayActive := false
+		for hour := 0; hour < 24; hour++ {
+			if active[day][hour] {
+				dayActive = true
+				schedule.StartHour = min(schedule.StartHour, hour)
+				schedule.StopHour = max(schedule.StopHour, hour+1)
+			}
+		}
+		if dayActive {
+			schedule.Days = append(schedule.Days, day)
+		}
+	}
+	if len(schedule.Days) == 0 {
+		return nil
+	}
+
+	// an hour of margin on both ends, so the instance is up before the first activity
+	schedule.StartHour = max(schedule.StartHour-1, 0)
+	schedule.StopHour = min(schedule.StopHour+1, 24)
+	schedule.RunningHours = len(schedule.Days) * (schedule.StopHour - schedule.StartHour)
+
+	if float64(schedule.RunningHours) > 7*24*(1-scheduleMinOffFraction) {
+		return nil
+	}
+	return schedule
+}
+
+// DetectIdle tells whether the instance was not active in any hour of its hourly CPU and network datapoints,
+// over at least the hours needed to detect a schedule
+func DetectIdle(cpu, network []*golang2.DataPoint, thresholds ActivityThresholds) bool {
+	active, observed := weeklyActivity(cpu, network, thresholds)
+	if observed < scheduleMinObservedHours {
+		return false
+	}
+	for day := range active {
+		for hour := range active[day] {
+			if active[day][hour] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// weeklyActivity returns the hours of the week the instance is active in any of the observed weeks and the
+// number of hours observed
+func weeklyActivity(cpu, network []*golang2.DataPoint, thresholds ActivityThresholds) ([7][24]bool, int) {
+	var active [7][24]bool
+	observed := make(map[int64]bool)
+
+	// an aligned datapoint ends at the end of its hour, the start of a gauge datapoint is its end
+	mark := func(dps []*golang2.DataPoint, threshold float64) {
+		for _, dp := range dps {
+			second := dp.GetEndTime().GetValue() - 1
+			observed[second/3600] = true
+			if dp.GetValue() > threshold {
+				t := time.Unix(second, 0).UTC()
+				active[t.Weekday()][t.Hour()] = true
+			}
+		}
+	}
+	mark(cpu, thresholds.CpuUtilization)
+	mark(network, thresholds.NetworkRate)
+	return active, len(observed)
+}
+
+func (s InstanceSchedule) String() string {
+	return fmt.Sprintf("%s %02d:00-%02d:00 %s", daysString(s.Days), s.StartHour, s.StopHour, s.TimeZone)
+}
+
+// StartCron and StopCron are the cron expressions of the vm start and stop schedules of the resource policy.
+// Stopping at midnight is done at the start of the next day, and an instance running whole days is only
+// started on the first and stopped after the last of consecutive days.
+func (s InstanceSchedule) StartCron() string {
+	var days []time.Weekday
+	for _, day := range s.Days {
+		if !s.wholeDays() || !s.scheduled((day+6)%7) {
+			days = append(days, day)
+		}
+	}
+	return fmt.Sprintf("0 %d * * %s", s.StartHour, cronDays(days, 0))
+}
+
+func (s InstanceSchedule) StopCron() string {
+	if s.StopHour < 24 {
+		return fmt.Sprintf("0 %d * * %s", s.StopHour, cronDays(s.Days, 0))
+	}
+	var days []time.Weekday
+	for _, day := range s.Days {
+		if !s.wholeDays() || !s.scheduled((day+1)%7) {
+			days = append(days, day)
+		}
+	}
+	return fmt.Sprintf("0 0 * * %s", cronDays(days, 1))
+}
+
+func (s InstanceSchedule) wholeDays() bool {
+	return s.StartHour == 0 && s.StopHour == 24
+}
+
+func (s InstanceSchedule) scheduled(day time.Weekday) bool {
+	for _, d := range s.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+func cronDays(days []time.Weekday, shift int) string {
+	var values []string
+	for _, day := range days {
+		values = append(values, fmt.Sprintf("%d", (int(day)+shift)%7))
+	}
+	return strings.Join(values, ",")
+}
+
+// daysString renders the days with consecutive days as ranges, e.g. Mon-Fri
+func daysString(days []time.Weekday) string {
+	var parts []string
+	for i := 0; i < len(days); {
+		j := i
+		for j+1 < len(days) && days[j+1] == days[j]+1 {
+			j++
+		}
+		switch {
+		case j == i:
+			parts = append(parts, days[i].String()[:3])
+		case j == i+1:
+			parts = append(parts, days[i].String()[:3], days[j].String()[:3])
+		default:
+			parts = append(parts, days[i].String()[:3]+"-"+days[j].String()[:3])
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// ScheduleSaving is the monthly saving of running the instance on its schedule instead of 24/7. The part of
+// the cost covered by commitments is paid whether the instance runs or not, and sustained use discounts barely
+// apply to an instance running part of the month so the scheduled vCPU and memory hours are priced without them.
+// The OS license and GPU charges have no sustained use discount.
+func (i ComputeInstanceItem) ScheduleSaving() float64 {
+	if i.Schedule == nil || i.Wastage == nil || i.Wastage.Rightsizing == nil || i.Wastage.Rightsizing.Current == nil {
+		return 0
+	}
+	current, _ := i.InstanceCosts()
+	c := i.Wastage.Rightsizing.Current
+	sud := 0.0
+	if !c.Preemptible {
+		sud = sustainedUseDiscounts[machineFamily(c.MachineType)]
+	}
+	extraCost := min(c.OsLicenseCost+c.AcceleratorsCost, current.OnDemandCost)
+	scheduledCost := ((current.OnDemandCost-extraCost)/(1-sud) + extraCost) * float64(i.Schedule.RunningHours) / (7 * 24)
+	return max(current.OnDemandCost-scheduledCost, 0)
+}
+
+// scheduleGcloudCommands creates the instance schedule resource policy of the instance and attaches it,
+// the instance service agent needs the compute.instances.start and stop permissions to apply it
+func (i ComputeInstanceItem) scheduleGcloudCommands(target, region string) []string {
+	policy := gcp.ComputeResourceName(fmt.Sprintf("%s-schedule", i.Name))
+	return []string{
+		fmt.Sprintf("gcloud compute resource-policies create instance-schedule %s --project=%s --region=%s --vm-start-schedule=\"%s\" --vm-stop-schedule=\"%s\" --timezone=%s",
+			policy, i.ProjectId, region, i.Schedule.StartCron(), i.Schedule.StopCron(), i.Schedule.TimeZone),
+		fmt.Sprintf("gcloud compute instances add-resource-policies %s %s --resource-policies=%s", i.Name, target, policy),
+	}
+}
@@ -0,0 +1,194 @@
+package compute_instance
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// activity returns two weeks of hourly datapoints from Sunday 2023-01-01 UTC, with the value of active
+// hours where active is true
+func activity(value float64, active func(day time.Weekday, hour int) bool) []*golang2.DataPoint {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	var dps []*golang2.DataPoint
+	for h := 0; h < 14*24; h++ {
+		t := start.Add(time.Duration(h) * time.Hour)
+		v := 0.0
+		if active(t.Weekday(), t.Hour()) {
+			v = value
+		}
+		dps = append(dps, &golang2.DataPoint{
+			StartTime: wrapperspb.Int64(t.Unix()),
+			EndTime:   wrapperspb.Int64(t.Add(time.Hour).Unix()),
+			Value:     v,
+		})
+	}
+	return dps
+}
+
+func officeHours(day time.Weekday, hour int) bool {
+	return day >= time.Monday && day <= time.Friday && hour >= 9 && hour < 17
+}
+
+func TestDetectSchedule(t *testing.T) {
+	defaults := activityThresholds(nil)
+	never := func(time.Weekday, int) bool { return false }
+	always := func(time.Weekday, int) bool { return true }
+
+	tests := []struct {
+		name       string
+		cpu        []*golang2.DataPoint
+		network    []*golang2.DataPoint
+		thresholds ActivityThresholds
+		want       string
+		wantIdle   bool
+	}{
+		{name: "office hours", cpu: activity(0.5, officeHours), thresholds: defaults, want: "Mon-Fri 08:00-18:00 UTC"},
+		{name: "network only", cpu: activity(0.5, never), network: activity(1024*1024, officeHours), thresholds: defaults,
+			want: "Mon-Fri 08:00-18:00 UTC"},
+		{name: "weekends", cpu: activity(0.5, func(day time.Weekday, _ int) bool {
+			return day == time.Saturday || day == time.Sunday
+		}), thresholds: defaults, want: "Sun,Sat 00:00-24:00 UTC"},
+		{name: "always active", cpu: activity(0.5, always), thresholds: defaults},
+		{name: "never active", cpu: activity(0.05, always), thresholds: defaults, wantIdle: true},
+		{name: "below a raised threshold", cpu: activity(0.5, officeHours),
+			thresholds: activityThresholds(map[string]*string{"ActiveCPUUtilization": stringPointer("60")}), wantIdle: true},
+		{name: "above a lowered threshold", cpu: activity(0.05, officeHours),
+			thresholds: activityThresholds(map[string]*string{"ActiveCPUUtilization": stringPointer("1")}), want: "Mon-Fri 08:00-18:00 UTC"},
+		{name: "not observed long enough", cpu: activity(0.5, officeHours)[:100], thresholds: defaults},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := DetectSchedule(tt.cpu, tt.network, tt.thresholds)
+			got := ""
+			if schedule != nil {
+				got = schedule.String()
+			}
+			if got != tt.want {
+				t.Errorf("schedule %q, want %q", got, tt.want)
+			}
+			if idle := DetectIdle(tt.cpu, tt.network, tt.thresholds); idle != tt.wantIdle {
+				t.Errorf("idle %v, want %v", idle, tt.wantIdle)
+			}
+		})
+	}
+}
+
+func stringPointer(s string) *string {
+	return &s
+}
+
+func TestActivityThresholds(t *testing.T) {
+	thresholds := activityThresholds(map[string]*string{
+		"ActiveCPUUtilization": stringPointer(" 5 "),
+		"ActiveNetworkRate":    stringPointer("invalid"),
+	})
+	if thresholds.CpuUtilization != 0.05 || thresholds.NetworkRate != defaultActiveNetworkRate*1024 {
+		t.Errorf("unexpected thresholds %+v", thresholds)
+	}
+}
+
+func TestScheduleCron(t *testing.T) {
+	tests := []struct {
+		name      string
+		schedule  InstanceSchedule
+		wantStart string
+		wantStop  string
+	}{
+		{
+			name:      "office hours",
+			schedule:  InstanceSchedule{Days: []time.Weekday{1, 2, 3, 4, 5}, StartHour: 8, StopHour: 18},
+			wantStart: "0 8 * * 1,2,3,4,5",
+			wantStop:  "0 18 * * 1,2,3,4,5",
+		},
+		{
+			name:      "until midnight",
+			schedule:  InstanceSchedule{Days: []time.Weekday{1, 3}, StartHour: 20, StopHour: 24},
+			wantStart: "0 20 * * 1,3",
+			wantStop:  "0 0 * * 2,4",
+		},
+		{
+			name:      "saturday until midnight",
+			schedule:  InstanceSchedule{Days: []time.Weekday{6}, StartHour: 12, StopHour: 24},
+			wantStart: "0 12 * * 6",
+			wantStop:  "0 0 * * 0",
+		},
+		{
+			// the weekend runs from Saturday to Monday, Sunday is not stopped and started again
+			name:      "whole consecutive days",
+			schedule:  InstanceSchedule{Days: []time.Weekday{0, 6}, StartHour: 0, StopHour: 24},
+			wantStart: "0 0 * * 6",
+			wantStop:  "0 0 * * 1",
+		},
+		{
+			name:      "whole separate days",
+			schedule:  InstanceSchedule{Days: []time.Weekday{1, 3}, StartHour: 0, StopHour: 24},
+			wantStart: "0 0 * * 1,3",
+			wantStop:  "0 0 * * 2,4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if start := tt.schedule.StartCron(); start != tt.wantStart {
+				t.Errorf("start %q, want %q", start, tt.wantStart)
+			}
+			if stop := tt.schedule.StopCron(); stop != tt.wantStop {
+				t.Errorf("stop %q, want %q", stop, tt.wantStop)
+			}
+		})
+	}
+}
+
+func TestScheduleGcloudCommandsPolicyName(t *testing.T) {
+	item := ComputeInstanceItem{
+		ProjectId: "p",
+		Name:      strings.Repeat("a", 60),
+		Schedule:  &InstanceSchedule{Days: []time.Weekday{1}, StartHour: 8, StopHour: 18, TimeZone: "UTC"},
+	}
+	commands := item.scheduleGcloudCommands("--project=p --zone=us-central1-a", "us-central1")
+	policy := strings.Fields(commands[0])[5]
+	if len(policy) > 63 || !strings.HasPrefix(policy, item.Name) {
+		t.Errorf("policy name %q is not a valid resource name", policy)
+	}
+	if !strings.HasSuffix(commands[1], "--resource-policies="+policy) {
+		t.Errorf("the instance is attached to another policy: %s", commands[1])
+	}
+}
+
+func TestScheduleSaving(t *testing.T) {
+	tests := []struct {
+		name        string
+		machineType string
+		preemptible bool
+		cost        float64
+		extraCost   float64
+		want        float64
+	}{
+		// 84 of the 168 weekly hours, the vCPUs and memory without their 20% sustained use discount
+		{name: "sustained use discount", machineType: "n2-standard-4", cost: 100, want: 80 - 100*0.5},
+		// the GPUs have no sustained use discount to lose
+		{name: "accelerators", machineType: "n2-standard-4", cost: 140, extraCost: 40, want: 120 - 140*0.5},
+		{name: "preemptible", machineType: "n2-standard-4", preemptible: true, cost: 100, want: 50},
+		{name: "no sustained use discount", machineType: "e2-standard-4", cost: 100, extraCost: 20, want: 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := ComputeInstanceItem{
+				Schedule: &InstanceSchedule{RunningHours: 84},
+				Wastage: &golang2.GCPComputeOptimizationResponse{
+					Rightsizing: &golang2.GcpComputeInstanceRightsizingRecommendation{
+						Current: &golang2.RightsizingGcpComputeInstance{MachineType: tt.machineType, Cpu: 4, MemoryMb: 16384,
+							Cost: tt.cost, AcceleratorsCost: tt.extraCost, Preemptible: tt.preemptible},
+					},
+				},
+			}
+			if got := item.ScheduleSaving(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("saving %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package compute_instance
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opengovern/plugin-gcp/plugin/processor/shared"
+)
+
+// SnapshotVersion is the format version of the snapshots, bumped on incompatible changes of the records
+const SnapshotVersion = 1
+
+// SnapshotOptions is where the results of every scan are stored and what they are compared to
+type SnapshotOptions struct {
+	// Dir is the directory of the snapshots, a snapshot is stored per project and scan
+	Dir string
+	// DiffFile receives the diff of this scan against the BaseFile snapshot, or against the
+	// previous snapshot of the project when BaseFile is not set
+	DiffFile string
+	BaseFile string
+}
+
+// NewSnapshotOptions stores the snapshots in dir, under the user's home directory when dir is empty
+// and not at all when enabled is false
+func NewSnapshotOptions(enabled, dir, diffFile, baseFile string) SnapshotOptions {
+	options := SnapshotOptions{
+		DiffFile: diffFile,
+		BaseFile: baseFile,
+	}
+	if enabled == "false" {
+		return options
+	}
+	options.Dir = dir
+	if options.Dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			log.Printf("failed to find the home directory, snapshots are disabled: %s", err.Error())
+			return options
+		}
+		options.Dir = filepath.Join(home, ".kaytu", "plugin-gcp", "snapshots")
+	}
+	return options
+}
+
+// Snapshot is the inventory, usage, recommendations and costs of a project at the time of a scan
+type Snapshot struct {
+	Version   int                     `json:"version"`
+	ProjectId string                  `json:"project_id"`
+	CreatedAt time.Time               `json:"created_at"`
+	Items     []ComputeInstanceRecord `json:"items"`
+}
+
+func ReadSnapshot(path string) (*Snapshot, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot Snapshot
+	err = json.Unmarshal(content, &snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %s", path, err.Error())
+	}
+	if snapshot.Version != SnapshotVersion {
+		return nil, fmt.Errorf("snapshot %s has version %d, expected %d", path, snapshot.Version, SnapshotVersion)
+	}
+	return &snapshot, nil
+}
+
+// snapshotPaths returns the snapshots of the project in dir, oldest first
+func snapshotPaths(dir, projectId string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, projectId, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	// snapshot file names are their creation time, so they sort chronologically
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// SnapshotChange is an instance of a snapshot diff, with the costs and recommendations of both snapshots
+type SnapshotChange struct {
+	Id                    string  `json:"id"`
+	Name                  string  `json:"name"`
+	Zone                  string  `json:"zone"`
+	BaseCost              float64 `json:"base_cost"`
+	BaseSavings           float64 `json:"base_savings"`
+	BaseRecommendation    string  `json:"base_recommendation,omitempty"`
+	CurrentCost           float64 `json:"current_cost"`
+	CurrentSavings        float64 `json:"current_savings"`
+	CurrentRecommendation string  `json:"current_recommendation,omitempty"`
+	// RealizedSavings is the cost reduction of a resolved recommendation
+	RealizedSavings float64 `json:"realized_savings,omitempty"`
+	Deleted         bool    `json:"deleted,omitempty"`
+}
+
+// SnapshotDiff compares the recommendations of two snapshots of a project
+type SnapshotDiff struct {
+	ProjectId string    `json:"project_id"`
+	Base      time.Time `json:"base"`
+	Current   time.Time `json:"current"`
+	// NewWaste are the instances with a saving that did not have one in the base snapshot
+	NewWaste []SnapshotChange `json:"new_waste"`
+	// Resolved are the instances with a saving in the base snapshot that no longer have one
+	Resolved []SnapshotChange `json:"resolved"`
+	// Changed are the instances with a saving in both snapshots whose recommendation changed
+	Changed         []SnapshotChange `json:"changed"`
+	NewWasteSavings float64          `json:"new_waste_savings"`
+	RealizedSavings float64          `json:"realized_savings"`
+}
+
+// recordCost returns the current cost, savings and recommendation of the instance and its disks
+func recordCost(r ComputeInstanceRecord) (float64, float64, string) {
+	cost, savings := r.Cost.Current, r.Cost.Savings
+	var recommendation []string
+	if current, recommended := r.Specs(); recommended != nil && current != nil && recommended.MachineType != current.MachineType {
+		recommendation = append(recommendation, recommended.MachineType)
+	}
+	for _, d := range r.Disks {
+		cost += d.Cost.Current
+		savings += d.Cost.Savings
+		current, recommended := d.Specs()
+		if recommended != nil && current != nil &&
+			(recommended.DiskType != current.DiskType || recommended.DiskSize != current.DiskSize) {
+			recommendation = append(recommendation, fmt.Sprintf("%s: %s / %d GB", d.Name, recommended.DiskType, recommended.DiskSize))
+		}
+	}
+	return cost, savings, strings.Join(recommendation, ", ")
+}
+
+// evaluated tells whether the instance of the record was evaluated, a record without a recommendation
+// (skipped or failed) has no savings to compare
+func evaluated(r ComputeInstanceRecord) bool {
+	current, _ := r.Specs()
+	return current != nil
+}
+
+// DiffSnapshots reports the new waste, resolved recommendations and their realized savings, and the
+// changed recommendations of current compared to base. Instances not evaluated in current are left out,
+// and the ones not evaluated in base are compared as new instances.
+func DiffSnapshots(base, current *Snapshot) SnapshotDiff {
+	diff := SnapshotDiff{
+		ProjectId: current.ProjectId,
+		Base:      base.CreatedAt,
+		Current:   current.CreatedAt,
+	}
+
+	baseItems := make(map[string]ComputeInstanceRecord)
+	for _, r := range base.Items {
+		if evaluated(r) {
+			baseItems[r.Id] = r
+		}
+	}
+	currentItems := make(map[string]bool)
+
+	for _, r := range current.Items {
+		currentItems[r.Id] = true
+		if !evaluated(r) {
+			continue
+		}
+		change := SnapshotChange{Id: r.Id, Name: r.Name, Zone: r.Zone}
+		change.CurrentCost, change.CurrentSavings, change.CurrentRecommendation = recordCost(r)
+
+		b, ok := baseItems[r.Id]
+		if ok {
+			change.BaseCost, change.BaseSavings, change.BaseRecommendation = recordCost(b)
+		}
+
+		switch {
+		case change.CurrentSavings > 0 && change.BaseSavings <= 0:
+			diff.NewWaste = append(diff.NewWaste, change)
+			diff.NewWasteSavings += change.CurrentSavings
+		case change.CurrentSavings <= 0 && change.BaseSavings > 0:
+			change.RealizedSavings = max(change.BaseCost-change.CurrentCost, 0)
+			diff.Resolved = append(diff.Resolved, change)
+			diff.RealizedSavings += change.RealizedSavings
+		case change.CurrentSavings > 0 && change.CurrentRecommendation != change.BaseRecommendation:
+			diff.Changed = append(diff.Changed, change)
+		}
+	}
+
+	// a deleted instance with a saving realized all of its cost
+	for _, b := range baseItems {
+		if currentItems[b.Id] {
+			continue
+		}
+		change := SnapshotChange{Id: b.Id, Name: b.Name, Zone: b.Zone, Deleted: true}
+		change.BaseCost, change.BaseSavings, change.BaseRecommendation = recordCost(b)
+		if change.BaseSavings > 0 {
+			change.RealizedSavings = change.BaseCost
+			diff.Resolved = append(diff.Resolved, change)
+			diff.RealizedSavings += change.RealizedSavings
+		}
+	}
+
+	for _, changes := range [][]SnapshotChange{diff.NewWaste, diff.Resolved, diff.Changed} {
+		sort.Slice(changes, func(i, j int) bool {
+			return changes[i].Name < changes[j].Name
+		})
+	}
+	return diff
+}
+
+// DiffSnapshotFiles diffs two snapshot files of the same project, without a scan
+func DiffSnapshotFiles(basePath, currentPath string) (SnapshotDiff, error) {
+	base, err := ReadSnapshot(basePath)
+	if err != nil {
+		return SnapshotDiff{}, err
+	}
+	current, err := ReadSnapshot(currentPath)
+	if err != nil {
+		return SnapshotDiff{}, err
+	}
+	if base.ProjectId != current.ProjectId {
+		return SnapshotDiff{}, fmt.Errorf("snapshot %s is of project %s and %s of project %s", basePath, base.ProjectId,
+			currentPath, current.ProjectId)
+	}
+	return DiffSnapshots(base, current), nil
+}
+
+func (d SnapshotDiff) String() string {
+	return fmt.Sprintf("%s since %s: %d new waste (%.2f), %d resolved (%.2f realized), %d changed", d.ProjectId,
+		d.Base.Format(time.RFC3339), len(d.NewWaste), d.NewWasteSavings, len(d.Resolved), d.RealizedSavings, len(d.Changed))
+}
+
+// saveSnapshots stores a snapshot per project and writes the diff against the base snapshot
+func (m *ComputeInstanceProcessor) saveSnapshots() error {
+	createdAt := time.Now().UTC()
+	snapshots := make(map[string]*Snapshot)
+	m.items.Range(func(_ string, item ComputeInstanceItem) bool {
+		s, ok := snapshots[item.ProjectId]
+		if !ok {
+			s = &Snapshot{Version: SnapshotVersion, ProjectId: item.ProjectId, CreatedAt: createdAt}
+			snapshots[item.ProjectId] = s
+		}
+		s.Items = append(s.Items, item.Record())
+		return true
+	})
+
+	var diffs []SnapshotDiff
+	for projectId, snapshot := range snapshots {
+		sort.Slice(snapshot.Items, func(i, j int) bool {
+			return snapshot.Items[i].Id < snapshot.Items[j].Id
+		})
+
+		base, err := m.baseSnapshot(projectId)
+		if err != nil {
+			return err
+		}
+
+		if m.snapshotOptions.Dir != "" {
+			err = os.MkdirAll(filepath.Join(m.snapshotOptions.Dir, projectId), 0755)
+			if err != nil {
+				return err
+			}
+			content, err := json.Marshal(snapshot)
+			if err != nil {
+				return err
+			}
+			path := filepath.Join(m.snapshotOptions.Dir, projectId, createdAt.Format("20060102T150405Z")+".json")
+			err = os.WriteFile(path, content, 0644)
+			if err != nil {
+				return err
+			}
+			log.Printf("snapshot saved to %s", path)
+		}
+
+		if base != nil {
+			diff := DiffSnapshots(base, snapshot)
+			log.Print(diff.String())
+			diffs = append(diffs, diff)
+		}
+	}
+
+	if m.snapshotOptions.DiffFile != "" {
+		return shared.WriteJson(m.snapshotOptions.DiffFile, diffs)
+	}
+	return nil
+}
+
+// baseSnapshot is the snapshot the scan is compared to, nil when the project has no previous snapshot or
+// the base file is of another project
+func (m *ComputeInstanceProcessor) baseSnapshot(projectId string) (*Snapshot, error) {
+	if m.snapshotOptions.BaseFile != "" {
+		base, err := ReadSnapshot(m.snapshotOptions.BaseFile)
+		if err != nil {
+			return nil, err
+		}
+		if base.ProjectId != projectId {
+			log.Printf("snapshot %s is of project %s, project %s is not diffed", m.snapshotOptions.BaseFile, base.ProjectId, projectId)
+			return nil, nil
+		}
+		return base, nil
+	}
+	if m.snapshotOptions.Dir == "" {
+		return nil, nil
+	}
+	paths, err := snapshotPaths(m.snapshotOptions.Dir, projectId)
+	if err != nil || len(paths) == 0 {
+		return nil, err
+	}
+	return ReadSnapshot(paths[len(paths)-1])
+}
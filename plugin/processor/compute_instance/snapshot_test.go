@@ -0,0 +1,137 @@
+package compute_instance
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+)
+
+// snapshotRecord is an evaluated instance of a snapshot, recommended to move to machineType with the
+// given savings, or not evaluated when machineType is empty
+func snapshotRecord(id, machineType string, cost, savings float64) ComputeInstanceRecord {
+	r := ComputeInstanceRecord{Id: id, Name: "vm-" + id, Zone: "us-central1-a", Current: protoRecord(nil), Recommended: protoRecord(nil)}
+	if machineType == "" {
+		return r
+	}
+	r.Current = protoRecord(&golang2.RightsizingGcpComputeInstance{MachineType: "n2-standard-8"})
+	r.Recommended = protoRecord(&golang2.RightsizingGcpComputeInstance{MachineType: machineType})
+	r.Cost = CostRecord{Current: cost, Savings: savings}
+	return r
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	base := &Snapshot{ProjectId: "p", CreatedAt: time.Unix(0, 0), Items: []ComputeInstanceRecord{
+		snapshotRecord("resolved", "n2-standard-4", 200, 100),
+		snapshotRecord("changed", "n2-standard-4", 200, 100),
+		snapshotRecord("unchanged", "n2-standard-4", 200, 100),
+		snapshotRecord("deleted", "n2-standard-4", 200, 100),
+		snapshotRecord("skipped", "n2-standard-4", 200, 100),
+		snapshotRecord("was-skipped", "", 0, 0),
+	}}
+	current := &Snapshot{ProjectId: "p", CreatedAt: time.Unix(3600, 0), Items: []ComputeInstanceRecord{
+		snapshotRecord("resolved", "n2-standard-8", 120, 0),
+		snapshotRecord("changed", "n2-standard-2", 200, 150),
+		snapshotRecord("unchanged", "n2-standard-4", 200, 100),
+		snapshotRecord("skipped", "", 0, 0),
+		snapshotRecord("was-skipped", "n2-standard-4", 200, 100),
+		snapshotRecord("new", "n2-standard-4", 300, 50),
+	}}
+
+	diff := DiffSnapshots(base, current)
+
+	ids := func(changes []SnapshotChange) []string {
+		var ids []string
+		for _, c := range changes {
+			ids = append(ids, c.Id)
+		}
+		return ids
+	}
+	tests := []struct {
+		name    string
+		changes []SnapshotChange
+		want    []string
+	}{
+		{name: "new waste", changes: diff.NewWaste, want: []string{"new", "was-skipped"}},
+		{name: "resolved", changes: diff.Resolved, want: []string{"deleted", "resolved"}},
+		{name: "changed", changes: diff.Changed, want: []string{"changed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ids(tt.changes)
+			if len(got) != len(tt.want) {
+				t.Fatalf("%v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("%v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	if diff.NewWasteSavings != 150 {
+		t.Errorf("new waste savings %.2f, want 150", diff.NewWasteSavings)
+	}
+	// the resolved instance saved 80, the deleted one all of its 200
+	if diff.RealizedSavings != 280 {
+		t.Errorf("realized savings %.2f, want 280", diff.RealizedSavings)
+	}
+}
+
+func writeSnapshot(t *testing.T, dir, name string, snapshot Snapshot) string {
+	snapshot.Version = SnapshotVersion
+	content, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	err = os.WriteFile(path, content, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiffSnapshotFiles(t *testing.T) {
+	dir := t.TempDir()
+	base := writeSnapshot(t, dir, "base.json", Snapshot{ProjectId: "p", Items: []ComputeInstanceRecord{
+		snapshotRecord("1", "n2-standard-4", 200, 100),
+	}})
+	current := writeSnapshot(t, dir, "current.json", Snapshot{ProjectId: "p", Items: []ComputeInstanceRecord{
+		snapshotRecord("1", "n2-standard-8", 100, 0),
+	}})
+	other := writeSnapshot(t, dir, "other.json", Snapshot{ProjectId: "other"})
+
+	diff, err := DiffSnapshotFiles(base, current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Resolved) != 1 || diff.RealizedSavings != 100 {
+		t.Errorf("unexpected diff %+v", diff)
+	}
+
+	_, err = DiffSnapshotFiles(base, other)
+	if err == nil {
+		t.Error("snapshots of different projects are diffed")
+	}
+}
+
+func TestBaseSnapshotProject(t *testing.T) {
+	dir := t.TempDir()
+	m := &ComputeInstanceProcessor{snapshotOptions: SnapshotOptions{
+		BaseFile: writeSnapshot(t, dir, "base.json", Snapshot{ProjectId: "p"}),
+	}}
+
+	base, err := m.baseSnapshot("p")
+	if err != nil || base == nil {
+		t.Fatalf("base snapshot of its project not found: %v", err)
+	}
+	base, err = m.baseSnapshot("other")
+	if err != nil || base != nil {
+		t.Errorf("base snapshot used for another project: %v", err)
+	}
+}
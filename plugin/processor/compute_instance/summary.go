@@ -1,6 +1,160 @@
 package compute_instance
 
+import (
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	"github.com/kaytu-io/kaytu/pkg/style"
+	"github.com/kaytu-io/kaytu/pkg/utils"
+	"sort"
+	"strings"
+)
+
+const (
+	RecommendationTypeRightsizing = "Rightsizing"
+	RecommendationTypeDisk        = "Disk"
+	RecommendationTypeIdle        = "Idle"
+	RecommendationTypeNone        = "None"
+)
+
+// summaryBreakdownSize is the number of groups of a breakdown shown in the interactive summary
+const summaryBreakdownSize = 5
+
 type ComputeInstanceSummary struct {
 	CurrentRuntimeCost float64
 	Savings            float64
+	// CommitmentSavings is the part of Savings that only frees commitment capacity
+	CommitmentSavings float64
+
+	ProjectId     string
+	Region        string
+	MachineFamily string
+	// Label is the value of the summary label of the instance
+	Label string
+	// InstanceSavings and DiskSavings split Savings, the instance savings are of type idle and the whole
+	// instance cost when the instance was not active in its activity metrics
+	InstanceSavings float64
+	DiskSavings     float64
+	Idle            bool
+}
+
+// SummaryBreakdown is the current cost and savings of a group of instances
+type SummaryBreakdown struct {
+	Name        string
+	Instances   int
+	CurrentCost float64
+	Savings     float64
+}
+
+type summaryBreakdowns struct {
+	Title  string
+	Groups []SummaryBreakdown
+}
+
+// breakdowns returns the savings by recommendation type, project, region, machine family and summary label
+func (m *ComputeInstanceProcessor) breakdowns() []summaryBreakdowns {
+	projects := make(map[string]*SummaryBreakdown)
+	regions := make(map[string]*SummaryBreakdown)
+	families := make(map[string]*SummaryBreakdown)
+	labels := make(map[string]*SummaryBreakdown)
+	types := make(map[string]*SummaryBreakdown)
+
+	add := func(groups map[string]*SummaryBreakdown, name string, currentCost, savings float64) {
+		if name == "" {
+			name = "N/A"
+		}
+		g, ok := groups[name]
+		if !ok {
+			g = &SummaryBreakdown{Name: name}
+			groups[name] = g
+		}
+		g.Instances++
+		g.CurrentCost += currentCost
+		g.Savings += savings
+	}
+
+	m.summary.Range(func(_ string, item ComputeInstanceSummary) bool {
+		add(projects, item.ProjectId, item.CurrentRuntimeCost, item.Savings)
+		add(regions, item.Region, item.CurrentRuntimeCost, item.Savings)
+		add(families, item.MachineFamily, item.CurrentRuntimeCost, item.Savings)
+		add(labels, item.Label, item.CurrentRuntimeCost, item.Savings)
+
+		instanceType := RecommendationTypeRightsizing
+		if item.Idle {
+			instanceType = RecommendationTypeIdle
+		}
+		if item.InstanceSavings != 0 {
+			add(types, instanceType, 0, item.InstanceSavings)
+		}
+		if item.DiskSavings != 0 {
+			add(types, RecommendationTypeDisk, 0, item.DiskSavings)
+		}
+		if item.InstanceSavings == 0 && item.DiskSavings == 0 {
+			add(types, RecommendationTypeNone, 0, 0)
+		}
+		return true
+	})
+
+	breakdowns := []summaryBreakdowns{
+		{Title: "Recommendation Type", Groups: sortedBreakdowns(types)},
+		{Title: "Project", Groups: sortedBreakdowns(projects)},
+		{Title: "Region", Groups: sortedBreakdowns(regions)},
+		{Title: "Machine Family", Groups: sortedBreakdowns(families)},
+	}
+	if m.summaryLabel != "" {
+		breakdowns = append(breakdowns, summaryBreakdowns{Title: fmt.Sprintf("Label %s", m.summaryLabel), Groups: sortedBreakdowns(labels)})
+	}
+	return breakdowns
+}
+
+func sortedBreakdowns(groups map[string]*SummaryBreakdown) []SummaryBreakdown {
+	var sorted []SummaryBreakdown
+	for _, g := range groups {
+		sorted = append(sorted, *g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Savings != sorted[j].Savings {
+			return sorted[i].Savings > sorted[j].Savings
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// breakdownsMessage renders the largest groups of every breakdown, one breakdown per line
+func (m *ComputeInstanceProcessor) breakdownsMessage() string {
+	var lines []string
+	for _, b := range m.breakdowns() {
+		if len(b.Groups) == 0 {
+			continue
+		}
+		var groups []string
+		for i, g := range b.Groups {
+			if i == summaryBreakdownSize {
+				groups = append(groups, fmt.Sprintf("%d more", len(b.Groups)-summaryBreakdownSize))
+				break
+			}
+			groups = append(groups, fmt.Sprintf("%s %s", g.Name, style.SavingStyle.Render(utils.FormatPriceFloat(g.Savings))))
+		}
+		lines = append(lines, fmt.Sprintf("Savings by %s: %s", strings.ToLower(b.Title), strings.Join(groups, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *ComputeInstanceProcessor) exportSummaryCsv() []*golang.CSVRow {
+	rows := []*golang.CSVRow{
+		{Row: []string{"Breakdown", "Group", "Instances", "Current Cost", "Savings"}},
+	}
+	for _, b := range m.breakdowns() {
+		for _, g := range b.Groups {
+			currentCost := utils.FormatPriceFloat(g.CurrentCost)
+			if b.Title == "Recommendation Type" {
+				// costs are not split by recommendation type
+				currentCost = ""
+			}
+			rows = append(rows, &golang.CSVRow{Row: []string{
+				b.Title, g.Name, fmt.Sprintf("%d", g.Instances), currentCost, utils.FormatPriceFloat(g.Savings),
+			}})
+		}
+	}
+	return rows
 }
@@ -0,0 +1,70 @@
+package compute_instance
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	"github.com/kaytu-io/kaytu/pkg/utils"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+)
+
+func TestUpdateSummary(t *testing.T) {
+	instance := func(cost float64) *golang2.RightsizingGcpComputeInstance {
+		return &golang2.RightsizingGcpComputeInstance{MachineType: "n2-standard-4", Region: "us-central1", Cpu: 4, MemoryMb: 16384, Cost: cost}
+	}
+	// effective is the cost of the instance after its discounts
+	effective := func(listCost float64) float64 {
+		current, _ := ComputeInstanceItem{Wastage: &golang2.GCPComputeOptimizationResponse{
+			Rightsizing: &golang2.GcpComputeInstanceRightsizingRecommendation{Current: instance(listCost)},
+		}}.InstanceCosts()
+		return current.Cost()
+	}
+	m := &ComputeInstanceProcessor{
+		items:                utils.NewConcurrentMap[string, ComputeInstanceItem](),
+		publishResultSummary: func(*golang.ResultSummary) {},
+	}
+	for _, item := range []ComputeInstanceItem{
+		{Id: "rightsized", ProjectId: "p", Wastage: &golang2.GCPComputeOptimizationResponse{
+			Rightsizing: &golang2.GcpComputeInstanceRightsizingRecommendation{Current: instance(100), Recommended: instance(60)},
+		}},
+		// an idle instance saves its whole cost whatever the rightsizing
+		{Id: "idle", ProjectId: "p", Idle: true, Wastage: &golang2.GCPComputeOptimizationResponse{
+			Rightsizing: &golang2.GcpComputeInstanceRightsizingRecommendation{Current: instance(100), Recommended: instance(80)},
+		}},
+		{Id: "no recommendation", ProjectId: "p", Wastage: &golang2.GCPComputeOptimizationResponse{
+			Rightsizing: &golang2.GcpComputeInstanceRightsizingRecommendation{Current: instance(50)},
+		}},
+		{Id: "not optimized yet", ProjectId: "p"},
+	} {
+		m.items.Set(item.Id, item)
+		m.UpdateSummary(item.Id)
+	}
+
+	want := map[string]SummaryBreakdown{
+		RecommendationTypeRightsizing: {Instances: 1, Savings: effective(100) - effective(60)},
+		RecommendationTypeIdle:        {Instances: 1, Savings: effective(100)},
+		RecommendationTypeNone:        {Instances: 2},
+		"p":                           {Instances: 4, CurrentCost: 2*effective(100) + effective(50), Savings: 2*effective(100) - effective(60)},
+	}
+	got := 0
+	for _, b := range m.breakdowns() {
+		if b.Title != "Recommendation Type" && b.Title != "Project" {
+			continue
+		}
+		for _, g := range b.Groups {
+			w, ok := want[g.Name]
+			if !ok {
+				t.Errorf("unexpected %s group %s", b.Title, g.Name)
+				continue
+			}
+			got++
+			if g.Instances != w.Instances || math.Abs(g.CurrentCost-w.CurrentCost) > 1e-9 || math.Abs(g.Savings-w.Savings) > 1e-9 {
+				t.Errorf("%s %s: %+v, want %+v", b.Title, g.Name, g, w)
+			}
+		}
+	}
+	if got != len(want) {
+		t.Errorf("%d groups, want %d", got, len(want))
+	}
+}
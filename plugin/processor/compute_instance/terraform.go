@@ -0,0 +1,143 @@
+package compute_instance
+
+import (
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// terraformAttribute is an attribute to set, the path holds the nested blocks it is in
+type terraformAttribute struct {
+	Path    []string
+	Value   cty.Value
+	Comment string
+	// BootDiskComment replaces the comment when the attribute of a boot disk is set in the boot_disk block of
+	// its instance
+	BootDiskComment string
+}
+
+// terraformChange is the change a recommendation makes to a terraform resource
+type terraformChange struct {
+	ResourceType string
+	Name         string
+	Zone         string
+	Project      string
+	Comments     []string
+	Attributes   []terraformAttribute
+	// Guidance are the changes left out because they force the resource to be recreated, written as comments
+	// in the resource block
+	Guidance []string
+	// BootDiskOf is the instance of a boot disk, which may be created by the instance's boot_disk block
+	BootDiskOf string
+}
+
+// stringAttribute returns the value of a string literal attribute of the body
+func stringAttribute(body *hclwrite.Body, name string) (string, bool) {
+	attribute := body.GetAttribute(name)
+	if attribute == nil {
+		return "", false
+	}
+	tokens := attribute.Expr().BuildTokens(nil)
+	if len(tokens) != 3 || tokens[0].Type != hclsyntax.TokenOQuote || tokens[1].Type != hclsyntax.TokenQuotedLit ||
+		tokens[2].Type != hclsyntax.TokenCQuote {
+		return "", false
+	}
+	return string(tokens[1].Bytes), true
+}
+
+// matches tells whether a resource block describes the resource of the change, matched by the
+// name attribute (or the block label when the name is not a literal) and the zone and project when set
+func (c terraformChange) matches(block *hclwrite.Block) bool {
+	labels := block.Labels()
+	if block.Type() != "resource" || len(labels) != 2 || labels[0] != c.ResourceType {
+		return false
+	}
+	name, ok := stringAttribute(block.Body(), "name")
+	if !ok {
+		name = labels[1]
+	}
+	if name != c.Name {
+		return false
+	}
+	if zone, ok := stringAttribute(block.Body(), "zone"); ok && zone != c.Zone {
+		return false
+	}
+	if project, ok := stringAttribute(block.Body(), "project"); ok && c.Project != "" && project != c.Project {
+		return false
+	}
+	return true
+}
+
+// apply sets the attributes of the change in the resource block, adding the nested blocks they are in
+// when they are missing, and writes the guidance as comments at the end of the block
+func (c terraformChange) apply(block *hclwrite.Block) {
+	for _, a := range c.Attributes {
+		body := block.Body()
+		for _, name := range a.Path[:len(a.Path)-1] {
+			child := body.FirstMatchingBlock(name, nil)
+			if child == nil {
+				child = body.AppendNewBlock(name, nil)
+			}
+			body = child.Body()
+		}
+		tokens := hclwrite.TokensForValue(a.Value)
+		tokens = append(tokens, commentTokens(a.Comment, false)...)
+		body.SetAttributeRaw(a.Path[len(a.Path)-1], tokens)
+	}
+	for _, guidance := range c.Guidance {
+		block.Body().AppendUnstructuredTokens(commentTokens(guidance, true))
+	}
+}
+
+func commentTokens(comment string, line bool) hclwrite.Tokens {
+	if comment == "" {
+		return nil
+	}
+	if line {
+		return hclwrite.Tokens{{Type: hclsyntax.TokenComment, Bytes: []byte("# " + comment + "\n")}}
+	}
+	return hclwrite.Tokens{{Type: hclsyntax.TokenComment, Bytes: []byte("# " + comment), SpacesBefore: 1}}
+}
+
+// snippet renders the change as a standalone terraform resource with only the changed attributes
+func (c terraformChange) snippet() string {
+	f := hclwrite.NewEmptyFile()
+	for _, comment := range c.Comments {
+		f.Body().AppendUnstructuredTokens(commentTokens(comment, true))
+	}
+	block := f.Body().AppendNewBlock("resource", []string{c.ResourceType, c.Name})
+	block.Body().SetAttributeValue("name", cty.StringVal(c.Name))
+	c.apply(block)
+	return string(hclwrite.Format(f.Bytes()))
+}
+
+// bootDiskChange is the change of a boot disk created inline by the boot_disk block of its instance
+func (c terraformChange) bootDiskChange(instance terraformChange) terraformChange {
+	bootDisk := instance
+	bootDisk.Comments = c.Comments
+	bootDisk.Guidance = c.Guidance
+	bootDisk.Attributes = nil
+	for _, a := range c.Attributes {
+		comment := a.Comment
+		if a.BootDiskComment != "" {
+			comment = a.BootDiskComment
+		}
+		bootDisk.Attributes = append(bootDisk.Attributes, terraformAttribute{
+			Path:    append([]string{"boot_disk", "initialize_params"}, a.Path...),
+			Value:   a.Value,
+			Comment: comment,
+		})
+	}
+	return bootDisk
+}
+
+// resourceBlocks returns the resource blocks of the file
+func resourceBlocks(f *hclwrite.File) []*hclwrite.Block {
+	var blocks []*hclwrite.Block
+	for _, block := range f.Body().Blocks() {
+		if block.Type() == "resource" {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
@@ -0,0 +1,161 @@
+package compute_instance
+
+import (
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// terraformItem is the instance web-1 of the terraform fixtures with its boot disk and the data-1 disk
+func terraformItem(machineType string, preemptible bool, boot, data *golang2.RightsizingGcpComputeDisk) ComputeInstanceItem {
+	zone := "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a"
+	item := ComputeInstanceItem{
+		ProjectId: "p",
+		Name:      "web-1",
+		Id:        "1",
+		Region:    "us-central1-a",
+		Instance: &computepb.Instance{Disks: []*computepb.AttachedDisk{
+			{Boot: proto.Bool(true), Source: proto.String(zone + "/disks/web-1")},
+			{Boot: proto.Bool(false), Source: proto.String(zone + "/disks/data-1")},
+		}},
+		Disks: []compute.Disk{{Id: 10, Name: "web-1", Zone: zone}, {Id: 11, Name: "data-1", Zone: zone}},
+		Wastage: &golang2.GCPComputeOptimizationResponse{
+			Rightsizing: &golang2.GcpComputeInstanceRightsizingRecommendation{
+				Current:     &golang2.RightsizingGcpComputeInstance{MachineType: "n2-standard-8", Cost: 200},
+				Recommended: &golang2.RightsizingGcpComputeInstance{MachineType: machineType, Preemptible: preemptible, Cost: 100},
+			},
+			VolumesRightsizing: map[string]*golang2.GcpComputeDiskRecommendation{
+				"10": {Current: &golang2.RightsizingGcpComputeDisk{DiskType: "pd-balanced", DiskSize: 100}, Recommended: boot},
+				"11": {Current: &golang2.RightsizingGcpComputeDisk{DiskType: "pd-ssd", DiskSize: 500}, Recommended: data},
+			},
+		},
+	}
+	return item
+}
+
+func TestTerraformPatch(t *testing.T) {
+	unchangedBoot := &golang2.RightsizingGcpComputeDisk{DiskType: "pd-balanced", DiskSize: 100}
+	unchangedData := &golang2.RightsizingGcpComputeDisk{DiskType: "pd-ssd", DiskSize: 500}
+
+	tests := []struct {
+		name          string
+		item          ComputeInstanceItem
+		allowRecreate bool
+		contains      []string
+		excludes      []string
+		unmatched     int
+	}{
+		{
+			name: "machine type",
+			item: terraformItem("n2-standard-4", false, unchangedBoot, unchangedData),
+			contains: []string{
+				"--- a/main.tf\n+++ b/main.tf\n",
+				`-  machine_type = "n2-standard-8"`,
+				`+  machine_type = "n2-standard-4" # was n2-standard-8`,
+				"+  allow_stopping_for_update = true # the instance is stopped to change its machine type",
+			},
+			excludes: []string{"disks.tf", "echo"},
+		},
+		{
+			name:     "preemptible without recreate",
+			item:     terraformItem("n2-standard-8", true, unchangedBoot, unchangedData),
+			contains: []string{"+  # recommended: scheduling preemptible = true"},
+			excludes: []string{"+  scheduling {"},
+		},
+		{
+			name:          "preemptible with recreate",
+			item:          terraformItem("n2-standard-8", true, unchangedBoot, unchangedData),
+			allowRecreate: true,
+			contains: []string{
+				"+  scheduling {",
+				"+    preemptible         = true # forces the instance to be recreated",
+				`+    on_host_maintenance = "TERMINATE"`,
+			},
+		},
+		{
+			name: "disk type and shrink without recreate",
+			item: terraformItem("n2-standard-8", false, unchangedBoot,
+				&golang2.RightsizingGcpComputeDisk{DiskType: "pd-balanced", DiskSize: 200}),
+			contains: []string{
+				"--- a/disks.tf\n+++ b/disks.tf\n",
+				`+  # recommended: type = "pd-balanced" (was pd-ssd), not set as it forces the disk to be recreated`,
+				"+  # recommended: size = 200 (was 500)",
+			},
+			excludes: []string{`+  type = "pd-balanced"`, "+  size = 200", "other-project"},
+		},
+		{
+			name: "disk type with recreate",
+			item: terraformItem("n2-standard-8", false, unchangedBoot,
+				&golang2.RightsizingGcpComputeDisk{DiskType: "pd-balanced", DiskSize: 500}),
+			allowRecreate: true,
+			contains:      []string{`+  type = "pd-balanced" # was pd-ssd, forces the disk to be recreated`},
+		},
+		{
+			name: "inline boot disk grows",
+			item: terraformItem("n2-standard-8", false,
+				&golang2.RightsizingGcpComputeDisk{DiskType: "pd-balanced", DiskSize: 200}, unchangedData),
+			contains: []string{"-      size  = 100", "+      size  = 200 # was 100"},
+		},
+		{
+			name: "inline boot disk type with recreate",
+			item: terraformItem("n2-standard-8", false,
+				&golang2.RightsizingGcpComputeDisk{DiskType: "pd-standard", DiskSize: 100}, unchangedData),
+			allowRecreate: true,
+			contains:      []string{`+      type  = "pd-standard" # was pd-balanced, forces the instance to be recreated`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, unmatched, err := terraformPatch("testdata/terraform", tt.item.TerraformChanges(tt.allowRecreate))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(patch, s) {
+					t.Errorf("patch does not contain %q:\n%s", s, patch)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(patch, s) {
+					t.Errorf("patch contains %q:\n%s", s, patch)
+				}
+			}
+			if len(unmatched) != tt.unmatched {
+				t.Errorf("%d unmatched changes, want %d", len(unmatched), tt.unmatched)
+			}
+		})
+	}
+}
+
+func TestTerraformPatchUnmatched(t *testing.T) {
+	item := terraformItem("n2-standard-4", false, nil, nil)
+	item.Name = "api-1"
+	item.Instance = nil
+
+	patch, unmatched, err := terraformPatch("testdata/terraform", item.TerraformChanges(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch != "" {
+		t.Errorf("unexpected patch:\n%s", patch)
+	}
+	if len(unmatched) != 1 {
+		t.Fatalf("%d unmatched changes, want 1", len(unmatched))
+	}
+
+	snippet := unmatched[0].snippet()
+	for _, s := range []string{
+		"# api-1 (p/us-central1-a): n2-standard-8 -> n2-standard-4",
+		`resource "google_compute_instance" "api-1" {`,
+		`  machine_type              = "n2-standard-4" # was n2-standard-8`,
+	} {
+		if !strings.Contains(snippet, s) {
+			t.Errorf("snippet does not contain %q:\n%s", s, snippet)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+resource "google_compute_disk" "data" {
+  name = "data-1"
+  zone = "us-central1-a"
+  type = "pd-ssd"
+  size = 500
+}
+
+// a disk of another project
+resource "google_compute_disk" "other" {
+  name    = "data-1"
+  zone    = "us-central1-a"
+  project = "other-project"
+  type    = "pd-ssd"
+  size    = 50
+}
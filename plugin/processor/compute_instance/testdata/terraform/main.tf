@@ -0,0 +1,39 @@
+variable "zone" {
+  default = "us-central1-a"
+}
+
+resource "google_compute_instance" "web" {
+  name         = "web-1" # the frontend
+  zone         = "us-central1-a"
+  machine_type = "n2-standard-8"
+
+  boot_disk {
+    initialize_params {
+      image = "debian-cloud/debian-12"
+      size  = 100
+    }
+  }
+
+  network_interface {
+    network = "default"
+  }
+
+  metadata_startup_script = <<-EOT
+    #!/bin/bash
+    echo "machine_type = { not an attribute }"
+  EOT
+
+  labels = {
+    team = "web"
+  }
+}
+
+resource "google_compute_instance" "worker" {
+  name         = "worker-${var.zone}"
+  zone         = var.zone
+  machine_type = "e2-standard-4"
+
+  boot_disk {
+    source = google_compute_disk.worker_boot.id
+  }
+}
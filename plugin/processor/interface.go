@@ -6,3 +6,8 @@ type PluginProcessor interface {
 	ReEvaluate(id string, items []*golang.PreferenceItem)
 	ExportNonInteractive() *golang.NonInteractiveExport
 }
+
+// FileExporter is implemented by processors writing their results to local files at the end of the run
+type FileExporter interface {
+	ExportFiles() error
+}
@@ -0,0 +1,125 @@
+package load_balancer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kaytu-io/kaytu/preferences"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	monthHours   = 730
+	monthSeconds = monthHours * 60 * 60
+	gb           = 1024 * 1024 * 1024
+)
+
+// Load balancing list prices from https://cloud.google.com/vpc/network-pricing#lb. The first five forwarding
+// rules of a project in a region are billed together at the same hourly rate, a single rule is priced at that
+// rate. The data processed is the inbound data of the load balancer.
+const (
+	forwardingRulePrice = 0.025 // per hour
+	dataProcessingPrice = 0.008 // per GB
+)
+
+// backendTargetTypes are the targets of load balancers whose backends are known, forwarding rules with other
+// targets (e.g. private service connect or vpn gateways) are only found idle from their traffic
+var backendTargetTypes = map[string]bool{
+	"backendServices":    true,
+	"targetHttpProxies":  true,
+	"targetHttpsProxies": true,
+	"targetTcpProxies":   true,
+	"targetSslProxies":   true,
+	"targetPools":        true,
+	"targetInstances":    true,
+}
+
+func usage(dps []*golang2.DataPoint) *golang2.Usage {
+	if len(dps) == 0 {
+		return nil
+	}
+	var sum float64
+	maxValue := dps[0].GetValue()
+	for _, dp := range dps {
+		sum += dp.GetValue()
+		maxValue = max(maxValue, dp.GetValue())
+	}
+	return &golang2.Usage{
+		Avg: wrapperspb.Double(sum / float64(len(dps))),
+		Max: wrapperspb.Double(maxValue),
+	}
+}
+
+// loadBalancerCost is the monthly cost of the forwarding rule and of the data it processes, the ingress is in
+// bytes per second
+func loadBalancerCost(region string, ingress float64) *golang2.RightsizingGcpLoadBalancer {
+	lb := &golang2.RightsizingGcpLoadBalancer{
+		Region:             region,
+		ForwardingRuleCost: forwardingRulePrice * monthHours,
+		DataProcessingCost: ingress * monthSeconds / gb * dataProcessingPrice,
+	}
+	lb.Cost = lb.ForwardingRuleCost + lb.DataProcessingCost
+	return lb
+}
+
+// idleReason returns why the load balancer is idle, or an empty string when it is not. It is idle when its
+// request rate never went above the threshold in the last week, or when it has no backends or none of them
+// is healthy. Backends whose health is unknown are not considered unhealthy.
+func idleReason(item LoadBalancerItem, requests *golang2.Usage, prefs map[string]*string) string {
+	threshold := 0.0
+	if v := prefs["IdleRequestRateThreshold"]; v != nil {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(*v), 64); err == nil {
+			threshold = f
+		}
+	}
+	if requests != nil && requests.GetMax().GetValue() <= threshold {
+		if threshold == 0 {
+			return "no requests in the last 7 days"
+		}
+		return fmt.Sprintf("at most %.2f requests per second in the last 7 days", requests.GetMax().GetValue())
+	}
+
+	if v := prefs["ConsiderNoHealthyBackendsIdle"]; v != nil && *v != "Yes" {
+		return ""
+	}
+	if !backendTargetTypes[item.TargetType] || item.HealthUnknown || len(item.BackendBuckets) > 0 {
+		return ""
+	}
+	switch {
+	case item.Endpoints == 0:
+		return "no backends"
+	case item.HealthyEndpoints == 0:
+		return "no healthy backends"
+	}
+	return ""
+}
+
+// recommendLoadBalancer decides whether the load balancer is idle, an idle load balancer is recommended to be
+// deleted and costs nothing anymore
+func recommendLoadBalancer(item LoadBalancerItem) *golang2.GcpLoadBalancerRecommendation {
+	prefs := preferences.Export(item.Preferences)
+
+	recommendation := &golang2.GcpLoadBalancerRecommendation{
+		RequestCount: usage(item.Metrics["RequestCount"]),
+		IngressBytes: usage(item.Metrics["IngressBytes"]),
+		EgressBytes:  usage(item.Metrics["EgressBytes"]),
+	}
+	recommendation.Current = loadBalancerCost(item.Region, recommendation.IngressBytes.GetAvg().GetValue())
+
+	reason := idleReason(item, recommendation.RequestCount, prefs)
+	if reason == "" {
+		recommendation.Description = "The load balancer is serving traffic."
+		if recommendation.RequestCount == nil {
+			recommendation.Description = "No traffic was reported for the load balancer, it is kept until its backends show it is unused."
+		}
+		return recommendation
+	}
+
+	recommendation.Idle = true
+	recommendation.IdleReason = reason
+	recommendation.Recommended = &golang2.RightsizingGcpLoadBalancer{Region: item.Region}
+	recommendation.Description = fmt.Sprintf("The load balancer is idle, it had %s. Delete the forwarding rule.", reason)
+	return recommendation
+}
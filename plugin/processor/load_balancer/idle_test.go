@@ -0,0 +1,71 @@
+package load_balancer
+
+import (
+	"testing"
+
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func testLoadBalancer(targetType string, requests float64, endpoints, healthy int32, prefs ...*golang.PreferenceItem) LoadBalancerItem {
+	return LoadBalancerItem{
+		Region:           "global",
+		TargetType:       targetType,
+		Endpoints:        endpoints,
+		HealthyEndpoints: healthy,
+		Preferences:      prefs,
+		Metrics: map[string][]*golang2.DataPoint{
+			"RequestCount": {{Value: 0}, {Value: requests}},
+			"IngressBytes": {{Value: 1000}},
+		},
+	}
+}
+
+func TestRecommendLoadBalancer(t *testing.T) {
+	unknown := testLoadBalancer("targetHttpsProxies", 5, 0, 0)
+	unknown.HealthUnknown = true
+	buckets := testLoadBalancer("targetHttpsProxies", 5, 0, 0)
+	buckets.BackendBuckets = []string{"projects/p/global/backendBuckets/static"}
+
+	cases := []struct {
+		name       string
+		item       LoadBalancerItem
+		wantReason string
+	}{
+		{"no requests", testLoadBalancer("targetHttpsProxies", 0, 2, 2), "no requests in the last 7 days"},
+		{"serving traffic", testLoadBalancer("targetHttpsProxies", 5, 2, 2), ""},
+		{"no healthy backends", testLoadBalancer("targetHttpsProxies", 5, 2, 0), "no healthy backends"},
+		{"no backends", testLoadBalancer("backendServices", 5, 0, 0), "no backends"},
+		{"healthy target pool", testLoadBalancer("targetPools", 5, 3, 1), ""},
+		{"unknown health is not unhealthy", unknown, ""},
+		{"backend buckets have no endpoints", buckets, ""},
+		{"unknown target only idle from traffic", testLoadBalancer("serviceAttachments", 5, 0, 0), ""},
+		{
+			"unhealthy backends not considered idle",
+			testLoadBalancer("targetHttpsProxies", 5, 2, 0,
+				&golang.PreferenceItem{Key: "ConsiderNoHealthyBackendsIdle", Value: wrapperspb.String("No")}),
+			"",
+		},
+		{
+			"request rate under the threshold",
+			testLoadBalancer("targetHttpsProxies", 0.5, 2, 2,
+				&golang.PreferenceItem{Key: "IdleRequestRateThreshold", Value: wrapperspb.String("1")}),
+			"at most 0.50 requests per second in the last 7 days",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := recommendLoadBalancer(c.item)
+			if r.IdleReason != c.wantReason {
+				t.Fatalf("idle reason = %q, want %q", r.IdleReason, c.wantReason)
+			}
+			if r.Idle != (c.wantReason != "") || (r.Recommended != nil) != r.Idle {
+				t.Errorf("idle = %v, recommended = %v", r.Idle, r.Recommended)
+			}
+			if r.Idle && r.Recommended.Cost != 0 {
+				t.Errorf("deleted load balancer costs %v", r.Recommended.Cost)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package load_balancer
+
+import (
+	"context"
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/api/compute/v1"
+	"log"
+	"time"
+
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type GetLoadBalancerMetricsJob struct {
+	processor *LoadBalancerProcessor
+	itemId    string
+}
+
+func NewGetLoadBalancerMetricsJob(processor *LoadBalancerProcessor, itemId string) *GetLoadBalancerMetricsJob {
+	return &GetLoadBalancerMetricsJob{
+		processor: processor,
+		itemId:    itemId,
+	}
+}
+
+func (job *GetLoadBalancerMetricsJob) Properties() sdk.JobProperties {
+	return sdk.JobProperties{
+		ID:          fmt.Sprintf("get_load_balancer_metrics_%s", job.itemId),
+		Description: fmt.Sprintf("Get metrics for load balancer: %s", job.itemId),
+		MaxRetry:    0,
+	}
+}
+
+func (job *GetLoadBalancerMetricsJob) Run(ctx context.Context) error {
+	item, ok := job.processor.items.Get(job.itemId)
+	if !ok {
+		return fmt.Errorf("item not found %s", job.itemId)
+	}
+
+	// the health of backends that can not be read (e.g. serverless NEGs have no health checks) is unknown,
+	// they are neither healthy nor unhealthy
+	backendHealth := make(map[string]BackendHealth)
+	var endpoints, healthyEndpoints int32
+	var healthUnknown bool
+	addHealth := func(link string, health BackendHealth) {
+		backendHealth[link] = health
+		endpoints += health.Endpoints
+		healthyEndpoints += health.HealthyEndpoints
+		healthUnknown = healthUnknown || health.Unknown
+	}
+	countHealth := func(health *BackendHealth, statuses []*compute.HealthStatus) {
+		for _, status := range statuses {
+			health.Endpoints++
+			if status.HealthState == "HEALTHY" {
+				health.HealthyEndpoints++
+			}
+		}
+	}
+	for _, bs := range item.BackendServices {
+		var health BackendHealth
+		for _, backend := range bs.Backends {
+			statuses, err := job.processor.provider.GetBackendHealth(ctx, bs, backend.Group)
+			if err != nil {
+				log.Printf("failed to get health of backend %s: %v", backend.Group, err)
+				health.Unknown = true
+				continue
+			}
+			countHealth(&health, statuses)
+		}
+		addHealth(bs.SelfLink, health)
+	}
+	if pool := item.TargetPool; pool != nil {
+		var health BackendHealth
+		for _, instance := range pool.Instances {
+			if len(pool.HealthChecks) == 0 {
+				// pools without health checks send traffic to all their instances
+				health.Endpoints++
+				health.HealthyEndpoints++
+				continue
+			}
+			statuses, err := job.processor.provider.GetTargetPoolHealth(ctx, pool, instance)
+			if err != nil {
+				log.Printf("failed to get health of instance %s: %v", instance, err)
+				health.Unknown = true
+				continue
+			}
+			countHealth(&health, statuses)
+		}
+		addHealth(pool.SelfLink, health)
+	}
+	if ti := item.TargetInstance; ti != nil {
+		// target instances have no health checks, the instance receives the traffic while it runs
+		health := BackendHealth{Endpoints: 1}
+		status, err := job.processor.provider.GetInstanceStatus(ctx, ti.Instance)
+		if err != nil {
+			log.Printf("failed to get status of instance %s: %v", ti.Instance, err)
+			health.Unknown = true
+		} else if status == "RUNNING" {
+			health.HealthyEndpoints = 1
+		}
+		addHealth(ti.SelfLink, health)
+	}
+
+	endTime := time.Now()                         // end time of requested time series
+	startTime := endTime.Add(-24 * 7 * time.Hour) // an idle load balancer should be idle for a whole week
+
+	requestMetric, ingressMetric, egressMetric := trafficMetricTypes(item)
+
+	newRequest := func(metricType string) *monitoringpb.ListTimeSeriesRequest {
+		return job.processor.metricProvider.NewTimeSeriesRequest(
+			fmt.Sprintf(
+				`metric.type="%s" AND resource.labels.forwarding_rule_name="%s" AND resource.labels.region="%s" AND resource.labels.project_id="%s"`,
+				metricType,
+				item.Name,
+				item.Region,
+				item.ProjectId,
+			),
+			&monitoringpb.TimeInterval{
+				EndTime:   timestamppb.New(endTime),
+				StartTime: timestamppb.New(startTime),
+			},
+			&monitoringpb.Aggregation{
+				AlignmentPeriod: &durationpb.Duration{
+					Seconds: 60 * 60,
+				},
+				PerSeriesAligner:   monitoringpb.Aggregation_ALIGN_RATE, // rate per second in the above period
+				CrossSeriesReducer: monitoringpb.Aggregation_REDUCE_SUM, // sum up all backends and response codes
+			},
+		)
+	}
+
+	requestCountMetric, err := job.processor.metricProvider.GetMetric(ctx, newRequest(requestMetric))
+	if err != nil {
+		return err
+	}
+
+	ingressBytesMetric, err := job.processor.metricProvider.GetMetric(ctx, newRequest(ingressMetric))
+	if err != nil {
+		return err
+	}
+
+	egressBytesMetric, err := job.processor.metricProvider.GetMetric(ctx, newRequest(egressMetric))
+	if err != nil {
+		return err
+	}
+
+	lbMetrics := make(map[string][]*golang2.DataPoint)
+
+	lbMetrics["RequestCount"] = requestCountMetric
+	lbMetrics["IngressBytes"] = ingressBytesMetric
+	lbMetrics["EgressBytes"] = egressBytesMetric
+
+	item.OptimizationLoading = true
+	item.Skipped = false
+	item.SkipReason = "N/A"
+	item.LazyLoadingEnabled = false
+	item.Metrics = lbMetrics
+	item.BackendHealth = backendHealth
+	item.Endpoints = endpoints
+	item.HealthyEndpoints = healthyEndpoints
+	item.HealthUnknown = healthUnknown
+
+	for k, v := range item.Metrics {
+		log.Printf("%s %s : %d", item.Id, k, len(v))
+	}
+
+	job.processor.items.Set(item.Id, item)
+	job.processor.publishOptimizationItem(item.ToOptimizationItem())
+	job.processor.UpdateSummary(item.Id)
+
+	job.processor.jobQueue.Push(NewOptimizeLoadBalancerJob(job.processor, item.Id))
+
+	return nil
+}
+
+// trafficMetricTypes returns the request (or packet/connection) count, ingress bytes and egress bytes
+// metric types matching the kind of load balancer the forwarding rule belongs to
+func trafficMetricTypes(item LoadBalancerItem) (string, string, string) {
+	switch item.TargetType {
+	case "targetHttpProxies", "targetHttpsProxies":
+		if item.Scheme == "INTERNAL_MANAGED" {
+			return "loadbalancing.googleapis.com/https/internal/request_count",
+				"loadbalancing.googleapis.com/https/internal/request_bytes_count",
+				"loadbalancing.googleapis.com/https/internal/response_bytes_count"
+		}
+		return "loadbalancing.googleapis.com/https/request_count",
+			"loadbalancing.googleapis.com/https/request_bytes_count",
+			"loadbalancing.googleapis.com/https/response_bytes_count"
+	case "targetTcpProxies", "targetSslProxies":
+		return "loadbalancing.googleapis.com/tcp_ssl_proxy/new_connections",
+			"loadbalancing.googleapis.com/tcp_ssl_proxy/ingress_bytes_count",
+			"loadbalancing.googleapis.com/tcp_ssl_proxy/egress_bytes_count"
+	}
+	if item.Scheme == "INTERNAL" {
+		return "loadbalancing.googleapis.com/l3/internal/ingress_packets_count",
+			"loadbalancing.googleapis.com/l3/internal/ingress_bytes_count",
+			"loadbalancing.googleapis.com/l3/internal/egress_bytes_count"
+	}
+	return "loadbalancing.googleapis.com/l3/external/ingress_packets_count",
+		"loadbalancing.googleapis.com/l3/external/ingress_bytes_count",
+		"loadbalancing.googleapis.com/l3/external/egress_bytes_count"
+}
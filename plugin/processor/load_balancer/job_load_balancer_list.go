@@ -0,0 +1,185 @@
+package load_balancer
+
+import (
+	"context"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	"google.golang.org/api/compute/v1"
+	"log"
+	"strconv"
+	"strings"
+
+	util "github.com/opengovern/plugin-gcp/utils"
+)
+
+type ListLoadBalancersJob struct {
+	processor *LoadBalancerProcessor
+}
+
+func NewListLoadBalancersJob(processor *LoadBalancerProcessor) *ListLoadBalancersJob {
+	return &ListLoadBalancersJob{
+		processor: processor,
+	}
+}
+
+func (job *ListLoadBalancersJob) Properties() sdk.JobProperties {
+	return sdk.JobProperties{
+		ID:          "list_load_balancers",
+		Description: "List all load balancer forwarding rules in current project",
+		MaxRetry:    0,
+	}
+}
+
+func (job *ListLoadBalancersJob) Run(ctx context.Context) error {
+	log.Println("Running list load balancers job")
+
+	rules, err := job.processor.provider.GetAllForwardingRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	backendServiceList, err := job.processor.provider.GetAllBackendServices(ctx)
+	if err != nil {
+		return err
+	}
+	backendServices := make(map[string]*compute.BackendService)
+	for _, bs := range backendServiceList {
+		backendServices[bs.SelfLink] = bs
+	}
+
+	urlMapList, err := job.processor.provider.GetAllUrlMaps(ctx)
+	if err != nil {
+		return err
+	}
+	urlMaps := make(map[string]*compute.UrlMap)
+	for _, um := range urlMapList {
+		urlMaps[um.SelfLink] = um
+	}
+
+	proxies, err := job.processor.provider.GetAllTargetProxies(ctx)
+	if err != nil {
+		return err
+	}
+
+	poolList, err := job.processor.provider.GetAllTargetPools(ctx)
+	if err != nil {
+		return err
+	}
+	targetPools := make(map[string]*compute.TargetPool)
+	for _, pool := range poolList {
+		targetPools[pool.SelfLink] = pool
+	}
+
+	targetInstanceList, err := job.processor.provider.GetAllTargetInstances(ctx)
+	if err != nil {
+		return err
+	}
+	targetInstances := make(map[string]*compute.TargetInstance)
+	for _, ti := range targetInstanceList {
+		targetInstances[ti.SelfLink] = ti
+	}
+
+	log.Printf("# of forwarding rules: %d", len(rules))
+
+	for _, rule := range rules {
+		oi := LoadBalancerItem{
+			ProjectId:           job.processor.provider.ProjectID,
+			Name:                rule.Name,
+			Id:                  strconv.FormatUint(rule.Id, 10),
+			Region:              "global",
+			IsGlobal:            rule.Region == "",
+			Scheme:              rule.LoadBalancingScheme,
+			Protocol:            rule.IPProtocol,
+			IPAddress:           rule.IPAddress,
+			NetworkTier:         rule.NetworkTier,
+			OptimizationLoading: true,
+			Preferences:         job.processor.defaultPreferences,
+			Skipped:             false,
+			LazyLoadingEnabled:  false,
+			SkipReason:          "NA",
+			ForwardingRule:      rule,
+			Metrics:             nil,
+		}
+		if rule.Region != "" {
+			oi.Region = util.TrimmedString(rule.Region, "/")
+		}
+
+		var backendServiceLinks []string
+		switch {
+		case rule.BackendService != "":
+			oi.TargetType = "backendServices"
+			backendServiceLinks = append(backendServiceLinks, rule.BackendService)
+		case strings.Contains(rule.Target, "/"):
+			// target is in form of .../{collection}/{name}, e.g. .../targetHttpsProxies/my-proxy
+			targetParts := strings.Split(rule.Target, "/")
+			oi.TargetType = targetParts[len(targetParts)-2]
+			oi.TargetPool = targetPools[rule.Target]
+			oi.TargetInstance = targetInstances[rule.Target]
+			if next, ok := proxies[rule.Target]; ok {
+				if urlMap, ok := urlMaps[next]; ok {
+					backendServiceLinks = append(backendServiceLinks, urlMapServices(urlMap)...)
+				} else {
+					backendServiceLinks = append(backendServiceLinks, next)
+				}
+			}
+		default:
+			// private service connect endpoints for google apis have targets like "all-apis"
+			oi.Skipped = true
+			oi.SkipReason = "private service connect endpoint"
+		}
+
+		seen := make(map[string]bool)
+		for _, link := range backendServiceLinks {
+			if seen[link] {
+				continue
+			}
+			seen[link] = true
+			// url maps can point to backend buckets too, those have no backends to check
+			if bs, ok := backendServices[link]; ok {
+				oi.BackendServices = append(oi.BackendServices, bs)
+			} else if strings.Contains(link, "/backendBuckets/") {
+				oi.BackendBuckets = append(oi.BackendBuckets, link)
+			}
+		}
+
+		if !oi.Skipped {
+			job.processor.lazyloadCounter.Add(1)
+			if job.processor.lazyloadCounter.Load() > uint32(1) {
+				oi.LazyLoadingEnabled = true
+			}
+		}
+
+		job.processor.items.Set(oi.Id, oi)
+		job.processor.publishOptimizationItem(oi.ToOptimizationItem())
+		job.processor.UpdateSummary(oi.Id)
+
+		if !oi.Skipped {
+			job.processor.jobQueue.Push(NewGetLoadBalancerMetricsJob(job.processor, oi.Id))
+		}
+	}
+
+	return nil
+}
+
+// urlMapServices returns every service the url map can route traffic to
+func urlMapServices(urlMap *compute.UrlMap) []string {
+	var services []string
+	if urlMap.DefaultService != "" {
+		services = append(services, urlMap.DefaultService)
+	}
+	for _, pm := range urlMap.PathMatchers {
+		if pm.DefaultService != "" {
+			services = append(services, pm.DefaultService)
+		}
+		for _, pr := range pm.PathRules {
+			if pr.Service != "" {
+				services = append(services, pr.Service)
+			}
+		}
+		for _, rr := range pm.RouteRules {
+			if rr.Service != "" {
+				services = append(services, rr.Service)
+			}
+		}
+	}
+	return services
+}
@@ -0,0 +1,53 @@
+package load_balancer
+
+import (
+	"context"
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+)
+
+type OptimizeLoadBalancerJob struct {
+	processor *LoadBalancerProcessor
+	itemId    string
+}
+
+func NewOptimizeLoadBalancerJob(processor *LoadBalancerProcessor, itemId string) *OptimizeLoadBalancerJob {
+	return &OptimizeLoadBalancerJob{
+		processor: processor,
+		itemId:    itemId,
+	}
+}
+
+func (job *OptimizeLoadBalancerJob) Properties() sdk.JobProperties {
+	return sdk.JobProperties{
+		ID:          fmt.Sprintf("optimize_load_balancer_%s", job.itemId),
+		Description: fmt.Sprintf("Optimizing %s", job.itemId),
+		MaxRetry:    3,
+	}
+}
+
+func (job *OptimizeLoadBalancerJob) Run(ctx context.Context) error {
+	item, ok := job.processor.items.Get(job.itemId)
+	if !ok {
+		return fmt.Errorf("item not found %s", job.itemId)
+	}
+
+	if item.LazyLoadingEnabled {
+		job.processor.jobQueue.Push(NewGetLoadBalancerMetricsJob(job.processor, job.itemId))
+		return nil
+	}
+
+	item.OptimizationLoading = false
+	item.Skipped = false
+	item.SkipReason = "N/A"
+	item.LazyLoadingEnabled = false
+	// idleness is decided locally, the optimization service has no load balancer support
+	item.Wastage = &golang2.GCPLoadBalancerOptimizationResponse{Rightsizing: recommendLoadBalancer(item)}
+
+	job.processor.items.Set(job.itemId, item)
+	job.processor.publishOptimizationItem(item.ToOptimizationItem())
+	job.processor.UpdateSummary(item.Id)
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package load_balancer
+
+import (
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	"github.com/kaytu-io/kaytu/pkg/style"
+	"github.com/kaytu-io/kaytu/pkg/utils"
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+	"strings"
+	"sync/atomic"
+)
+
+type LoadBalancerProcessor struct {
+	provider                *gcp.Compute
+	metricProvider          *gcp.CloudMonitoring
+	items                   utils.ConcurrentMap[string, LoadBalancerItem]
+	publishOptimizationItem func(item *golang.ChartOptimizationItem)
+	publishResultSummary    func(summary *golang.ResultSummary)
+	kaytuAcccessToken       string
+	jobQueue                *sdk.JobQueue
+	lazyloadCounter         atomic.Uint32
+
+	defaultPreferences []*golang.PreferenceItem
+
+	summary utils.ConcurrentMap[string, LoadBalancerSummary]
+}
+
+func NewLoadBalancerProcessor(
+	prv *gcp.Compute,
+	metricPrv *gcp.CloudMonitoring,
+	publishOptimizationItem func(item *golang.ChartOptimizationItem),
+	publishResultSummary func(summary *golang.ResultSummary),
+	kaytuAcccessToken string,
+	jobQueue *sdk.JobQueue,
+	defaultPreferences []*golang.PreferenceItem,
+) *LoadBalancerProcessor {
+	r := &LoadBalancerProcessor{
+		provider:                prv,
+		metricProvider:          metricPrv,
+		items:                   utils.NewConcurrentMap[string, LoadBalancerItem](),
+		publishOptimizationItem: publishOptimizationItem,
+		publishResultSummary:    publishResultSummary,
+		kaytuAcccessToken:       kaytuAcccessToken,
+		jobQueue:                jobQueue,
+		lazyloadCounter:         atomic.Uint32{},
+		defaultPreferences:      defaultPreferences,
+	}
+
+	jobQueue.Push(NewListLoadBalancersJob(r))
+	return r
+}
+
+func (m *LoadBalancerProcessor) ReEvaluate(id string, items []*golang.PreferenceItem) {
+	v, _ := m.items.Get(id)
+	v.Preferences = items
+	m.items.Set(id, v)
+	v.OptimizationLoading = true
+	m.publishOptimizationItem(v.ToOptimizationItem())
+	m.jobQueue.Push(NewOptimizeLoadBalancerJob(m, id))
+}
+
+func (m *LoadBalancerProcessor) ExportNonInteractive() *golang.NonInteractiveExport {
+	return &golang.NonInteractiveExport{
+		Csv: m.exportCsv(),
+	}
+}
+
+func (m *LoadBalancerProcessor) exportCsv() []*golang.CSVRow {
+	headers := []string{
+		"Project ID", "Region", "Resource Type", "Resource ID", "Resource Name", "Platform",
+		"Device Runtime (Hrs)", "Current Cost", "Recommendation Cost", "Net Savings",
+		"Current Spec", "Suggested Spec", "Parent Device", "Justification", "Additional Details",
+	}
+	var rows []*golang.CSVRow
+	rows = append(rows, &golang.CSVRow{Row: headers})
+
+	m.items.Range(func(key string, value LoadBalancerItem) bool {
+		if value.Wastage == nil || value.Wastage.Rightsizing == nil || value.Wastage.Rightsizing.Current == nil {
+			return true
+		}
+		var additionalDetails []string
+		var rightSizingCost, saving, recSpec string
+		if value.Wastage.Rightsizing.Recommended != nil {
+			rightSizingCost = utils.FormatPriceFloat(value.Wastage.Rightsizing.Recommended.Cost)
+			saving = utils.FormatPriceFloat(value.Wastage.Rightsizing.Current.Cost - value.Wastage.Rightsizing.Recommended.Cost)
+			recSpec = "Delete"
+			if !value.Wastage.Rightsizing.Idle {
+				recSpec = "Keep"
+			}
+		}
+		additionalDetails = append(additionalDetails,
+			fmt.Sprintf("Backends:: Healthy: %d - Total: %d - Health Unknown: %t", value.HealthyEndpoints, value.Endpoints, value.HealthUnknown))
+		additionalDetails = append(additionalDetails,
+			fmt.Sprintf("Backend Services:: %s", strings.Join(value.BackendServiceNames(), ", ")))
+		if value.Wastage.Rightsizing.Idle {
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("Idle:: %s", value.Wastage.Rightsizing.IdleReason))
+		}
+		ruleRow := []string{
+			value.ProjectId, value.Region, "Forwarding Rule", value.Id, value.Name, value.Scheme,
+			"730 Hrs", utils.FormatPriceFloat(value.Wastage.Rightsizing.Current.Cost), rightSizingCost, saving,
+			fmt.Sprintf("%s %s", value.Protocol, value.IPAddress), recSpec, "None", value.Wastage.Rightsizing.Description, strings.Join(additionalDetails, "---")}
+
+		rows = append(rows, &golang.CSVRow{Row: ruleRow})
+
+		return true
+	})
+	return rows
+}
+
+func (m *LoadBalancerProcessor) ResultsSummary() *golang.ResultSummary {
+	summary := &golang.ResultSummary{}
+	var totalCost, savings float64
+	m.summary.Range(func(_ string, item LoadBalancerSummary) bool {
+		totalCost += item.CurrentRuntimeCost
+		savings += item.Savings
+		return true
+	})
+
+	summary.Message = fmt.Sprintf("Current runtime cost: %s, Savings: %s",
+		style.CostStyle.Render(fmt.Sprintf("%s", utils.FormatPriceFloat(totalCost))), style.SavingStyle.Render(fmt.Sprintf("%s", utils.FormatPriceFloat(savings))))
+	return summary
+}
+
+func (m *LoadBalancerProcessor) UpdateSummary(itemId string) {
+	i, ok := m.items.Get(itemId)
+	if ok && i.Wastage != nil && i.Wastage.Rightsizing != nil && i.Wastage.Rightsizing.Recommended != nil {
+		m.summary.Set(itemId, LoadBalancerSummary{
+			CurrentRuntimeCost: i.Wastage.Rightsizing.Current.Cost,
+			Savings:            i.Wastage.Rightsizing.Current.Cost - i.Wastage.Rightsizing.Recommended.Cost,
+		})
+	}
+	m.publishResultSummary(m.ResultsSummary())
+}
@@ -0,0 +1,313 @@
+package load_balancer
+
+import (
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	"github.com/kaytu-io/kaytu/pkg/utils"
+	"github.com/opengovern/plugin-gcp/plugin/processor/shared"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"maps"
+	"strconv"
+)
+
+type BackendHealth struct {
+	Endpoints        int32
+	HealthyEndpoints int32
+	// Unknown is set when the health of some backends could not be read, e.g. serverless NEGs have no health
+	Unknown bool
+}
+
+type LoadBalancerItem struct {
+	ProjectId           string
+	Name                string
+	Id                  string
+	Region              string
+	IsGlobal            bool
+	Scheme              string
+	Protocol            string
+	IPAddress           string
+	NetworkTier         string
+	TargetType          string
+	OptimizationLoading bool
+	Preferences         []*golang.PreferenceItem
+	Skipped             bool
+	LazyLoadingEnabled  bool
+	SkipReason          string
+	ForwardingRule      *compute.ForwardingRule
+	BackendServices     []*compute.BackendService
+	BackendBuckets      []string
+	TargetPool          *compute.TargetPool
+	TargetInstance      *compute.TargetInstance
+	BackendHealth       map[string]BackendHealth
+	Endpoints           int32
+	HealthyEndpoints    int32
+	HealthUnknown       bool
+	Metrics             map[string][]*golang2.DataPoint
+	Wastage             *golang2.GCPLoadBalancerOptimizationResponse
+}
+
+func (i LoadBalancerItem) BackendServiceNames() []string {
+	var names []string
+	for _, bs := range i.BackendServices {
+		names = append(names, bs.Name)
+	}
+	return names
+}
+
+func (i LoadBalancerItem) ForwardingRuleDevice() (*golang.ChartRow, map[string]*golang.Properties) {
+	row := golang.ChartRow{
+		RowId:  i.Id,
+		Values: make(map[string]*golang.ChartRowItem),
+	}
+
+	row.Values["resource_id"] = &golang.ChartRowItem{
+		Value: i.Id,
+	}
+	row.Values["resource_name"] = &golang.ChartRowItem{
+		Value: i.Name,
+	}
+	row.Values["resource_type"] = &golang.ChartRowItem{
+		Value: "Forwarding Rule",
+	}
+	row.Values["project_id"] = &golang.ChartRowItem{
+		Value: i.ProjectId,
+	}
+
+	RegionProperty := &golang.Property{Key: "Region", Current: i.Region}
+	SchemeProperty := &golang.Property{Key: "Load Balancing Scheme", Current: i.Scheme}
+	TargetProperty := &golang.Property{Key: "Target", Current: i.TargetType}
+	StatusProperty := &golang.Property{Key: "Status", Current: "Keep"}
+	BackendsProperty := &golang.Property{Key: "  Healthy Endpoints", Current: healthString(i.HealthyEndpoints, i.Endpoints, i.HealthUnknown)}
+	RequestCountProperty := &golang.Property{Key: "  Requests (per second)"}
+	IngressBytesProperty := &golang.Property{Key: "  Ingress (bytes per second)"}
+	EgressBytesProperty := &golang.Property{Key: "  Egress (bytes per second)"}
+	ForwardingRuleCostProperty := &golang.Property{Key: "  Forwarding Rule Cost"}
+	DataProcessingCostProperty := &golang.Property{Key: "  Data Processing Cost"}
+
+	if i.Wastage != nil && i.Wastage.Rightsizing != nil && i.Wastage.Rightsizing.Current != nil {
+		current := i.Wastage.Rightsizing.Current
+
+		RequestCountProperty.Average = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.RequestCount.GetAvg()))
+		RequestCountProperty.Max = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.RequestCount.GetMax()))
+		IngressBytesProperty.Average = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.IngressBytes.GetAvg()))
+		IngressBytesProperty.Max = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.IngressBytes.GetMax()))
+		EgressBytesProperty.Average = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.EgressBytes.GetAvg()))
+		EgressBytesProperty.Max = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.EgressBytes.GetMax()))
+
+		ForwardingRuleCostProperty.Current = utils.FormatPriceFloat(current.ForwardingRuleCost)
+		DataProcessingCostProperty.Current = utils.FormatPriceFloat(current.DataProcessingCost)
+
+		row.Values["current_cost"] = &golang.ChartRowItem{
+			Value: utils.FormatPriceFloat(current.Cost),
+		}
+
+		if i.Wastage.Rightsizing.Idle {
+			StatusProperty.Current = fmt.Sprintf("Idle - %s", i.Wastage.Rightsizing.IdleReason)
+			StatusProperty.Recommended = "Delete"
+		}
+
+		if recommended := i.Wastage.Rightsizing.Recommended; recommended != nil {
+			row.Values["right_sized_cost"] = &golang.ChartRowItem{
+				Value: utils.FormatPriceFloat(recommended.Cost),
+			}
+			row.Values["savings"] = &golang.ChartRowItem{
+				Value: utils.FormatPriceFloat(current.Cost - recommended.Cost),
+			}
+			RegionProperty.Recommended = recommended.Region
+			ForwardingRuleCostProperty.Recommended = utils.FormatPriceFloat(recommended.ForwardingRuleCost)
+			DataProcessingCostProperty.Recommended = utils.FormatPriceFloat(recommended.DataProcessingCost)
+		}
+	}
+
+	props := make(map[string]*golang.Properties)
+	properties := &golang.Properties{}
+
+	properties.Properties = append(properties.Properties, RegionProperty)
+	properties.Properties = append(properties.Properties, SchemeProperty)
+	properties.Properties = append(properties.Properties, TargetProperty)
+	properties.Properties = append(properties.Properties, StatusProperty)
+	properties.Properties = append(properties.Properties, &golang.Property{
+		Key: "Traffic",
+	})
+	properties.Properties = append(properties.Properties, BackendsProperty)
+	properties.Properties = append(properties.Properties, RequestCountProperty)
+	properties.Properties = append(properties.Properties, IngressBytesProperty)
+	properties.Properties = append(properties.Properties, EgressBytesProperty)
+	properties.Properties = append(properties.Properties, &golang.Property{
+		Key: "Cost",
+	})
+	properties.Properties = append(properties.Properties, ForwardingRuleCostProperty)
+	properties.Properties = append(properties.Properties, DataProcessingCostProperty)
+
+	props[i.Id] = properties
+
+	return &row, props
+}
+
+func (i LoadBalancerItem) BackendServiceDevices() ([]*golang.ChartRow, map[string]*golang.Properties) {
+	var rows []*golang.ChartRow
+	props := make(map[string]*golang.Properties)
+
+	for _, bs := range i.BackendServices {
+		key := strconv.FormatUint(bs.Id, 10)
+		row := golang.ChartRow{
+			RowId:  key,
+			Values: make(map[string]*golang.ChartRowItem),
+		}
+
+		row.Values["project_id"] = &golang.ChartRowItem{
+			Value: i.ProjectId,
+		}
+		row.Values["resource_id"] = &golang.ChartRowItem{
+			Value: key,
+		}
+		row.Values["resource_name"] = &golang.ChartRowItem{
+			Value: bs.Name,
+		}
+		row.Values["resource_type"] = &golang.ChartRowItem{
+			Value: "Backend Service",
+		}
+
+		health := i.BackendHealth[bs.SelfLink]
+
+		properties := &golang.Properties{}
+		properties.Properties = append(properties.Properties, &golang.Property{
+			Key:     "Protocol",
+			Current: bs.Protocol,
+		})
+		properties.Properties = append(properties.Properties, &golang.Property{
+			Key:     "Backends",
+			Current: fmt.Sprintf("%d", len(bs.Backends)),
+		})
+		properties.Properties = append(properties.Properties, &golang.Property{
+			Key:     "Healthy Endpoints",
+			Current: healthString(health.HealthyEndpoints, health.Endpoints, health.Unknown),
+		})
+
+		props[key] = properties
+		rows = append(rows, &row)
+	}
+
+	// network load balancers forward to a target pool or a single instance instead of backend services
+	var targetId, targetName, targetType, targetLink string
+	var instances int
+	switch {
+	case i.TargetPool != nil:
+		targetId, targetName, targetType, targetLink = strconv.FormatUint(i.TargetPool.Id, 10), i.TargetPool.Name, "Target Pool", i.TargetPool.SelfLink
+		instances = len(i.TargetPool.Instances)
+	case i.TargetInstance != nil:
+		targetId, targetName, targetType, targetLink = strconv.FormatUint(i.TargetInstance.Id, 10), i.TargetInstance.Name, "Target Instance", i.TargetInstance.SelfLink
+		instances = 1
+	default:
+		return rows, props
+	}
+	row := golang.ChartRow{
+		RowId: targetId,
+		Values: map[string]*golang.ChartRowItem{
+			"project_id":    {Value: i.ProjectId},
+			"resource_id":   {Value: targetId},
+			"resource_name": {Value: targetName},
+			"resource_type": {Value: targetType},
+		},
+	}
+	health := i.BackendHealth[targetLink]
+	properties := &golang.Properties{}
+	properties.Properties = append(properties.Properties, &golang.Property{
+		Key:     "Instances",
+		Current: fmt.Sprintf("%d", instances),
+	})
+	properties.Properties = append(properties.Properties, &golang.Property{
+		Key:     "Healthy Endpoints",
+		Current: healthString(health.HealthyEndpoints, health.Endpoints, health.Unknown),
+	})
+	props[targetId] = properties
+	rows = append(rows, &row)
+
+	return rows, props
+}
+
+func healthString(healthy, endpoints int32, unknown bool) string {
+	if unknown {
+		return fmt.Sprintf("%d / %d (some unknown)", healthy, endpoints)
+	}
+	return fmt.Sprintf("%d / %d", healthy, endpoints)
+}
+
+func (i LoadBalancerItem) Devices() ([]*golang.ChartRow, map[string]*golang.Properties) {
+
+	var deviceRows []*golang.ChartRow
+	deviceProps := make(map[string]*golang.Properties)
+
+	ruleRow, ruleProps := i.ForwardingRuleDevice()
+	backendRows, backendProps := i.BackendServiceDevices()
+
+	deviceRows = append(deviceRows, ruleRow)
+	deviceRows = append(deviceRows, backendRows...)
+	maps.Copy(deviceProps, ruleProps)
+	maps.Copy(deviceProps, backendProps)
+
+	return deviceRows, deviceProps
+}
+
+func (i LoadBalancerItem) ToOptimizationItem() *golang.ChartOptimizationItem {
+
+	deviceRows, deviceProps := i.Devices()
+
+	status := ""
+	if i.Skipped {
+		status = fmt.Sprintf("skipped - %s", i.SkipReason)
+	} else if i.LazyLoadingEnabled && !i.OptimizationLoading {
+		status = "press enter to load"
+	} else if i.OptimizationLoading {
+		status = "loading"
+	} else if i.Wastage != nil && i.Wastage.Rightsizing != nil && i.Wastage.Rightsizing.Recommended != nil {
+		totalSaving := i.Wastage.Rightsizing.Current.Cost - i.Wastage.Rightsizing.Recommended.Cost
+		totalCurrentCost := i.Wastage.Rightsizing.Current.Cost
+		status = fmt.Sprintf("%s (%.2f%%)", utils.FormatPriceFloat(totalSaving), (totalSaving/totalCurrentCost)*100)
+	}
+
+	chartrow := &golang.ChartRow{
+		RowId: i.Id,
+		Values: map[string]*golang.ChartRowItem{
+			"x_kaytu_right_arrow": {
+				Value: "→",
+			},
+			"resource_id": {
+				Value: i.Id,
+			},
+			"resource_name": {
+				Value: i.Name,
+			},
+			"resource_type": {
+				Value: i.TargetType,
+			},
+			"region": {
+				Value: i.Region,
+			},
+			"platform": {
+				Value: i.Scheme,
+			},
+			"total_saving": {
+				Value: status,
+			},
+		},
+	}
+
+	coi := &golang.ChartOptimizationItem{
+		OverviewChartRow:   chartrow,
+		DevicesChartRows:   deviceRows,
+		DevicesProperties:  deviceProps,
+		Preferences:        i.Preferences,
+		Loading:            i.OptimizationLoading,
+		Skipped:            i.Skipped,
+		SkipReason:         wrapperspb.String(i.SkipReason),
+		LazyLoadingEnabled: i.LazyLoadingEnabled,
+	}
+	if i.Wastage != nil && i.Wastage.Rightsizing != nil {
+		coi.Description = i.Wastage.Rightsizing.Description
+	}
+
+	return coi
+}
@@ -0,0 +1,6 @@
+package load_balancer
+
+type LoadBalancerSummary struct {
+	CurrentRuntimeCost float64
+	Savings            float64
+}
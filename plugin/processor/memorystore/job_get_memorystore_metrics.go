@@ -0,0 +1,138 @@
+package memorystore
+
+import (
+	"context"
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"log"
+	"time"
+
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type GetMemorystoreInstanceMetricsJob struct {
+	processor *MemorystoreProcessor
+	itemId    string
+}
+
+func NewGetMemorystoreInstanceMetricsJob(processor *MemorystoreProcessor, itemId string) *GetMemorystoreInstanceMetricsJob {
+	return &GetMemorystoreInstanceMetricsJob{
+		processor: processor,
+		itemId:    itemId,
+	}
+}
+
+func (job *GetMemorystoreInstanceMetricsJob) Properties() sdk.JobProperties {
+	return sdk.JobProperties{
+		ID:          fmt.Sprintf("get_memorystore_instance_metrics_%s", job.itemId),
+		Description: fmt.Sprintf("Get metrics for memorystore instance: %s", job.itemId),
+		MaxRetry:    0,
+	}
+}
+
+func (job *GetMemorystoreInstanceMetricsJob) Run(ctx context.Context) error {
+	item, ok := job.processor.items.Get(job.itemId)
+	if !ok {
+		return fmt.Errorf("item not found %s", job.itemId)
+	}
+
+	endTime := time.Now()                         // end time of requested time series
+	startTime := endTime.Add(-24 * 1 * time.Hour) // start time of requested time series
+
+	memoryUsageRequest := job.processor.metricProvider.NewTimeSeriesRequest(
+		fmt.Sprintf(
+			`metric.type="%s" AND resource.labels.instance_id="%s" AND metric.labels.role="primary"`,
+			"redis.googleapis.com/stats/memory/usage_ratio",
+			item.Id, // full resource name of the instance
+		),
+		&monitoringpb.TimeInterval{
+			EndTime:   timestamppb.New(endTime),
+			StartTime: timestamppb.New(startTime),
+		},
+		&monitoringpb.Aggregation{
+			AlignmentPeriod: &durationpb.Duration{
+				Seconds: 60,
+			},
+			PerSeriesAligner: monitoringpb.Aggregation_ALIGN_MEAN, // will represent all the datapoints in the above period, with a mean
+		},
+	)
+
+	memoryUsageMetric, err := job.processor.metricProvider.GetMetric(ctx, memoryUsageRequest)
+	if err != nil {
+		return err
+	}
+
+	cpuRequest := job.processor.metricProvider.NewTimeSeriesRequest(
+		fmt.Sprintf(
+			`metric.type="%s" AND resource.labels.instance_id="%s" AND metric.labels.role="primary"`,
+			"redis.googleapis.com/stats/cpu_utilization",
+			item.Id,
+		),
+		&monitoringpb.TimeInterval{
+			EndTime:   timestamppb.New(endTime),
+			StartTime: timestamppb.New(startTime),
+		},
+		&monitoringpb.Aggregation{
+			AlignmentPeriod: &durationpb.Duration{
+				Seconds: 60,
+			},
+			PerSeriesAligner:   monitoringpb.Aggregation_ALIGN_RATE, // cpu seconds per second, i.e. the number of busy cores
+			CrossSeriesReducer: monitoringpb.Aggregation_REDUCE_SUM, // sum up user and system space usage
+		},
+	)
+
+	cpuMetric, err := job.processor.metricProvider.GetMetric(ctx, cpuRequest)
+	if err != nil {
+		return err
+	}
+
+	connectedClientsRequest := job.processor.metricProvider.NewTimeSeriesRequest(
+		fmt.Sprintf(
+			`metric.type="%s" AND resource.labels.instance_id="%s" AND metric.labels.role="primary"`,
+			"redis.googleapis.com/clients/connected",
+			item.Id,
+		),
+		&monitoringpb.TimeInterval{
+			EndTime:   timestamppb.New(endTime),
+			StartTime: timestamppb.New(startTime),
+		},
+		&monitoringpb.Aggregation{
+			AlignmentPeriod: &durationpb.Duration{
+				Seconds: 60,
+			},
+			PerSeriesAligner: monitoringpb.Aggregation_ALIGN_MEAN, // will represent all the datapoints in the above period, with a mean
+		},
+	)
+
+	connectedClientsMetric, err := job.processor.metricProvider.GetMetric(ctx, connectedClientsRequest)
+	if err != nil {
+		return err
+	}
+
+	instanceMetrics := make(map[string][]*golang2.DataPoint)
+
+	instanceMetrics["MemoryUsageRatio"] = memoryUsageMetric
+	instanceMetrics["CPUUtilization"] = cpuMetric
+	instanceMetrics["ConnectedClients"] = connectedClientsMetric
+
+	item.OptimizationLoading = true
+	item.Skipped = false
+	item.SkipReason = "N/A"
+	item.LazyLoadingEnabled = false
+	item.Metrics = instanceMetrics
+
+	for k, v := range item.Metrics {
+		log.Printf("%s %s : %d", item.Id, k, len(v))
+	}
+
+	job.processor.items.Set(item.Id, item)
+	job.processor.publishOptimizationItem(item.ToOptimizationItem())
+	job.processor.UpdateSummary(item.Id)
+
+	job.processor.jobQueue.Push(NewOptimizeMemorystoreInstanceJob(job.processor, item.Id))
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package memorystore
+
+import (
+	"context"
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	"log"
+	"strings"
+
+	util "github.com/opengovern/plugin-gcp/utils"
+)
+
+type ListMemorystoreInstancesJob struct {
+	processor *MemorystoreProcessor
+}
+
+func NewListMemorystoreInstancesJob(processor *MemorystoreProcessor) *ListMemorystoreInstancesJob {
+	return &ListMemorystoreInstancesJob{
+		processor: processor,
+	}
+}
+
+func (job *ListMemorystoreInstancesJob) Properties() sdk.JobProperties {
+	return sdk.JobProperties{
+		ID:          "list_memorystore_instances",
+		Description: "List all memorystore redis instances in current project",
+		MaxRetry:    0,
+	}
+}
+
+func (job *ListMemorystoreInstancesJob) Run(ctx context.Context) error {
+	log.Println("Running list memorystore instances job")
+
+	instances, err := job.processor.provider.GetAllInstances(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("# of instances: %d", len(instances))
+
+	for _, instance := range instances {
+		// instance name is in form of projects/{project}/locations/{region}/instances/{instance}
+		oi := MemorystoreInstanceItem{
+			ProjectId:           job.processor.provider.ProjectID,
+			Name:                util.TrimmedString(instance.Name, "/"),
+			Id:                  instance.Name,
+			Region:              regionOfZone(instance.LocationId),
+			Zone:                instance.LocationId,
+			Tier:                instance.Tier,
+			MemorySizeGb:        instance.MemorySizeGb,
+			RedisVersion:        instance.RedisVersion,
+			OptimizationLoading: true,
+			Preferences:         job.processor.defaultPreferences,
+			Skipped:             false,
+			LazyLoadingEnabled:  false,
+			SkipReason:          "NA",
+			Instance:            instance,
+			Metrics:             nil,
+		}
+
+		if instance.State != "READY" {
+			oi.Skipped = true
+			oi.SkipReason = fmt.Sprintf("instance is %s", instance.State)
+		}
+
+		if !oi.Skipped {
+			job.processor.lazyloadCounter.Add(1)
+			if job.processor.lazyloadCounter.Load() > uint32(1) {
+				oi.LazyLoadingEnabled = true
+			}
+		}
+
+		job.processor.items.Set(oi.Id, oi)
+		job.processor.publishOptimizationItem(oi.ToOptimizationItem())
+		job.processor.UpdateSummary(oi.Id)
+
+		if !oi.Skipped {
+			job.processor.jobQueue.Push(NewGetMemorystoreInstanceMetricsJob(job.processor, oi.Id))
+		}
+	}
+
+	return nil
+}
+
+// regionOfZone turns a zone such as us-central1-a into its region us-central1
+func regionOfZone(zone string) string {
+	parts := strings.Split(zone, "-")
+	if len(parts) < 3 {
+		return zone
+	}
+	return strings.Join(parts[:len(parts)-1], "-")
+}
@@ -0,0 +1,53 @@
+package memorystore
+
+import (
+	"context"
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+)
+
+type OptimizeMemorystoreInstanceJob struct {
+	processor *MemorystoreProcessor
+	itemId    string
+}
+
+func NewOptimizeMemorystoreInstanceJob(processor *MemorystoreProcessor, itemId string) *OptimizeMemorystoreInstanceJob {
+	return &OptimizeMemorystoreInstanceJob{
+		processor: processor,
+		itemId:    itemId,
+	}
+}
+
+func (job *OptimizeMemorystoreInstanceJob) Properties() sdk.JobProperties {
+	return sdk.JobProperties{
+		ID:          fmt.Sprintf("optimize_memorystore_instance_%s", job.itemId),
+		Description: fmt.Sprintf("Optimizing %s", job.itemId),
+		MaxRetry:    3,
+	}
+}
+
+func (job *OptimizeMemorystoreInstanceJob) Run(ctx context.Context) error {
+	item, ok := job.processor.items.Get(job.itemId)
+	if !ok {
+		return fmt.Errorf("item not found %s", job.itemId)
+	}
+
+	if item.LazyLoadingEnabled {
+		job.processor.jobQueue.Push(NewGetMemorystoreInstanceMetricsJob(job.processor, job.itemId))
+		return nil
+	}
+
+	item.OptimizationLoading = false
+	item.Skipped = false
+	item.SkipReason = "N/A"
+	item.LazyLoadingEnabled = false
+	// the capacity is sized locally, the optimization service has no Memorystore support
+	item.Wastage = &golang2.GCPMemorystoreOptimizationResponse{Rightsizing: recommendInstance(item)}
+
+	job.processor.items.Set(job.itemId, item)
+	job.processor.publishOptimizationItem(item.ToOptimizationItem())
+	job.processor.UpdateSummary(item.Id)
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package memorystore
+
+import (
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	"github.com/kaytu-io/kaytu/pkg/style"
+	"github.com/kaytu-io/kaytu/pkg/utils"
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+	"strings"
+	"sync/atomic"
+)
+
+type MemorystoreProcessor struct {
+	provider                *gcp.Memorystore
+	metricProvider          *gcp.CloudMonitoring
+	items                   utils.ConcurrentMap[string, MemorystoreInstanceItem]
+	publishOptimizationItem func(item *golang.ChartOptimizationItem)
+	publishResultSummary    func(summary *golang.ResultSummary)
+	kaytuAcccessToken       string
+	jobQueue                *sdk.JobQueue
+	lazyloadCounter         atomic.Uint32
+
+	defaultPreferences []*golang.PreferenceItem
+
+	summary utils.ConcurrentMap[string, MemorystoreInstanceSummary]
+}
+
+func NewMemorystoreProcessor(
+	prv *gcp.Memorystore,
+	metricPrv *gcp.CloudMonitoring,
+	publishOptimizationItem func(item *golang.ChartOptimizationItem),
+	publishResultSummary func(summary *golang.ResultSummary),
+	kaytuAcccessToken string,
+	jobQueue *sdk.JobQueue,
+	defaultPreferences []*golang.PreferenceItem,
+) *MemorystoreProcessor {
+	r := &MemorystoreProcessor{
+		provider:                prv,
+		metricProvider:          metricPrv,
+		items:                   utils.NewConcurrentMap[string, MemorystoreInstanceItem](),
+		publishOptimizationItem: publishOptimizationItem,
+		publishResultSummary:    publishResultSummary,
+		kaytuAcccessToken:       kaytuAcccessToken,
+		jobQueue:                jobQueue,
+		lazyloadCounter:         atomic.Uint32{},
+		defaultPreferences:      defaultPreferences,
+	}
+
+	jobQueue.Push(NewListMemorystoreInstancesJob(r))
+	return r
+}
+
+func (m *MemorystoreProcessor) ReEvaluate(id string, items []*golang.PreferenceItem) {
+	v, _ := m.items.Get(id)
+	v.Preferences = items
+	m.items.Set(id, v)
+	v.OptimizationLoading = true
+	m.publishOptimizationItem(v.ToOptimizationItem())
+	m.jobQueue.Push(NewOptimizeMemorystoreInstanceJob(m, id))
+}
+
+func (m *MemorystoreProcessor) ExportNonInteractive() *golang.NonInteractiveExport {
+	return &golang.NonInteractiveExport{
+		Csv: m.exportCsv(),
+	}
+}
+
+func (m *MemorystoreProcessor) exportCsv() []*golang.CSVRow {
+	headers := []string{
+		"Project ID", "Region", "Resource Type", "Resource ID", "Resource Name", "Platform",
+		"Device Runtime (Hrs)", "Current Cost", "Recommendation Cost", "Net Savings",
+		"Current Spec", "Suggested Spec", "Parent Device", "Justification", "Additional Details",
+	}
+	var rows []*golang.CSVRow
+	rows = append(rows, &golang.CSVRow{Row: headers})
+
+	m.items.Range(func(key string, value MemorystoreInstanceItem) bool {
+		if value.Wastage == nil || value.Wastage.Rightsizing == nil || value.Wastage.Rightsizing.Current == nil {
+			return true
+		}
+		var additionalDetails []string
+		var rightSizingCost, saving, recSpec string
+		if value.Wastage.Rightsizing.Recommended != nil {
+			rightSizingCost = utils.FormatPriceFloat(value.Wastage.Rightsizing.Recommended.Cost)
+			saving = utils.FormatPriceFloat(value.Wastage.Rightsizing.Current.Cost - value.Wastage.Rightsizing.Recommended.Cost)
+			recSpec = instanceSpec(value.Wastage.Rightsizing.Recommended)
+
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("Tier:: Current: %s - Recommended: %s", value.Wastage.Rightsizing.Current.Tier,
+					value.Wastage.Rightsizing.Recommended.Tier))
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("Region:: Current: %s - Recommended: %s", value.Wastage.Rightsizing.Current.Region,
+					value.Wastage.Rightsizing.Recommended.Region))
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("Capacity:: Current: %d GB - Recommended: %d GB", value.Wastage.Rightsizing.Current.MemorySizeGb,
+					value.Wastage.Rightsizing.Recommended.MemorySizeGb))
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("Replicas:: Current: %d - Recommended: %d", value.Wastage.Rightsizing.Current.ReplicaCount,
+					value.Wastage.Rightsizing.Recommended.ReplicaCount))
+		}
+		instanceRow := []string{
+			value.ProjectId, value.Region, "Memorystore Instance", value.Id, value.Name, value.RedisVersion,
+			"730 Hrs", utils.FormatPriceFloat(value.Wastage.Rightsizing.Current.Cost), rightSizingCost, saving,
+			instanceSpec(value.Wastage.Rightsizing.Current), recSpec, "None", value.Wastage.Rightsizing.Description, strings.Join(additionalDetails, "---")}
+
+		rows = append(rows, &golang.CSVRow{Row: instanceRow})
+
+		return true
+	})
+	return rows
+}
+
+func (m *MemorystoreProcessor) ResultsSummary() *golang.ResultSummary {
+	summary := &golang.ResultSummary{}
+	var totalCost, savings float64
+	m.summary.Range(func(_ string, item MemorystoreInstanceSummary) bool {
+		totalCost += item.CurrentRuntimeCost
+		savings += item.Savings
+		return true
+	})
+
+	summary.Message = fmt.Sprintf("Current runtime cost: %s, Savings: %s",
+		style.CostStyle.Render(fmt.Sprintf("%s", utils.FormatPriceFloat(totalCost))), style.SavingStyle.Render(fmt.Sprintf("%s", utils.FormatPriceFloat(savings))))
+	return summary
+}
+
+func (m *MemorystoreProcessor) UpdateSummary(itemId string) {
+	i, ok := m.items.Get(itemId)
+	if ok && i.Wastage != nil && i.Wastage.Rightsizing != nil && i.Wastage.Rightsizing.Recommended != nil {
+		m.summary.Set(itemId, MemorystoreInstanceSummary{
+			CurrentRuntimeCost: i.Wastage.Rightsizing.Current.Cost,
+			Savings:            i.Wastage.Rightsizing.Current.Cost - i.Wastage.Rightsizing.Recommended.Cost,
+		})
+	}
+	m.publishResultSummary(m.ResultsSummary())
+}
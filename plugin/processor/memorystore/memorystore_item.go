@@ -0,0 +1,182 @@
+package memorystore
+
+import (
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	"github.com/kaytu-io/kaytu/pkg/utils"
+	"github.com/opengovern/plugin-gcp/plugin/processor/shared"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/api/redis/v1"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type MemorystoreInstanceItem struct {
+	ProjectId           string
+	Name                string
+	Id                  string
+	Region              string
+	Zone                string
+	Tier                string
+	MemorySizeGb        int64
+	RedisVersion        string
+	OptimizationLoading bool
+	Preferences         []*golang.PreferenceItem
+	Skipped             bool
+	LazyLoadingEnabled  bool
+	SkipReason          string
+	Instance            *redis.Instance
+	Metrics             map[string][]*golang2.DataPoint
+	Wastage             *golang2.GCPMemorystoreOptimizationResponse
+}
+
+func (i MemorystoreInstanceItem) MemorystoreInstanceDevice() (*golang.ChartRow, map[string]*golang.Properties) {
+	row := golang.ChartRow{
+		RowId:  i.Id,
+		Values: make(map[string]*golang.ChartRowItem),
+	}
+
+	row.Values["resource_id"] = &golang.ChartRowItem{
+		Value: i.Id,
+	}
+	row.Values["resource_name"] = &golang.ChartRowItem{
+		Value: i.Name,
+	}
+	row.Values["resource_type"] = &golang.ChartRowItem{
+		Value: "Memorystore Instance",
+	}
+	row.Values["project_id"] = &golang.ChartRowItem{
+		Value: i.ProjectId,
+	}
+
+	RegionProperty := &golang.Property{Key: "Region"}
+	TierProperty := &golang.Property{Key: "Tier"}
+	ReplicaCountProperty := &golang.Property{Key: "Read Replicas"}
+	MemoryProperty := &golang.Property{Key: "  Capacity"}
+	MemoryUsageProperty := &golang.Property{Key: "  Memory Usage Ratio"}
+	CPUProperty := &golang.Property{Key: "  CPU (cores)"}
+	ConnectedClientsProperty := &golang.Property{Key: "  Connected Clients"}
+
+	if i.Wastage != nil && i.Wastage.Rightsizing != nil && i.Wastage.Rightsizing.Current != nil {
+		current := i.Wastage.Rightsizing.Current
+		RegionProperty.Current = current.Region
+		TierProperty.Current = current.Tier
+		ReplicaCountProperty.Current = fmt.Sprintf("%d", current.ReplicaCount)
+		MemoryProperty.Current = fmt.Sprintf("%d GB", current.MemorySizeGb)
+
+		MemoryUsageProperty.Average = utils.Percentage(shared.PWrapperDouble(i.Wastage.Rightsizing.MemoryUsageRatio.GetAvg()))
+		MemoryUsageProperty.Max = utils.Percentage(shared.PWrapperDouble(i.Wastage.Rightsizing.MemoryUsageRatio.GetMax()))
+		CPUProperty.Average = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.Cpu.GetAvg()))
+		CPUProperty.Max = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.Cpu.GetMax()))
+		ConnectedClientsProperty.Average = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.ConnectedClients.GetAvg()))
+		ConnectedClientsProperty.Max = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.ConnectedClients.GetMax()))
+
+		row.Values["current_cost"] = &golang.ChartRowItem{
+			Value: utils.FormatPriceFloat(current.Cost),
+		}
+
+		if recommended := i.Wastage.Rightsizing.Recommended; recommended != nil {
+			row.Values["right_sized_cost"] = &golang.ChartRowItem{
+				Value: utils.FormatPriceFloat(recommended.Cost),
+			}
+			row.Values["savings"] = &golang.ChartRowItem{
+				Value: utils.FormatPriceFloat(current.Cost - recommended.Cost),
+			}
+			RegionProperty.Recommended = recommended.Region
+			TierProperty.Recommended = recommended.Tier
+			ReplicaCountProperty.Recommended = fmt.Sprintf("%d", recommended.ReplicaCount)
+			MemoryProperty.Recommended = fmt.Sprintf("%d GB", recommended.MemorySizeGb)
+		}
+	}
+
+	props := make(map[string]*golang.Properties)
+	properties := &golang.Properties{}
+
+	properties.Properties = append(properties.Properties, RegionProperty)
+	properties.Properties = append(properties.Properties, TierProperty)
+	properties.Properties = append(properties.Properties, ReplicaCountProperty)
+	properties.Properties = append(properties.Properties, &golang.Property{
+		Key: "Memory",
+	})
+	properties.Properties = append(properties.Properties, MemoryProperty)
+	properties.Properties = append(properties.Properties, MemoryUsageProperty)
+	properties.Properties = append(properties.Properties, &golang.Property{
+		Key: "Compute",
+	})
+	properties.Properties = append(properties.Properties, CPUProperty)
+	properties.Properties = append(properties.Properties, ConnectedClientsProperty)
+
+	props[i.Id] = properties
+
+	return &row, props
+}
+
+func (i MemorystoreInstanceItem) Devices() ([]*golang.ChartRow, map[string]*golang.Properties) {
+	instanceRow, instanceProps := i.MemorystoreInstanceDevice()
+
+	return []*golang.ChartRow{instanceRow}, instanceProps
+}
+
+func (i MemorystoreInstanceItem) ToOptimizationItem() *golang.ChartOptimizationItem {
+
+	deviceRows, deviceProps := i.Devices()
+
+	status := ""
+	if i.Skipped {
+		status = fmt.Sprintf("skipped - %s", i.SkipReason)
+	} else if i.LazyLoadingEnabled && !i.OptimizationLoading {
+		status = "press enter to load"
+	} else if i.OptimizationLoading {
+		status = "loading"
+	} else if i.Wastage != nil && i.Wastage.Rightsizing != nil && i.Wastage.Rightsizing.Recommended != nil {
+		totalSaving := i.Wastage.Rightsizing.Current.Cost - i.Wastage.Rightsizing.Recommended.Cost
+		totalCurrentCost := i.Wastage.Rightsizing.Current.Cost
+		status = fmt.Sprintf("%s (%.2f%%)", utils.FormatPriceFloat(totalSaving), (totalSaving/totalCurrentCost)*100)
+	}
+
+	chartrow := &golang.ChartRow{
+		RowId: i.Id,
+		Values: map[string]*golang.ChartRowItem{
+			"x_kaytu_right_arrow": {
+				Value: "→",
+			},
+			"resource_id": {
+				Value: i.Id,
+			},
+			"resource_name": {
+				Value: i.Name,
+			},
+			"resource_type": {
+				Value: fmt.Sprintf("%s %d GB", i.Tier, i.MemorySizeGb),
+			},
+			"region": {
+				Value: i.Region,
+			},
+			"platform": {
+				Value: i.RedisVersion,
+			},
+			"total_saving": {
+				Value: status,
+			},
+		},
+	}
+
+	coi := &golang.ChartOptimizationItem{
+		OverviewChartRow:   chartrow,
+		DevicesChartRows:   deviceRows,
+		DevicesProperties:  deviceProps,
+		Preferences:        i.Preferences,
+		Loading:            i.OptimizationLoading,
+		Skipped:            i.Skipped,
+		SkipReason:         wrapperspb.String(i.SkipReason),
+		LazyLoadingEnabled: i.LazyLoadingEnabled,
+	}
+	if i.Wastage != nil && i.Wastage.Rightsizing != nil {
+		coi.Description = i.Wastage.Rightsizing.Description
+	}
+
+	return coi
+}
+
+func instanceSpec(i *golang2.RightsizingGcpMemorystoreInstance) string {
+	return fmt.Sprintf("%s / %d GB", i.Tier, i.MemorySizeGb)
+}
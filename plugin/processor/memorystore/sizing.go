@@ -0,0 +1,193 @@
+package memorystore
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/kaytu-io/kaytu/preferences"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	TierBasic      = "BASIC"
+	TierStandardHa = "STANDARD_HA"
+
+	monthHours = 730
+
+	minMemorySizeGb = 1
+	maxMemorySizeGb = 300
+	// read replicas need at least 5 GB of capacity
+	minReadReplicasMemorySizeGb = 5
+	maxReplicaCount             = 5
+)
+
+// capacityTier is a Memorystore for Redis capacity tier, the price per GB of an instance drops as its capacity
+// grows. The prices are the us-central1 hourly prices per GB from https://cloud.google.com/memorystore/docs/redis/pricing,
+// other regions cost up to about a third more.
+type capacityTier struct {
+	name string
+	// maxGb is the largest capacity of the tier
+	maxGb    int64
+	basic    float64
+	standard float64
+}
+
+var capacityTiers = []capacityTier{
+	{name: "M1", maxGb: 4, basic: 0.049, standard: 0.064},
+	{name: "M2", maxGb: 10, basic: 0.027, standard: 0.054},
+	{name: "M3", maxGb: 35, basic: 0.023, standard: 0.046},
+	{name: "M4", maxGb: 100, basic: 0.019, standard: 0.038},
+	{name: "M5", maxGb: maxMemorySizeGb, basic: 0.016, standard: 0.032},
+}
+
+func capacityTierOf(memorySizeGb int64) capacityTier {
+	for _, t := range capacityTiers {
+		if memorySizeGb <= t.maxGb {
+			return t
+		}
+	}
+	return capacityTiers[len(capacityTiers)-1]
+}
+
+// instanceCost is the monthly cost of an instance. The Standard tier price covers the primary and one replica,
+// each additional read replica is a node of the same capacity and is billed at the Basic price.
+func instanceCost(tier string, memorySizeGb, replicaCount int64) float64 {
+	t := capacityTierOf(memorySizeGb)
+	if tier != TierStandardHa {
+		return float64(memorySizeGb) * t.basic * monthHours
+	}
+	cost := float64(memorySizeGb) * t.standard * monthHours
+	if replicaCount > 1 {
+		cost += float64(replicaCount-1) * float64(memorySizeGb) * t.basic * monthHours
+	}
+	return cost
+}
+
+func instance(region, tier string, memorySizeGb, replicaCount int64) *golang2.RightsizingGcpMemorystoreInstance {
+	if tier != TierStandardHa {
+		replicaCount = 0
+	}
+	return &golang2.RightsizingGcpMemorystoreInstance{
+		Region:       region,
+		Tier:         tier,
+		MemorySizeGb: memorySizeGb,
+		ReplicaCount: replicaCount,
+		Cost:         instanceCost(tier, memorySizeGb, replicaCount),
+	}
+}
+
+func usage(dps []*golang2.DataPoint) *golang2.Usage {
+	if len(dps) == 0 {
+		return nil
+	}
+	var sum float64
+	maxValue := dps[0].GetValue()
+	for _, dp := range dps {
+		sum += dp.GetValue()
+		maxValue = max(maxValue, dp.GetValue())
+	}
+	return &golang2.Usage{
+		Avg: wrapperspb.Double(sum / float64(len(dps))),
+		Max: wrapperspb.Double(maxValue),
+	}
+}
+
+func numberPreference(prefs map[string]*string, key string, defaultValue float64) float64 {
+	v := prefs[key]
+	if v == nil {
+		return defaultValue
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(*v), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return f
+}
+
+// recommendInstance sizes the capacity of the instance on its peak memory usage plus the memory breathing room.
+// Redis runs commands on a single thread, so the capacity is not reduced while the peak CPU usage with the CPU
+// breathing room reaches a core, the larger capacity tiers get more network throughput.
+func recommendInstance(item MemorystoreInstanceItem) *golang2.GcpMemorystoreInstanceRecommendation {
+	prefs := preferences.Export(item.Preferences)
+
+	var replicaCount int64
+	if item.Instance != nil {
+		replicaCount = item.Instance.ReplicaCount
+	}
+	current := instance(item.Region, item.Tier, item.MemorySizeGb, replicaCount)
+	recommendation := &golang2.GcpMemorystoreInstanceRecommendation{
+		Current:          current,
+		MemoryUsageRatio: usage(item.Metrics["MemoryUsageRatio"]),
+		Cpu:              usage(item.Metrics["CPUUtilization"]),
+		ConnectedClients: usage(item.Metrics["ConnectedClients"]),
+	}
+	if recommendation.MemoryUsageRatio == nil {
+		recommendation.Description = "No memory usage was reported for the instance."
+		return recommendation
+	}
+
+	tier := item.Tier
+	if v := prefs["Tier"]; v != nil && *v != "" {
+		tier = strings.ToUpper(*v)
+	}
+	if v := prefs["ReplicaCount"]; v != nil && *v != "" {
+		replicaCount = int64(numberPreference(prefs, "ReplicaCount", float64(replicaCount)))
+	}
+	replicaCount = min(max(replicaCount, 0), maxReplicaCount)
+
+	memoryBreathingRoom := numberPreference(prefs, "MemoryBreathingRoom", 20) / 100
+	cpuBreathingRoom := numberPreference(prefs, "CPUBreathingRoom", 10) / 100
+
+	usedGb := recommendation.MemoryUsageRatio.GetMax().GetValue() * float64(item.MemorySizeGb)
+	memorySizeGb := int64(math.Ceil(usedGb * (1 + memoryBreathingRoom)))
+	cpuBound := recommendation.Cpu.GetMax().GetValue()*(1+cpuBreathingRoom) >= 1
+	if cpuBound {
+		memorySizeGb = max(memorySizeGb, item.MemorySizeGb)
+	}
+	if v := prefs["MemorySizeGb"]; v != nil && *v != "" {
+		memorySizeGb = int64(numberPreference(prefs, "MemorySizeGb", float64(memorySizeGb)))
+	}
+	memorySizeGb = min(max(memorySizeGb, minMemorySizeGb), maxMemorySizeGb)
+	for _, p := range item.Preferences {
+		if !p.Pinned {
+			continue
+		}
+		switch p.Key {
+		case "Tier":
+			tier = item.Tier
+		case "MemorySizeGb":
+			memorySizeGb = item.MemorySizeGb
+		case "ReplicaCount":
+			replicaCount = current.ReplicaCount
+		}
+	}
+	if tier == TierStandardHa && replicaCount > 1 {
+		memorySizeGb = max(memorySizeGb, minReadReplicasMemorySizeGb)
+	}
+
+	recommended := instance(item.Region, tier, memorySizeGb, replicaCount)
+	if recommended.Cost >= current.Cost-0.01 {
+		recommendation.Description = fmt.Sprintf("The instance peaks at %.1f GB out of %d GB, its capacity fits its usage.",
+			usedGb, item.MemorySizeGb)
+		if cpuBound {
+			recommendation.Description = fmt.Sprintf("The instance peaks at %.0f%% of a core, Redis runs commands on a single thread so its capacity is kept.",
+				recommendation.Cpu.GetMax().GetValue()*100)
+		}
+		return recommendation
+	}
+
+	description := fmt.Sprintf("The instance peaks at %.1f GB out of %d GB, %d GB leaves %.0f%% of breathing room.",
+		usedGb, item.MemorySizeGb, memorySizeGb, memoryBreathingRoom*100)
+	if tier != item.Tier {
+		description += fmt.Sprintf(" Change the tier to %s.", tier)
+	}
+	if tier == TierBasic && item.Tier == TierStandardHa {
+		description += " The Basic tier has no replica, the data is lost when the instance restarts."
+	}
+	recommendation.Recommended = recommended
+	recommendation.Description = description
+	return recommendation
+}
@@ -0,0 +1,96 @@
+package memorystore
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/api/redis/v1"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func testInstance(tier string, memorySizeGb, replicaCount int64, memoryRatio, cpu float64, prefs ...*golang.PreferenceItem) MemorystoreInstanceItem {
+	return MemorystoreInstanceItem{
+		Region:       "us-central1",
+		Tier:         tier,
+		MemorySizeGb: memorySizeGb,
+		Preferences:  prefs,
+		Instance:     &redis.Instance{ReplicaCount: replicaCount},
+		Metrics: map[string][]*golang2.DataPoint{
+			"MemoryUsageRatio": {{Value: memoryRatio / 2}, {Value: memoryRatio}},
+			"CPUUtilization":   {{Value: cpu}},
+		},
+	}
+}
+
+func TestRecommendInstance(t *testing.T) {
+	cases := []struct {
+		name             string
+		item             MemorystoreInstanceItem
+		wantMemorySizeGb int64
+		wantTier         string
+	}{
+		{
+			name:             "over-provisioned instance shrinks to its peak plus breathing room",
+			item:             testInstance(TierStandardHa, 10, 1, 0.3, 0.1),
+			wantMemorySizeGb: 4,
+			wantTier:         TierStandardHa,
+		},
+		{
+			name: "busy CPU keeps the capacity",
+			item: testInstance(TierStandardHa, 10, 1, 0.3, 0.95),
+		},
+		{
+			name:             "read replicas keep the 5 GB minimum",
+			item:             testInstance(TierStandardHa, 20, 3, 0.1, 0.1),
+			wantMemorySizeGb: 5,
+			wantTier:         TierStandardHa,
+		},
+		{
+			name: "tier preference moves to basic",
+			item: testInstance(TierStandardHa, 10, 1, 0.9, 0.1,
+				&golang.PreferenceItem{Key: "Tier", Value: wrapperspb.String(TierBasic)}),
+			wantMemorySizeGb: 11,
+			wantTier:         TierBasic,
+		},
+		{
+			name: "pinned capacity is kept",
+			item: testInstance(TierStandardHa, 10, 1, 0.3, 0.1,
+				&golang.PreferenceItem{Key: "MemorySizeGb", Pinned: true}),
+		},
+		{
+			name: "well sized instance",
+			item: testInstance(TierBasic, 5, 0, 0.8, 0.1),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := recommendInstance(c.item)
+			if c.wantMemorySizeGb == 0 {
+				if r.Recommended != nil {
+					t.Fatalf("expected no recommendation, got %v", r.Recommended)
+				}
+				return
+			}
+			if r.Recommended == nil {
+				t.Fatalf("expected a recommendation: %s", r.Description)
+			}
+			if r.Recommended.MemorySizeGb != c.wantMemorySizeGb || r.Recommended.Tier != c.wantTier {
+				t.Errorf("recommended %s %d GB, want %s %d GB", r.Recommended.Tier, r.Recommended.MemorySizeGb, c.wantTier, c.wantMemorySizeGb)
+			}
+			if r.Recommended.Cost >= r.Current.Cost {
+				t.Errorf("recommended cost %v is not below current cost %v", r.Recommended.Cost, r.Current.Cost)
+			}
+		})
+	}
+}
+
+func TestInstanceCost(t *testing.T) {
+	if got, want := instanceCost(TierBasic, 4, 0), 4*0.049*monthHours; math.Abs(got-want) > 1e-9 {
+		t.Errorf("basic M1 cost = %v, want %v", got, want)
+	}
+	if got, want := instanceCost(TierStandardHa, 10, 3), 10*0.054*monthHours+2*10*0.027*monthHours; math.Abs(got-want) > 1e-9 {
+		t.Errorf("standard M2 with 3 replicas cost = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,6 @@
+package memorystore
+
+type MemorystoreInstanceSummary struct {
+	CurrentRuntimeCost float64
+	Savings            float64
+}
@@ -0,0 +1,35 @@
+package shared
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WriteJson writes the records to the file as a JSON array, or as JSON Lines
+// (one record per line) when the file has the .jsonl extension
+func WriteJson[T any](path string, records []T) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	encoder := json.NewEncoder(f)
+	if strings.EqualFold(filepath.Ext(path), ".jsonl") {
+		for _, record := range records {
+			err = encoder.Encode(record)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if records == nil {
+		records = []T{}
+	}
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
@@ -0,0 +1,54 @@
+package shared
+
+import (
+	"strings"
+
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+)
+
+// SparklineWidth is the number of characters of the sparklines shown in the device properties
+const SparklineWidth = 30
+
+var sparklineBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders the datapoints as a line of block characters, oldest first. The observation window is
+// split in width buckets of equal time, each drawn at the height of its highest datapoint relative to the
+// highest of the window so spikes stay visible. Buckets without datapoints are drawn as spaces.
+func Sparkline(dps []*golang2.DataPoint, width int) string {
+	if len(dps) == 0 || width <= 0 {
+		return ""
+	}
+
+	start, end := dps[0].GetStartTime().GetValue(), dps[0].GetStartTime().GetValue()
+	for _, dp := range dps {
+		start = min(start, dp.GetStartTime().GetValue())
+		end = max(end, dp.GetStartTime().GetValue())
+	}
+
+	buckets := make([]float64, width)
+	filled := make([]bool, width)
+	var highest float64
+	for _, dp := range dps {
+		// every bucket covers the same share of the window, the last datapoint included
+		bucket := int((dp.GetStartTime().GetValue() - start) * int64(width) / (end - start + 1))
+		if !filled[bucket] || dp.GetValue() > buckets[bucket] {
+			buckets[bucket] = dp.GetValue()
+		}
+		filled[bucket] = true
+		highest = max(highest, dp.GetValue())
+	}
+
+	var line strings.Builder
+	for i, value := range buckets {
+		switch {
+		case !filled[i]:
+			line.WriteRune(' ')
+		case highest <= 0:
+			line.WriteRune(sparklineBlocks[0])
+		default:
+			level := int(value / highest * float64(len(sparklineBlocks)-1))
+			line.WriteRune(sparklineBlocks[max(min(level, len(sparklineBlocks)-1), 0)])
+		}
+	}
+	return line.String()
+}
@@ -0,0 +1,37 @@
+package shared
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		want   string
+	}{
+		{name: "empty", width: 4, want: ""},
+		{name: "no width", values: []float64{1}, width: 0, want: ""},
+		{name: "single datapoint", values: []float64{5}, width: 3, want: "█  "},
+		{name: "one datapoint per bucket", values: []float64{0, 1, 2, 3, 4, 5, 6, 7}, width: 8, want: "▁▂▃▄▅▆▇█"},
+		// 8 datapoints in 4 buckets of 2, each drawn at its highest
+		{name: "two datapoints per bucket", values: []float64{7, 0, 0, 1, 0, 3, 0, 7}, width: 4, want: "█▂▄█"},
+		// the last datapoint shares the last bucket instead of getting one of its own
+		{name: "equal buckets", values: []float64{0, 0, 0, 7}, width: 2, want: "▁█"},
+		{name: "more buckets than datapoints", values: []float64{7, 7}, width: 4, want: "█  █"},
+		{name: "zero", values: []float64{0, 0}, width: 2, want: "▁▁"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Sparkline(dataPoints(tt.values...), tt.width)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if got != "" && utf8.RuneCountInString(got) != tt.width {
+				t.Errorf("%d characters, want %d", utf8.RuneCountInString(got), tt.width)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package shared
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Percentile returns the p-th percentile (0-100) of the datapoints, interpolated between the closest ranks
+func Percentile(dps []*golang2.DataPoint, p float64) *float64 {
+	if len(dps) == 0 {
+		return nil
+	}
+	values := make([]float64, 0, len(dps))
+	for _, dp := range dps {
+		values = append(values, dp.GetValue())
+	}
+	sort.Float64s(values)
+
+	rank := p / 100 * float64(len(values)-1)
+	lower, upper := int(math.Floor(rank)), int(math.Ceil(rank))
+	value := values[lower] + (values[upper]-values[lower])*(rank-float64(lower))
+	return &value
+}
+
+// capPercentile returns the percentile the statistic caps the datapoints at, false for Max or an unknown statistic
+func capPercentile(statistic string) (float64, bool) {
+	statistic = strings.ToLower(statistic)
+	if !strings.HasPrefix(statistic, "p") {
+		return 0, false
+	}
+	p, err := strconv.ParseFloat(statistic[1:], 64)
+	if err != nil || p <= 0 || p >= 100 {
+		return 0, false
+	}
+	return p, true
+}
+
+// CapDataPoints caps the datapoints at the percentile chosen by the statistic (e.g. p95), so the spikes above
+// it do not drive the rightsizing. The datapoints are returned as is for Max or an unknown statistic.
+func CapDataPoints(dps []*golang2.DataPoint, statistic string) []*golang2.DataPoint {
+	p, ok := capPercentile(statistic)
+	if !ok {
+		return dps
+	}
+	limit := Percentile(dps, p)
+	if limit == nil {
+		return dps
+	}
+
+	capped := make([]*golang2.DataPoint, 0, len(dps))
+	for _, dp := range dps {
+		capped = append(capped, &golang2.DataPoint{
+			StartTime: dp.GetStartTime(),
+			EndTime:   dp.GetEndTime(),
+			Value:     min(dp.GetValue(), *limit),
+		})
+	}
+	return capped
+}
+
+// AddPercentiles sets the p50, p90, p95 and p99 of the usage from the datapoints. The usage is reported in
+// the units of the rightsizing, which may differ from the datapoints (e.g. a percentage of a ratio), so the
+// percentiles are scaled by the reported maximum over the maximum of the datapoints it was computed from.
+// The sent datapoints are the ones capped by CapDataPoints with the statistic, when it capped them the
+// maximum and average (which the capping lowers) are restored to the ones of the datapoints and the
+// statistic is set on the usage.
+func AddPercentiles(usage *golang2.Usage, dps, sent []*golang2.DataPoint, statistic string) {
+	if usage == nil || len(dps) == 0 {
+		return
+	}
+
+	scale := 1.0
+	if sentMax := Percentile(sent, 100); usage.GetMax() != nil && sentMax != nil && *sentMax != 0 {
+		scale = usage.GetMax().GetValue() / *sentMax
+	}
+
+	if _, capped := capPercentile(statistic); capped && len(sent) > 0 {
+		usage.Statistic = strings.ToLower(statistic)
+		usage.Max = wrapperspb.Double(*Percentile(dps, 100) * scale)
+		var sum float64
+		for _, dp := range dps {
+			sum += dp.GetValue()
+		}
+		usage.Avg = wrapperspb.Double(sum / float64(len(dps)) * scale)
+	}
+
+	for _, p := range []struct {
+		field **wrapperspb.DoubleValue
+		value float64
+	}{
+		{&usage.P50, 50},
+		{&usage.P90, 90},
+		{&usage.P95, 95},
+		{&usage.P99, 99},
+	} {
+		if *p.field == nil {
+			*p.field = wrapperspb.Double(*Percentile(dps, p.value) * scale)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package shared
+
+import (
+	"math"
+	"testing"
+
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// dataPoints returns a datapoint per value, a minute apart
+func dataPoints(values ...float64) []*golang2.DataPoint {
+	var dps []*golang2.DataPoint
+	for i, v := range values {
+		dps = append(dps, &golang2.DataPoint{
+			StartTime: wrapperspb.Int64(int64(i * 60)),
+			EndTime:   wrapperspb.Int64(int64(i*60 + 60)),
+			Value:     v,
+		})
+	}
+	return dps
+}
+
+func TestPercentile(t *testing.T) {
+	value := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name   string
+		values []float64
+		p      float64
+		want   *float64
+	}{
+		{name: "empty", p: 50},
+		{name: "single", values: []float64{7}, p: 95, want: value(7)},
+		{name: "min", values: []float64{3, 1, 2}, p: 0, want: value(1)},
+		{name: "max", values: []float64{3, 1, 2}, p: 100, want: value(3)},
+		{name: "median", values: []float64{4, 1, 3, 2, 5}, p: 50, want: value(3)},
+		{name: "interpolated", values: []float64{10, 20}, p: 25, want: value(12.5)},
+		{name: "p90 of 11", values: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, p: 90, want: value(9)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Percentile(dataPoints(tt.values...), tt.p)
+			switch {
+			case got == nil && tt.want == nil:
+			case got == nil || tt.want == nil:
+				t.Fatalf("got %v, want %v", got, tt.want)
+			case math.Abs(*got-*tt.want) > 1e-9:
+				t.Errorf("got %v, want %v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestCapDataPoints(t *testing.T) {
+	dps := dataPoints(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 100)
+
+	tests := []struct {
+		name      string
+		statistic string
+		wantMax   float64
+		unchanged bool
+	}{
+		{name: "p90", statistic: "p90", wantMax: 9},
+		{name: "upper case", statistic: "P50", wantMax: 5},
+		{name: "max", statistic: "Max", wantMax: 100, unchanged: true},
+		{name: "empty", statistic: "", wantMax: 100, unchanged: true},
+		{name: "p100", statistic: "p100", wantMax: 100, unchanged: true},
+		{name: "invalid", statistic: "pxx", wantMax: 100, unchanged: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			capped := CapDataPoints(dps, tt.statistic)
+			if len(capped) != len(dps) {
+				t.Fatalf("%d datapoints, want %d", len(capped), len(dps))
+			}
+			if tt.unchanged && &capped[0] != &dps[0] {
+				t.Errorf("datapoints were copied for %q", tt.statistic)
+			}
+			if max := *Percentile(capped, 100); max != tt.wantMax {
+				t.Errorf("max %v, want %v", max, tt.wantMax)
+			}
+			for i, dp := range capped {
+				if dp.GetStartTime().GetValue() != dps[i].GetStartTime().GetValue() || dp.GetValue() > dps[i].GetValue() {
+					t.Errorf("datapoint %d is %v, was %v", i, dp.GetValue(), dps[i].GetValue())
+				}
+			}
+		})
+	}
+}
+
+func TestAddPercentiles(t *testing.T) {
+	dps := dataPoints(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 100)
+
+	t.Run("capped", func(t *testing.T) {
+		sent := CapDataPoints(dps, "p90")
+		// the rightsizing reports a percentage of the ratio datapoints
+		usage := &golang2.Usage{Max: wrapperspb.Double(900), Avg: wrapperspb.Double(1)}
+		AddPercentiles(usage, dps, sent, "p90")
+
+		if usage.GetStatistic() != "p90" {
+			t.Errorf("statistic %q, want p90", usage.GetStatistic())
+		}
+		if usage.GetMax().GetValue() != 10000 {
+			t.Errorf("max %v, want the uncapped 10000", usage.GetMax().GetValue())
+		}
+		if math.Abs(usage.GetAvg().GetValue()-14500.0/11) > 1e-9 {
+			t.Errorf("avg %v, want the uncapped %v", usage.GetAvg().GetValue(), 14500.0/11)
+		}
+		if usage.GetP50().GetValue() != 500 || usage.GetP90().GetValue() != 900 {
+			t.Errorf("p50 %v p90 %v, want 500 900", usage.GetP50().GetValue(), usage.GetP90().GetValue())
+		}
+	})
+
+	t.Run("not capped", func(t *testing.T) {
+		// the same datapoints are sent, even when they are copies the usage is left as reported
+		sent := dataPoints(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 100)
+		usage := &golang2.Usage{Max: wrapperspb.Double(100), Avg: wrapperspb.Double(12)}
+		AddPercentiles(usage, dps, sent, "max")
+
+		if usage.GetStatistic() != "" || usage.GetMax().GetValue() != 100 || usage.GetAvg().GetValue() != 12 {
+			t.Errorf("usage changed: %v", usage)
+		}
+		if usage.GetP99().GetValue() != *Percentile(dps, 99) {
+			t.Errorf("p99 %v, want %v", usage.GetP99().GetValue(), *Percentile(dps, 99))
+		}
+	})
+
+	t.Run("no datapoints", func(t *testing.T) {
+		usage := &golang2.Usage{}
+		AddPercentiles(usage, nil, nil, "p95")
+		if usage.GetP50() != nil || usage.GetStatistic() != "" {
+			t.Errorf("usage changed: %v", usage)
+		}
+	})
+}
@@ -0,0 +1,11 @@
+package shared
+
+import "google.golang.org/protobuf/types/known/wrapperspb"
+
+func PWrapperDouble(v *wrapperspb.DoubleValue) *float64 {
+	if v == nil {
+		return nil
+	}
+	value := v.GetValue()
+	return &value
+}
@@ -0,0 +1,208 @@
+package storage_bucket
+
+import (
+	"context"
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"log"
+	"time"
+
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type GetStorageBucketMetricsJob struct {
+	processor *StorageBucketProcessor
+	itemId    string
+}
+
+func NewGetStorageBucketMetricsJob(processor *StorageBucketProcessor, itemId string) *GetStorageBucketMetricsJob {
+	return &GetStorageBucketMetricsJob{
+		processor: processor,
+		itemId:    itemId,
+	}
+}
+
+func (job *GetStorageBucketMetricsJob) Properties() sdk.JobProperties {
+	return sdk.JobProperties{
+		ID:          fmt.Sprintf("get_storage_bucket_metrics_%s", job.itemId),
+		Description: fmt.Sprintf("Get metrics for storage bucket: %s", job.itemId),
+		MaxRetry:    0,
+	}
+}
+
+func (job *GetStorageBucketMetricsJob) Run(ctx context.Context) error {
+	item, ok := job.processor.items.Get(job.itemId)
+	if !ok {
+		return fmt.Errorf("item not found %s", job.itemId)
+	}
+
+	endTime := time.Now()                          // end time of requested time series
+	startTime := endTime.Add(-24 * 30 * time.Hour) // storage size is sampled once a day, so look back a full month
+
+	totalBytesRequest := job.processor.metricProvider.NewTimeSeriesRequest(
+		fmt.Sprintf(
+			`metric.type="%s" AND resource.labels.bucket_name="%s"`,
+			"storage.googleapis.com/storage/total_bytes",
+			item.Name,
+		),
+		&monitoringpb.TimeInterval{
+			EndTime:   timestamppb.New(endTime),
+			StartTime: timestamppb.New(startTime),
+		},
+		&monitoringpb.Aggregation{
+			AlignmentPeriod: &durationpb.Duration{
+				Seconds: 24 * 60 * 60,
+			},
+			PerSeriesAligner: monitoringpb.Aggregation_ALIGN_MEAN, // will represent all the datapoints in the above period, with a mean
+		},
+	)
+
+	// total bytes are reported per storage class, keep them apart so each class can be priced separately
+	totalBytesMetrics, err := job.processor.metricProvider.GetMetricByLabel(ctx, totalBytesRequest, "storage_class")
+	if err != nil {
+		return err
+	}
+
+	objectCountRequest := job.processor.metricProvider.NewTimeSeriesRequest(
+		fmt.Sprintf(
+			`metric.type="%s" AND resource.labels.bucket_name="%s"`,
+			"storage.googleapis.com/storage/object_count",
+			item.Name,
+		),
+		&monitoringpb.TimeInterval{
+			EndTime:   timestamppb.New(endTime),
+			StartTime: timestamppb.New(startTime),
+		},
+		&monitoringpb.Aggregation{
+			AlignmentPeriod: &durationpb.Duration{
+				Seconds: 24 * 60 * 60,
+			},
+			PerSeriesAligner: monitoringpb.Aggregation_ALIGN_MEAN,
+		},
+	)
+
+	// object counts price the operations of moving the objects to another class and the Autoclass fee
+	objectCountMetrics, err := job.processor.metricProvider.GetMetricByLabel(ctx, objectCountRequest, "storage_class")
+	if err != nil {
+		return err
+	}
+
+	requestCountRequest := job.processor.metricProvider.NewTimeSeriesRequest(
+		fmt.Sprintf(
+			`metric.type="%s" AND resource.labels.bucket_name="%s"`,
+			"storage.googleapis.com/api/request_count",
+			item.Name,
+		),
+		&monitoringpb.TimeInterval{
+			EndTime:   timestamppb.New(endTime),
+			StartTime: timestamppb.New(startTime),
+		},
+		&monitoringpb.Aggregation{
+			AlignmentPeriod: &durationpb.Duration{
+				Seconds: 60 * 60,
+			},
+			PerSeriesAligner:   monitoringpb.Aggregation_ALIGN_RATE, // requests per second in the above period
+			CrossSeriesReducer: monitoringpb.Aggregation_REDUCE_SUM,
+			GroupByFields:      []string{"metric.labels.method"},
+		},
+	)
+
+	// request counts are grouped by API method, so class A and class B operations can be told apart
+	requestCountMetrics, err := job.processor.metricProvider.GetMetricByLabel(ctx, requestCountRequest, "method")
+	if err != nil {
+		return err
+	}
+
+	sentBytesRequest := job.processor.metricProvider.NewTimeSeriesRequest(
+		fmt.Sprintf(
+			`metric.type="%s" AND resource.labels.bucket_name="%s"`,
+			"storage.googleapis.com/network/sent_bytes_count",
+			item.Name,
+		),
+		&monitoringpb.TimeInterval{
+			EndTime:   timestamppb.New(endTime),
+			StartTime: timestamppb.New(startTime),
+		},
+		&monitoringpb.Aggregation{
+			AlignmentPeriod: &durationpb.Duration{
+				Seconds: 60 * 60,
+			},
+			PerSeriesAligner:   monitoringpb.Aggregation_ALIGN_RATE, // bytes per second in the above period
+			CrossSeriesReducer: monitoringpb.Aggregation_REDUCE_SUM,
+		},
+	)
+
+	sentBytesMetric, err := job.processor.metricProvider.GetMetric(ctx, sentBytesRequest)
+	if err != nil {
+		return err
+	}
+
+	receivedBytesRequest := job.processor.metricProvider.NewTimeSeriesRequest(
+		fmt.Sprintf(
+			`metric.type="%s" AND resource.labels.bucket_name="%s"`,
+			"storage.googleapis.com/network/received_bytes_count",
+			item.Name,
+		),
+		&monitoringpb.TimeInterval{
+			EndTime:   timestamppb.New(endTime),
+			StartTime: timestamppb.New(startTime),
+		},
+		&monitoringpb.Aggregation{
+			AlignmentPeriod: &durationpb.Duration{
+				Seconds: 60 * 60,
+			},
+			PerSeriesAligner:   monitoringpb.Aggregation_ALIGN_RATE, // bytes per second in the above period
+			CrossSeriesReducer: monitoringpb.Aggregation_REDUCE_SUM,
+		},
+	)
+
+	// the data written per month tells how much of the stored data is younger than a lifecycle rule age
+	receivedBytesMetric, err := job.processor.metricProvider.GetMetric(ctx, receivedBytesRequest)
+	if err != nil {
+		return err
+	}
+
+	storageClassMetrics := make(map[string]map[string][]*golang2.DataPoint)
+	for storageClass, dps := range totalBytesMetrics {
+		storageClassMetrics[storageClass] = map[string][]*golang2.DataPoint{
+			"TotalBytes": dps,
+		}
+	}
+	for storageClass, dps := range objectCountMetrics {
+		if storageClassMetrics[storageClass] == nil {
+			storageClassMetrics[storageClass] = make(map[string][]*golang2.DataPoint)
+		}
+		storageClassMetrics[storageClass]["ObjectCount"] = dps
+	}
+
+	bucketMetrics := make(map[string][]*golang2.DataPoint)
+	for method, dps := range requestCountMetrics {
+		bucketMetrics[fmt.Sprintf("RequestCount/%s", method)] = dps
+	}
+	bucketMetrics["SentBytes"] = sentBytesMetric
+	bucketMetrics["ReceivedBytes"] = receivedBytesMetric
+
+	item.OptimizationLoading = true
+	item.Skipped = false
+	item.SkipReason = "N/A"
+	item.LazyLoadingEnabled = false
+	item.Metrics = bucketMetrics
+	item.StorageClassMetrics = storageClassMetrics
+
+	for c, v := range item.StorageClassMetrics {
+		for k, v := range v {
+			log.Printf("%s %s : %d", c, k, len(v))
+		}
+	}
+
+	job.processor.items.Set(item.Id, item)
+	job.processor.publishOptimizationItem(item.ToOptimizationItem())
+	job.processor.UpdateSummary(item.Id)
+
+	job.processor.jobQueue.Push(NewOptimizeStorageBucketJob(job.processor, item.Id))
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package storage_bucket
+
+import (
+	"context"
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+)
+
+type OptimizeStorageBucketJob struct {
+	processor *StorageBucketProcessor
+	itemId    string
+}
+
+func NewOptimizeStorageBucketJob(processor *StorageBucketProcessor, itemId string) *OptimizeStorageBucketJob {
+	return &OptimizeStorageBucketJob{
+		processor: processor,
+		itemId:    itemId,
+	}
+}
+
+func (job *OptimizeStorageBucketJob) Properties() sdk.JobProperties {
+	return sdk.JobProperties{
+		ID:          fmt.Sprintf("optimize_storage_bucket_%s", job.itemId),
+		Description: fmt.Sprintf("Optimizing %s", job.itemId),
+		MaxRetry:    3,
+	}
+}
+
+func (job *OptimizeStorageBucketJob) Run(ctx context.Context) error {
+	item, ok := job.processor.items.Get(job.itemId)
+	if !ok {
+		return fmt.Errorf("item not found %s", job.itemId)
+	}
+
+	if item.LazyLoadingEnabled {
+		job.processor.jobQueue.Push(NewGetStorageBucketMetricsJob(job.processor, job.itemId))
+		return nil
+	}
+
+	// the storage classes are priced locally, the optimization service has no Cloud Storage support
+	item.OptimizationLoading = false
+	item.Skipped = false
+	item.SkipReason = "N/A"
+	item.LazyLoadingEnabled = false
+	item.Wastage = &golang2.GCPStorageBucketOptimizationResponse{
+		Rightsizing: recommendStorageClass(item),
+	}
+
+	job.processor.items.Set(job.itemId, item)
+	job.processor.publishOptimizationItem(item.ToOptimizationItem())
+	job.processor.UpdateSummary(item.Id)
+
+	return nil
+}
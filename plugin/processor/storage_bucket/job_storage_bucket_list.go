@@ -0,0 +1,71 @@
+package storage_bucket
+
+import (
+	"context"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	"log"
+)
+
+type ListStorageBucketsJob struct {
+	processor *StorageBucketProcessor
+}
+
+func NewListStorageBucketsJob(processor *StorageBucketProcessor) *ListStorageBucketsJob {
+	return &ListStorageBucketsJob{
+		processor: processor,
+	}
+}
+
+func (job *ListStorageBucketsJob) Properties() sdk.JobProperties {
+	return sdk.JobProperties{
+		ID:          "list_storage_buckets",
+		Description: "List all storage buckets in current project",
+		MaxRetry:    0,
+	}
+}
+
+func (job *ListStorageBucketsJob) Run(ctx context.Context) error {
+	log.Println("Running list storage buckets job")
+
+	buckets, err := job.processor.provider.GetAllBuckets(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("# of buckets: %d", len(buckets))
+
+	for _, bucket := range buckets {
+		oi := StorageBucketItem{
+			ProjectId:           job.processor.provider.ProjectID,
+			Name:                bucket.Name,
+			Id:                  bucket.Id,
+			Location:            bucket.Location,
+			LocationType:        bucket.LocationType,
+			StorageClass:        bucket.StorageClass,
+			AutoclassEnabled:    bucket.Autoclass != nil && bucket.Autoclass.Enabled,
+			OptimizationLoading: true,
+			Preferences:         job.processor.defaultPreferences,
+			Skipped:             false,
+			LazyLoadingEnabled:  false,
+			SkipReason:          "NA",
+			Bucket:              bucket,
+			Metrics:             nil,
+			StorageClassMetrics: nil,
+		}
+
+		if !oi.Skipped {
+			job.processor.lazyloadCounter.Add(1)
+			if job.processor.lazyloadCounter.Load() > uint32(1) {
+				oi.LazyLoadingEnabled = true
+			}
+		}
+
+		job.processor.items.Set(oi.Id, oi)
+		job.processor.publishOptimizationItem(oi.ToOptimizationItem())
+		job.processor.UpdateSummary(oi.Id)
+
+		job.processor.jobQueue.Push(NewGetStorageBucketMetricsJob(job.processor, oi.Id))
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package storage_bucket
+
+import (
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
+	"github.com/kaytu-io/kaytu/pkg/style"
+	"github.com/kaytu-io/kaytu/pkg/utils"
+	"github.com/opengovern/plugin-gcp/plugin/gcp"
+	"strings"
+	"sync/atomic"
+)
+
+type StorageBucketProcessor struct {
+	provider                *gcp.Storage
+	metricProvider          *gcp.CloudMonitoring
+	items                   utils.ConcurrentMap[string, StorageBucketItem]
+	publishOptimizationItem func(item *golang.ChartOptimizationItem)
+	publishResultSummary    func(summary *golang.ResultSummary)
+	kaytuAcccessToken       string
+	jobQueue                *sdk.JobQueue
+	lazyloadCounter         atomic.Uint32
+
+	defaultPreferences []*golang.PreferenceItem
+
+	summary utils.ConcurrentMap[string, StorageBucketSummary]
+}
+
+func NewStorageBucketProcessor(
+	prv *gcp.Storage,
+	metricPrv *gcp.CloudMonitoring,
+	publishOptimizationItem func(item *golang.ChartOptimizationItem),
+	publishResultSummary func(summary *golang.ResultSummary),
+	kaytuAcccessToken string,
+	jobQueue *sdk.JobQueue,
+	defaultPreferences []*golang.PreferenceItem,
+) *StorageBucketProcessor {
+	r := &StorageBucketProcessor{
+		provider:                prv,
+		metricProvider:          metricPrv,
+		items:                   utils.NewConcurrentMap[string, StorageBucketItem](),
+		publishOptimizationItem: publishOptimizationItem,
+		publishResultSummary:    publishResultSummary,
+		kaytuAcccessToken:       kaytuAcccessToken,
+		jobQueue:                jobQueue,
+		lazyloadCounter:         atomic.Uint32{},
+		defaultPreferences:      defaultPreferences,
+	}
+
+	jobQueue.Push(NewListStorageBucketsJob(r))
+	return r
+}
+
+func (m *StorageBucketProcessor) ReEvaluate(id string, items []*golang.PreferenceItem) {
+	v, _ := m.items.Get(id)
+	v.Preferences = items
+	m.items.Set(id, v)
+	v.OptimizationLoading = true
+	m.publishOptimizationItem(v.ToOptimizationItem())
+	m.jobQueue.Push(NewOptimizeStorageBucketJob(m, id))
+}
+
+func (m *StorageBucketProcessor) ExportNonInteractive() *golang.NonInteractiveExport {
+	return &golang.NonInteractiveExport{
+		Csv: m.exportCsv(),
+	}
+}
+
+func (m *StorageBucketProcessor) exportCsv() []*golang.CSVRow {
+	headers := []string{
+		"Project ID", "Location", "Resource Type", "Resource ID", "Resource Name", "Platform",
+		"Device Runtime (Hrs)", "Current Cost", "Recommendation Cost", "Net Savings",
+		"Current Spec", "Suggested Spec", "Parent Device", "Justification", "Additional Details",
+	}
+	var rows []*golang.CSVRow
+	rows = append(rows, &golang.CSVRow{Row: headers})
+
+	m.items.Range(func(key string, value StorageBucketItem) bool {
+		if value.Wastage == nil || value.Wastage.Rightsizing == nil || value.Wastage.Rightsizing.Current == nil {
+			return true
+		}
+		var additionalDetails []string
+		var rightSizingCost, saving, recSpec string
+		if value.Wastage.Rightsizing.Recommended != nil {
+			rightSizingCost = utils.FormatPriceFloat(value.Wastage.Rightsizing.Recommended.Cost)
+			saving = utils.FormatPriceFloat(value.Wastage.Rightsizing.Current.Cost - value.Wastage.Rightsizing.Recommended.Cost)
+			recSpec = bucketSpec(value.Wastage.Rightsizing.Recommended)
+
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("Storage Class:: Current: %s - Recommended: %s", value.Wastage.Rightsizing.Current.StorageClass,
+					value.Wastage.Rightsizing.Recommended.StorageClass))
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("Autoclass:: Current: %t - Recommended: %t", value.Wastage.Rightsizing.Current.AutoclassEnabled,
+					value.Wastage.Rightsizing.Recommended.AutoclassEnabled))
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("Lifecycle Rules:: Current: %s - Recommended: %s", lifecycleRulesString(value.Wastage.Rightsizing.Current.LifecycleRules),
+					lifecycleRulesString(value.Wastage.Rightsizing.Recommended.LifecycleRules)))
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("Retrieval Cost:: Current: %s - Recommended: %s", utils.FormatPriceFloat(value.Wastage.Rightsizing.Current.RetrievalCost),
+					utils.FormatPriceFloat(value.Wastage.Rightsizing.Recommended.RetrievalCost)))
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("Retrieval Cost Risk:: %s", utils.FormatPriceFloat(value.Wastage.Rightsizing.RetrievalCostRisk)))
+			additionalDetails = append(additionalDetails,
+				fmt.Sprintf("Transition Cost:: %s once", utils.FormatPriceFloat(value.Wastage.Rightsizing.Recommended.TransitionCost)))
+		}
+		bucketRow := []string{
+			value.ProjectId, value.Location, "Storage Bucket", value.Id, value.Name, "N/A",
+			"730 Hrs", utils.FormatPriceFloat(value.Wastage.Rightsizing.Current.Cost), rightSizingCost, saving,
+			bucketSpec(value.Wastage.Rightsizing.Current), recSpec, "None", value.Wastage.Rightsizing.Description, strings.Join(additionalDetails, "---")}
+
+		rows = append(rows, &golang.CSVRow{Row: bucketRow})
+
+		return true
+	})
+	return rows
+}
+
+func (m *StorageBucketProcessor) ResultsSummary() *golang.ResultSummary {
+	summary := &golang.ResultSummary{}
+	var totalCost, savings float64
+	m.summary.Range(func(_ string, item StorageBucketSummary) bool {
+		totalCost += item.CurrentRuntimeCost
+		savings += item.Savings
+		return true
+	})
+
+	summary.Message = fmt.Sprintf("Current runtime cost: %s, Savings: %s",
+		style.CostStyle.Render(fmt.Sprintf("%s", utils.FormatPriceFloat(totalCost))), style.SavingStyle.Render(fmt.Sprintf("%s", utils.FormatPriceFloat(savings))))
+	return summary
+}
+
+func (m *StorageBucketProcessor) UpdateSummary(itemId string) {
+	i, ok := m.items.Get(itemId)
+	if ok && i.Wastage != nil && i.Wastage.Rightsizing != nil && i.Wastage.Rightsizing.Recommended != nil {
+		m.summary.Set(itemId, StorageBucketSummary{
+			CurrentRuntimeCost: i.Wastage.Rightsizing.Current.Cost,
+			Savings:            i.Wastage.Rightsizing.Current.Cost - i.Wastage.Rightsizing.Recommended.Cost,
+		})
+	}
+	m.publishResultSummary(m.ResultsSummary())
+}
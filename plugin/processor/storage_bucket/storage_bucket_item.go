@@ -0,0 +1,245 @@
+package storage_bucket
+
+import (
+	"fmt"
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	"github.com/kaytu-io/kaytu/pkg/utils"
+	"github.com/opengovern/plugin-gcp/plugin/processor/shared"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/api/storage/v1"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"strings"
+)
+
+type StorageBucketItem struct {
+	ProjectId           string
+	Name                string
+	Id                  string
+	Location            string
+	LocationType        string
+	StorageClass        string
+	AutoclassEnabled    bool
+	OptimizationLoading bool
+	Preferences         []*golang.PreferenceItem
+	Skipped             bool
+	LazyLoadingEnabled  bool
+	SkipReason          string
+	Bucket              *storage.Bucket
+	Metrics             map[string][]*golang2.DataPoint
+	StorageClassMetrics map[string]map[string][]*golang2.DataPoint
+	Wastage             *golang2.GCPStorageBucketOptimizationResponse
+}
+
+func (i StorageBucketItem) StorageBucketDevice() (*golang.ChartRow, map[string]*golang.Properties) {
+	row := golang.ChartRow{
+		RowId:  i.Id,
+		Values: make(map[string]*golang.ChartRowItem),
+	}
+
+	row.Values["resource_id"] = &golang.ChartRowItem{
+		Value: i.Id,
+	}
+	row.Values["resource_name"] = &golang.ChartRowItem{
+		Value: i.Name,
+	}
+	row.Values["resource_type"] = &golang.ChartRowItem{
+		Value: "Storage Bucket",
+	}
+	row.Values["project_id"] = &golang.ChartRowItem{
+		Value: i.ProjectId,
+	}
+
+	LocationProperty := &golang.Property{Key: "Location"}
+	StorageClassProperty := &golang.Property{Key: "Storage Class"}
+	AutoclassProperty := &golang.Property{Key: "Autoclass"}
+	LifecycleRulesProperty := &golang.Property{Key: "Lifecycle Rules"}
+	SizeProperty := &golang.Property{Key: "  Size"}
+	RequestCountProperty := &golang.Property{Key: "  Requests (per second)"}
+	SentBytesProperty := &golang.Property{Key: "  Retrieved Data (bytes per second)"}
+	StorageCostProperty := &golang.Property{Key: "  Storage Cost"}
+	OperationsCostProperty := &golang.Property{Key: "  Operations Cost"}
+	RetrievalCostProperty := &golang.Property{Key: "  Retrieval Cost"}
+	AutoclassCostProperty := &golang.Property{Key: "  Autoclass Management Cost"}
+	TransitionCostProperty := &golang.Property{Key: "  Transition Cost (once)"}
+
+	if i.Wastage != nil && i.Wastage.Rightsizing != nil && i.Wastage.Rightsizing.Current != nil {
+		current := i.Wastage.Rightsizing.Current
+		LocationProperty.Current = current.Location
+		StorageClassProperty.Current = current.StorageClass
+		AutoclassProperty.Current = enabledString(current.AutoclassEnabled)
+		LifecycleRulesProperty.Current = lifecycleRulesString(current.LifecycleRules)
+
+		SizeProperty.Current = fmt.Sprintf("%.2f GB", current.SizeGb)
+		if avg := shared.PWrapperDouble(i.Wastage.Rightsizing.TotalBytes.GetAvg()); avg != nil {
+			SizeProperty.Average = fmt.Sprintf("%.2f GB", *avg/(1024*1024*1024))
+		}
+		if max := shared.PWrapperDouble(i.Wastage.Rightsizing.TotalBytes.GetMax()); max != nil {
+			SizeProperty.Max = fmt.Sprintf("%.2f GB", *max/(1024*1024*1024))
+		}
+		RequestCountProperty.Average = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.RequestCount.GetAvg()))
+		RequestCountProperty.Max = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.RequestCount.GetMax()))
+		SentBytesProperty.Average = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.SentBytes.GetAvg()))
+		SentBytesProperty.Max = utils.PFloat64ToString(shared.PWrapperDouble(i.Wastage.Rightsizing.SentBytes.GetMax()))
+
+		StorageCostProperty.Current = utils.FormatPriceFloat(current.StorageCost)
+		OperationsCostProperty.Current = utils.FormatPriceFloat(current.OperationsCost)
+		RetrievalCostProperty.Current = utils.FormatPriceFloat(current.RetrievalCost)
+		AutoclassCostProperty.Current = utils.FormatPriceFloat(current.AutoclassCost)
+
+		row.Values["current_cost"] = &golang.ChartRowItem{
+			Value: utils.FormatPriceFloat(current.Cost),
+		}
+
+		if recommended := i.Wastage.Rightsizing.Recommended; recommended != nil {
+			row.Values["right_sized_cost"] = &golang.ChartRowItem{
+				Value: utils.FormatPriceFloat(recommended.Cost),
+			}
+			row.Values["savings"] = &golang.ChartRowItem{
+				Value: utils.FormatPriceFloat(current.Cost - recommended.Cost),
+			}
+			LocationProperty.Recommended = recommended.Location
+			StorageClassProperty.Recommended = recommended.StorageClass
+			AutoclassProperty.Recommended = enabledString(recommended.AutoclassEnabled)
+			LifecycleRulesProperty.Recommended = lifecycleRulesString(recommended.LifecycleRules)
+			StorageCostProperty.Recommended = utils.FormatPriceFloat(recommended.StorageCost)
+			OperationsCostProperty.Recommended = utils.FormatPriceFloat(recommended.OperationsCost)
+			RetrievalCostProperty.Recommended = utils.FormatPriceFloat(recommended.RetrievalCost)
+			AutoclassCostProperty.Recommended = utils.FormatPriceFloat(recommended.AutoclassCost)
+			TransitionCostProperty.Recommended = utils.FormatPriceFloat(recommended.TransitionCost)
+		}
+	}
+
+	props := make(map[string]*golang.Properties)
+	properties := &golang.Properties{}
+
+	properties.Properties = append(properties.Properties, LocationProperty)
+	properties.Properties = append(properties.Properties, StorageClassProperty)
+	properties.Properties = append(properties.Properties, AutoclassProperty)
+	properties.Properties = append(properties.Properties, LifecycleRulesProperty)
+	properties.Properties = append(properties.Properties, &golang.Property{
+		Key: "Usage",
+	})
+	properties.Properties = append(properties.Properties, SizeProperty)
+	properties.Properties = append(properties.Properties, RequestCountProperty)
+	properties.Properties = append(properties.Properties, SentBytesProperty)
+	properties.Properties = append(properties.Properties, &golang.Property{
+		Key: "Cost",
+	})
+	properties.Properties = append(properties.Properties, StorageCostProperty)
+	properties.Properties = append(properties.Properties, OperationsCostProperty)
+	properties.Properties = append(properties.Properties, RetrievalCostProperty)
+	properties.Properties = append(properties.Properties, AutoclassCostProperty)
+	properties.Properties = append(properties.Properties, TransitionCostProperty)
+	if i.Wastage != nil && i.Wastage.Rightsizing != nil && i.Wastage.Rightsizing.RetrievalCostRisk > 0 {
+		properties.Properties = append(properties.Properties, &golang.Property{
+			Key:     "Retrieval Cost Risk",
+			Current: utils.FormatPriceFloat(i.Wastage.Rightsizing.RetrievalCostRisk),
+		})
+	}
+
+	props[i.Id] = properties
+
+	return &row, props
+}
+
+func (i StorageBucketItem) Devices() ([]*golang.ChartRow, map[string]*golang.Properties) {
+	bucketRow, bucketProps := i.StorageBucketDevice()
+
+	return []*golang.ChartRow{bucketRow}, bucketProps
+}
+
+func (i StorageBucketItem) ToOptimizationItem() *golang.ChartOptimizationItem {
+
+	deviceRows, deviceProps := i.Devices()
+
+	status := ""
+	if i.Skipped {
+		status = fmt.Sprintf("skipped - %s", i.SkipReason)
+	} else if i.LazyLoadingEnabled && !i.OptimizationLoading {
+		status = "press enter to load"
+	} else if i.OptimizationLoading {
+		status = "loading"
+	} else if i.Wastage != nil && i.Wastage.Rightsizing != nil && i.Wastage.Rightsizing.Recommended != nil {
+		totalSaving := i.Wastage.Rightsizing.Current.Cost - i.Wastage.Rightsizing.Recommended.Cost
+		totalCurrentCost := i.Wastage.Rightsizing.Current.Cost
+		status = fmt.Sprintf("%s (%.2f%%)", utils.FormatPriceFloat(totalSaving), (totalSaving/totalCurrentCost)*100)
+	}
+
+	chartrow := &golang.ChartRow{
+		RowId: i.Id,
+		Values: map[string]*golang.ChartRowItem{
+			"x_kaytu_right_arrow": {
+				Value: "→",
+			},
+			"resource_id": {
+				Value: i.Id,
+			},
+			"resource_name": {
+				Value: i.Name,
+			},
+			"resource_type": {
+				Value: i.StorageClass,
+			},
+			"region": {
+				Value: i.Location,
+			},
+			"platform": {
+				Value: i.LocationType,
+			},
+			"total_saving": {
+				Value: status,
+			},
+		},
+	}
+
+	coi := &golang.ChartOptimizationItem{
+		OverviewChartRow:   chartrow,
+		DevicesChartRows:   deviceRows,
+		DevicesProperties:  deviceProps,
+		Preferences:        i.Preferences,
+		Loading:            i.OptimizationLoading,
+		Skipped:            i.Skipped,
+		SkipReason:         wrapperspb.String(i.SkipReason),
+		LazyLoadingEnabled: i.LazyLoadingEnabled,
+	}
+	if i.Wastage != nil && i.Wastage.Rightsizing != nil {
+		coi.Description = i.Wastage.Rightsizing.Description
+	}
+
+	return coi
+}
+
+func bucketSpec(b *golang2.RightsizingGcpStorageBucket) string {
+	if b.AutoclassEnabled {
+		return fmt.Sprintf("%s / Autoclass", b.StorageClass)
+	}
+	if len(b.LifecycleRules) > 0 {
+		return fmt.Sprintf("%s / %d lifecycle rules", b.StorageClass, len(b.LifecycleRules))
+	}
+	return b.StorageClass
+}
+
+func lifecycleRulesString(rules []*golang2.GcpStorageLifecycleRule) string {
+	if len(rules) == 0 {
+		return "None"
+	}
+	var rs []string
+	for _, r := range rules {
+		rule := r.Action
+		if r.StorageClass != "" {
+			rule = fmt.Sprintf("%s to %s", rule, r.StorageClass)
+		}
+		if r.AgeDays != nil {
+			rule = fmt.Sprintf("%s after %d days", rule, r.AgeDays.GetValue())
+		}
+		rs = append(rs, rule)
+	}
+	return strings.Join(rs, ", ")
+}
+
+func enabledString(enabled bool) string {
+	if enabled {
+		return "Enabled"
+	}
+	return "Disabled"
+}
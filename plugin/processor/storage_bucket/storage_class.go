@@ -0,0 +1,466 @@
+package storage_bucket
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kaytu-io/kaytu/pkg/utils"
+	"github.com/kaytu-io/kaytu/preferences"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	StorageClassStandard = "STANDARD"
+	StorageClassNearline = "NEARLINE"
+	StorageClassColdline = "COLDLINE"
+	StorageClassArchive  = "ARCHIVE"
+
+	monthSeconds = 730 * 60 * 60
+	monthDays    = float64(monthSeconds) / (24 * 60 * 60)
+	gb           = 1024 * 1024 * 1024
+
+	// autoclassManagementPrice is the monthly Autoclass management fee per 1,000 objects
+	autoclassManagementPrice = 0.0025
+	// transitionPaybackMonths is the period the one-time cost of moving the existing objects to another class
+	// is spread over, the move has to pay for itself within it to be recommended
+	transitionPaybackMonths = 12
+)
+
+var storageClasses = []string{StorageClassStandard, StorageClassNearline, StorageClassColdline, StorageClassArchive}
+
+// storageClassPrice is the monthly list price of a storage class, from https://cloud.google.com/storage/pricing.
+// The prices are the ones of us-central1 for regions, of US for multi-regions and of NAM4 for dual-regions,
+// the cheapest location of each type, other locations cost up to about a third more.
+type storageClassPrice struct {
+	// per GB stored
+	storage float64
+	// per 10,000 class A (writes, lists) and class B (reads) operations
+	classAOperations float64
+	classBOperations float64
+	// per GB read
+	retrieval float64
+	// minimum days an object is billed for, deleting or moving it earlier is billed as if it was kept
+	minStorageDays int64
+}
+
+var storageClassPrices = map[string]map[string]storageClassPrice{
+	"region": {
+		StorageClassStandard: {storage: 0.020, classAOperations: 0.05, classBOperations: 0.004},
+		StorageClassNearline: {storage: 0.010, classAOperations: 0.10, classBOperations: 0.01, retrieval: 0.01, minStorageDays: 30},
+		StorageClassColdline: {storage: 0.004, classAOperations: 0.20, classBOperations: 0.10, retrieval: 0.02, minStorageDays: 90},
+		StorageClassArchive:  {storage: 0.0012, classAOperations: 0.50, classBOperations: 0.50, retrieval: 0.05, minStorageDays: 365},
+	},
+	"dual-region": {
+		StorageClassStandard: {storage: 0.022, classAOperations: 0.10, classBOperations: 0.004},
+		StorageClassNearline: {storage: 0.011, classAOperations: 0.20, classBOperations: 0.01, retrieval: 0.01, minStorageDays: 30},
+		StorageClassColdline: {storage: 0.0045, classAOperations: 0.40, classBOperations: 0.10, retrieval: 0.02, minStorageDays: 90},
+		StorageClassArchive:  {storage: 0.0014, classAOperations: 1.00, classBOperations: 0.50, retrieval: 0.05, minStorageDays: 365},
+	},
+	"multi-region": {
+		StorageClassStandard: {storage: 0.026, classAOperations: 0.10, classBOperations: 0.004},
+		StorageClassNearline: {storage: 0.015, classAOperations: 0.20, classBOperations: 0.01, retrieval: 0.01, minStorageDays: 30},
+		StorageClassColdline: {storage: 0.007, classAOperations: 0.40, classBOperations: 0.10, retrieval: 0.02, minStorageDays: 90},
+		StorageClassArchive:  {storage: 0.0024, classAOperations: 1.00, classBOperations: 0.50, retrieval: 0.05, minStorageDays: 365},
+	},
+}
+
+// autoclassMinAccessedFraction is the part of the stored data Autoclass keeps in Standard when the bucket is
+// barely read
+const autoclassMinAccessedFraction = 0.1
+
+// autoclassStandardDays are the days Autoclass keeps objects in Standard after they are written or read
+const autoclassStandardDays = 30
+
+// colder reports whether the storage class has a lower storage price than the other one, lifecycle rules
+// only move objects to colder classes
+func colder(storageClass, other string) bool {
+	return slices.Index(storageClasses, storageClass) > slices.Index(storageClasses, other)
+}
+
+func classPrice(locationType, storageClass string) storageClassPrice {
+	prices, ok := storageClassPrices[strings.ToLower(locationType)]
+	if !ok {
+		prices = storageClassPrices["region"]
+	}
+	price, ok := prices[strings.ToUpper(storageClass)]
+	if !ok {
+		return prices[StorageClassStandard]
+	}
+	return price
+}
+
+// bucketUsage is the monthly usage of a bucket the storage classes are priced on
+type bucketUsage struct {
+	// sizeGb is the latest size of the bucket per storage class
+	sizeGb map[string]float64
+	// objects is the latest object count of the bucket per storage class
+	objects map[string]float64
+	// class A and class B operations per month, deletes are free
+	classAOperations float64
+	classBOperations float64
+	// retrievedGb and writtenGb are the data read from and written to the bucket per month
+	retrievedGb float64
+	writtenGb   float64
+}
+
+func (u bucketUsage) totalSizeGb() float64 {
+	var total float64
+	for _, size := range u.sizeGb {
+		total += size
+	}
+	return total
+}
+
+func (u bucketUsage) totalObjects() float64 {
+	var total float64
+	for _, objects := range u.objects {
+		total += objects
+	}
+	return total
+}
+
+// youngFraction is the part of the stored data written in the last days, from the data written per month.
+// The written data is assumed to be kept, so it is an upper bound when objects are replaced.
+func (u bucketUsage) youngFraction(days float64) float64 {
+	total := u.totalSizeGb()
+	if total <= 0 {
+		return 0
+	}
+	return min(u.writtenGb*days/monthDays/total, 1)
+}
+
+// operationClass returns the billing class (A, B or free) of a Cloud Storage API method
+func operationClass(method string) string {
+	switch {
+	case strings.HasPrefix(method, "Delete"):
+		return ""
+	case strings.HasPrefix(method, "Write"), strings.HasPrefix(method, "List"), strings.HasPrefix(method, "Compose"),
+		strings.HasPrefix(method, "Rewrite"), strings.HasPrefix(method, "Copy"), strings.HasPrefix(method, "Insert"),
+		strings.HasPrefix(method, "Update"), strings.HasPrefix(method, "Patch"), strings.HasPrefix(method, "Create"),
+		strings.HasPrefix(method, "Set"), strings.HasPrefix(method, "Restore"):
+		return "A"
+	}
+	return "B"
+}
+
+func meanValue(dps []*golang2.DataPoint) float64 {
+	if len(dps) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, dp := range dps {
+		sum += dp.GetValue()
+	}
+	return sum / float64(len(dps))
+}
+
+func latestValue(dps []*golang2.DataPoint) float64 {
+	var latest *golang2.DataPoint
+	for _, dp := range dps {
+		if latest == nil || dp.GetEndTime().GetValue() > latest.GetEndTime().GetValue() {
+			latest = dp
+		}
+	}
+	return latest.GetValue()
+}
+
+// usageStats returns the average and maximum of the datapoints
+func usageStats(dps []*golang2.DataPoint) *golang2.Usage {
+	if len(dps) == 0 {
+		return nil
+	}
+	usage := &golang2.Usage{Avg: wrapperspb.Double(meanValue(dps)), Max: wrapperspb.Double(dps[0].GetValue())}
+	for _, dp := range dps {
+		usage.Max = wrapperspb.Double(max(usage.Max.GetValue(), dp.GetValue()))
+	}
+	return usage
+}
+
+// sumByTime adds up the datapoints of several series ending at the same time
+func sumByTime(series map[string][]*golang2.DataPoint) []*golang2.DataPoint {
+	sums := make(map[int64]*golang2.DataPoint)
+	for _, dps := range series {
+		for _, dp := range dps {
+			end := dp.GetEndTime().GetValue()
+			if sums[end] == nil {
+				sums[end] = &golang2.DataPoint{StartTime: dp.GetStartTime(), EndTime: dp.GetEndTime()}
+			}
+			sums[end].Value += dp.GetValue()
+		}
+	}
+	var dps []*golang2.DataPoint
+	for _, dp := range sums {
+		dps = append(dps, dp)
+	}
+	sort.Slice(dps, func(i, j int) bool {
+		return dps[i].GetEndTime().GetValue() < dps[j].GetEndTime().GetValue()
+	})
+	return dps
+}
+
+// newBucketUsage reads the monthly usage of the bucket from its metrics, the request and sent bytes rates are
+// per second
+func newBucketUsage(item StorageBucketItem) bucketUsage {
+	usage := bucketUsage{sizeGb: make(map[string]float64), objects: make(map[string]float64)}
+	for storageClass, metrics := range item.StorageClassMetrics {
+		if dps := metrics["TotalBytes"]; len(dps) > 0 {
+			usage.sizeGb[strings.ToUpper(storageClass)] = latestValue(dps) / gb
+		}
+		if dps := metrics["ObjectCount"]; len(dps) > 0 {
+			usage.objects[strings.ToUpper(storageClass)] = latestValue(dps)
+		}
+	}
+	for key, dps := range item.Metrics {
+		method, ok := strings.CutPrefix(key, "RequestCount/")
+		if !ok {
+			continue
+		}
+		switch operationClass(method) {
+		case "A":
+			usage.classAOperations += meanValue(dps) * monthSeconds
+		case "B":
+			usage.classBOperations += meanValue(dps) * monthSeconds
+		}
+	}
+	usage.retrievedGb = meanValue(item.Metrics["SentBytes"]) * monthSeconds / gb
+	usage.writtenGb = meanValue(item.Metrics["ReceivedBytes"]) * monthSeconds / gb
+	return usage
+}
+
+// bucketCost prices the usage with all the data in the storage class, or in the storage classes it is
+// currently in when storageClass is empty. Operations are billed at the rates of the class of the objects
+// they access, the default class of the bucket is used for them.
+func bucketCost(locationType, storageClass, defaultClass string, autoclass bool, usage bucketUsage) *golang2.RightsizingGcpStorageBucket {
+	bucket := &golang2.RightsizingGcpStorageBucket{
+		StorageClass:     defaultClass,
+		AutoclassEnabled: autoclass,
+		SizeGb:           usage.totalSizeGb(),
+	}
+	if storageClass != "" {
+		bucket.StorageClass = storageClass
+		bucket.StorageCost = bucket.SizeGb * classPrice(locationType, storageClass).storage
+	} else {
+		for class, size := range usage.sizeGb {
+			bucket.StorageCost += size * classPrice(locationType, class).storage
+		}
+	}
+
+	operationsClass := bucket.StorageClass
+	if autoclass {
+		// Autoclass bills all operations at the Standard rates and has no retrieval fees
+		operationsClass = StorageClassStandard
+	}
+	price := classPrice(locationType, operationsClass)
+	bucket.OperationsCost = usage.classAOperations/10000*price.classAOperations + usage.classBOperations/10000*price.classBOperations
+	if autoclass {
+		bucket.AutoclassCost = usage.totalObjects() / 1000 * autoclassManagementPrice
+	} else {
+		bucket.RetrievalCost = usage.retrievedGb * price.retrieval
+	}
+	setCost(bucket)
+	return bucket
+}
+
+// setCost sets the monthly cost of the bucket, with the one-time transition cost spread over the payback period
+func setCost(bucket *golang2.RightsizingGcpStorageBucket) {
+	bucket.Cost = bucket.StorageCost + bucket.OperationsCost + bucket.RetrievalCost + bucket.AutoclassCost +
+		bucket.TransitionCost/transitionPaybackMonths
+}
+
+// classCost prices the bucket with its objects moved to the storage class. With a lifecycle rule (ageDays set)
+// only the objects of the default class older than ageDays move, the younger ones stay in their class and
+// move later. Otherwise all the objects are rewritten to the class now, and the objects younger than the
+// minimum storage duration of their class are billed early deletion charges. Each object moved is a class A
+// operation at the rate of the new class.
+func classCost(item StorageBucketItem, usage bucketUsage, storageClass string, ageDays int64) *golang2.RightsizingGcpStorageBucket {
+	bucket := bucketCost(item.LocationType, storageClass, item.StorageClass, false, usage)
+	price := classPrice(item.LocationType, storageClass)
+	if ageDays > 0 {
+		from := classPrice(item.LocationType, item.StorageClass)
+		old := 1 - usage.youngFraction(float64(ageDays))
+		movedGb := usage.sizeGb[item.StorageClass] * old
+		bucket.StorageCost = 0
+		for class, size := range usage.sizeGb {
+			bucket.StorageCost += size * classPrice(item.LocationType, class).storage
+		}
+		bucket.StorageCost += movedGb * (price.storage - from.storage)
+		// the objects written since move once they are old enough
+		bucket.OperationsCost += usage.objects[item.StorageClass] * usage.youngFraction(monthDays) / 10000 * price.classAOperations
+		bucket.TransitionCost = usage.objects[item.StorageClass]*old/10000*price.classAOperations +
+			movedGb*from.storage*float64(max(from.minStorageDays-ageDays, 0))/monthDays
+	} else {
+		for class, size := range usage.sizeGb {
+			if class == storageClass {
+				continue
+			}
+			from := classPrice(item.LocationType, class)
+			// the objects younger than the minimum storage duration are billed half of it on average
+			bucket.TransitionCost += usage.objects[class]/10000*price.classAOperations +
+				size*usage.youngFraction(float64(from.minStorageDays))*from.storage*float64(from.minStorageDays)/2/monthDays
+		}
+	}
+	setCost(bucket)
+	return bucket
+}
+
+// lifecycleAgeDays is the age the objects are moved to the storage class at by a lifecycle rule, once they are
+// older than its minimum storage duration so objects still being replaced are not billed early deletion charges
+func lifecycleAgeDays(locationType, storageClass string) int64 {
+	return max(classPrice(locationType, storageClass).minStorageDays, 30)
+}
+
+// autoclassCost estimates the cost of the bucket with Autoclass, which moves the objects not read or written
+// for 30 days to Nearline. The data read in a month or written in the last 30 days is assumed to stay in
+// Standard, the rest moves to Nearline. Autoclass transitions have no operation or early deletion charges,
+// the objects are billed the monthly management fee instead.
+func autoclassCost(locationType string, usage bucketUsage) *golang2.RightsizingGcpStorageBucket {
+	bucket := bucketCost(locationType, StorageClassStandard, StorageClassStandard, true, usage)
+	if bucket.SizeGb <= 0 {
+		return bucket
+	}
+	accessed := min(max(usage.retrievedGb/bucket.SizeGb, usage.youngFraction(autoclassStandardDays), autoclassMinAccessedFraction), 1)
+	bucket.StorageCost = bucket.SizeGb * (accessed*classPrice(locationType, StorageClassStandard).storage +
+		(1-accessed)*classPrice(locationType, StorageClassNearline).storage)
+	setCost(bucket)
+	return bucket
+}
+
+func preferenceValue(prefs map[string]*string, key string) string {
+	if v := prefs[key]; v != nil {
+		return *v
+	}
+	return ""
+}
+
+// recommendStorageClass compares the cost of the bucket in its current storage classes with its data moved to
+// each storage class, or with Autoclass, and recommends the cheapest including the cost of moving the objects.
+// Moving the existing objects to a colder class is done with a lifecycle rule, the default class of the bucket
+// only applies to new objects. The retrieval cost risk is the cost of reading the whole bucket once at the
+// recommended class.
+func recommendStorageClass(item StorageBucketItem) *golang2.GcpStorageBucketRecommendation {
+	usage := newBucketUsage(item)
+	prefs := preferences.Export(item.Preferences)
+
+	var lifecycleRules []*golang2.GcpStorageLifecycleRule
+	if item.Bucket != nil && item.Bucket.Lifecycle != nil {
+		for _, rule := range item.Bucket.Lifecycle.Rule {
+			if rule.Action == nil {
+				continue
+			}
+			r := &golang2.GcpStorageLifecycleRule{
+				Action:       rule.Action.Type,
+				StorageClass: rule.Action.StorageClass,
+			}
+			if rule.Condition != nil {
+				if rule.Condition.Age != nil {
+					r.AgeDays = wrapperspb.Int64(*rule.Condition.Age)
+				}
+				r.MatchesStorageClass = rule.Condition.MatchesStorageClass
+			}
+			lifecycleRules = append(lifecycleRules, r)
+		}
+	}
+
+	current := bucketCost(item.LocationType, "", item.StorageClass, item.AutoclassEnabled, usage)
+	current.Location = item.Location
+	current.LifecycleRules = lifecycleRules
+
+	totalBytes := make(map[string][]*golang2.DataPoint)
+	for storageClass, metrics := range item.StorageClassMetrics {
+		totalBytes[storageClass] = metrics["TotalBytes"]
+	}
+	requestCounts := make(map[string][]*golang2.DataPoint)
+	for key, dps := range item.Metrics {
+		if strings.HasPrefix(key, "RequestCount/") {
+			requestCounts[key] = dps
+		}
+	}
+	recommendation := &golang2.GcpStorageBucketRecommendation{
+		Current:      current,
+		TotalBytes:   usageStats(sumByTime(totalBytes)),
+		RequestCount: usageStats(sumByTime(requestCounts)),
+		SentBytes:    usageStats(item.Metrics["SentBytes"]),
+	}
+	if current.SizeGb <= 0 {
+		recommendation.Description = "The bucket is empty."
+		return recommendation
+	}
+
+	classes := storageClasses
+	if storageClass := strings.ToUpper(preferenceValue(prefs, "StorageClass")); storageClass != "" {
+		classes = []string{storageClass}
+	}
+	for _, p := range item.Preferences {
+		if p.Key == "StorageClass" && p.Pinned {
+			classes = []string{item.StorageClass}
+		}
+	}
+	excludeRetrievalRisk := preferenceValue(prefs, "ExcludeRetrievalRisk") == "Yes"
+	useLifecycleRules := preferenceValue(prefs, "ExcludeLifecycleRules") != "Yes"
+
+	var best *golang2.RightsizingGcpStorageBucket
+	for _, storageClass := range classes {
+		if excludeRetrievalRisk && classPrice(item.LocationType, storageClass).retrieval > 0 {
+			continue
+		}
+		var ageDays int64
+		if useLifecycleRules && colder(storageClass, item.StorageClass) {
+			ageDays = lifecycleAgeDays(item.LocationType, storageClass)
+		}
+		candidate := classCost(item, usage, storageClass, ageDays)
+		if best == nil || candidate.Cost < best.Cost {
+			best = candidate
+		}
+	}
+	if !item.AutoclassEnabled && preferenceValue(prefs, "ExcludeAutoclass") != "Yes" && len(classes) == len(storageClasses) {
+		if candidate := autoclassCost(item.LocationType, usage); best == nil || candidate.Cost < best.Cost {
+			best = candidate
+		}
+	}
+	if best == nil || best.Cost >= current.Cost-0.01 {
+		recommendation.Description = "The bucket is already in its cheapest storage class for its usage."
+		return recommendation
+	}
+
+	best.Location = item.Location
+	best.LifecycleRules = lifecycleRules
+	target := best.StorageClass
+	price := classPrice(item.LocationType, target)
+	description := ""
+	if item.AutoclassEnabled {
+		// lifecycle rules changing the storage class can not be used with Autoclass
+		description = "Disable Autoclass. "
+	}
+	switch {
+	case best.AutoclassEnabled:
+		description = fmt.Sprintf("Enable Autoclass, %.1f GB of the %.1f GB stored are read per month so most objects move to Nearline.",
+			usage.retrievedGb, best.SizeGb)
+	case target == item.StorageClass:
+		description += fmt.Sprintf("Keep the objects in %s.", target)
+	case useLifecycleRules && colder(target, item.StorageClass):
+		// existing objects only move with a lifecycle rule
+		ageDays := lifecycleAgeDays(item.LocationType, target)
+		best.StorageClass = item.StorageClass
+		best.LifecycleRules = append(best.LifecycleRules, &golang2.GcpStorageLifecycleRule{
+			Action:              "SetStorageClass",
+			StorageClass:        target,
+			AgeDays:             wrapperspb.Int64(ageDays),
+			MatchesStorageClass: []string{item.StorageClass},
+		})
+		description += fmt.Sprintf("Move the objects older than %d days to %s with a lifecycle rule, %.1f GB are read per month out of %.1f GB stored.",
+			ageDays, target, usage.retrievedGb, best.SizeGb)
+	default:
+		description += fmt.Sprintf("Change the storage class to %s, %.1f GB are read per month out of %.1f GB stored. The default class only applies to new objects, existing objects have to be rewritten.",
+			target, usage.retrievedGb, best.SizeGb)
+	}
+	if best.TransitionCost > 0 {
+		description += fmt.Sprintf(" Moving the objects costs %s once.", utils.FormatPriceFloat(best.TransitionCost))
+	}
+	recommendation.Recommended = best
+	recommendation.RetrievalCostRisk = best.SizeGb * price.retrieval
+	recommendation.Description = description
+	return recommendation
+}
@@ -0,0 +1,173 @@
+package storage_bucket
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
+	golang2 "github.com/opengovern/plugin-gcp/plugin/proto/src/golang/gcp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func dataPoints(values ...float64) []*golang2.DataPoint {
+	var dps []*golang2.DataPoint
+	for i, v := range values {
+		dps = append(dps, &golang2.DataPoint{
+			StartTime: wrapperspb.Int64(int64(i) * 60),
+			EndTime:   wrapperspb.Int64(int64(i+1) * 60),
+			Value:     v,
+		})
+	}
+	return dps
+}
+
+func standardBucket(sizeGb, sentGbPerMonth float64, prefs ...*golang.PreferenceItem) StorageBucketItem {
+	return StorageBucketItem{
+		Location:     "US-CENTRAL1",
+		LocationType: "region",
+		StorageClass: StorageClassStandard,
+		Preferences:  prefs,
+		Metrics: map[string][]*golang2.DataPoint{
+			"SentBytes": dataPoints(sentGbPerMonth*gb/monthSeconds, sentGbPerMonth*gb/monthSeconds),
+		},
+		StorageClassMetrics: map[string]map[string][]*golang2.DataPoint{
+			StorageClassStandard: {"TotalBytes": dataPoints(sizeGb*gb, sizeGb*gb)},
+		},
+	}
+}
+
+func TestRecommendStorageClass(t *testing.T) {
+	cases := []struct {
+		name          string
+		item          StorageBucketItem
+		wantClass     string
+		wantAutoclass bool
+		wantRule      string
+	}{
+		{
+			name:     "cold bucket moves to archive with a lifecycle rule",
+			item:     standardBucket(1000, 0),
+			wantRule: StorageClassArchive,
+		},
+		{
+			name: "hot bucket stays in standard",
+			item: standardBucket(1000, 5000),
+		},
+		{
+			name: "retrieval risk excluded falls back to autoclass",
+			item: standardBucket(1000, 0,
+				&golang.PreferenceItem{Key: "ExcludeRetrievalRisk", Value: wrapperspb.String("Yes")}),
+			wantClass:     StorageClassStandard,
+			wantAutoclass: true,
+		},
+		{
+			name: "pinned storage class is kept",
+			item: standardBucket(1000, 0,
+				&golang.PreferenceItem{Key: "StorageClass", Pinned: true}),
+		},
+		{
+			name: "lifecycle rules excluded changes the default class",
+			item: standardBucket(1000, 0,
+				&golang.PreferenceItem{Key: "ExcludeLifecycleRules", Value: wrapperspb.String("Yes")}),
+			wantClass: StorageClassArchive,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := recommendStorageClass(c.item)
+			if c.wantClass == "" && c.wantRule == "" {
+				if r.Recommended != nil {
+					t.Fatalf("expected no recommendation, got %v", r.Recommended)
+				}
+				return
+			}
+			if r.Recommended == nil {
+				t.Fatalf("expected a recommendation: %s", r.Description)
+			}
+			if r.Recommended.Cost >= r.Current.Cost {
+				t.Errorf("recommended cost %v is not below current cost %v", r.Recommended.Cost, r.Current.Cost)
+			}
+			if c.wantClass != "" && r.Recommended.StorageClass != c.wantClass {
+				t.Errorf("storage class = %s, want %s", r.Recommended.StorageClass, c.wantClass)
+			}
+			if r.Recommended.AutoclassEnabled != c.wantAutoclass {
+				t.Errorf("autoclass = %v, want %v", r.Recommended.AutoclassEnabled, c.wantAutoclass)
+			}
+			if c.wantRule != "" {
+				rules := r.Recommended.LifecycleRules
+				if len(rules) != 1 || rules[0].StorageClass != c.wantRule || rules[0].AgeDays.GetValue() != 365 {
+					t.Errorf("lifecycle rules = %v, want a rule moving to %s after 365 days", rules, c.wantRule)
+				}
+			}
+		})
+	}
+}
+
+func TestOperationClass(t *testing.T) {
+	for method, want := range map[string]string{
+		"WriteObject":       "A",
+		"ListObjects":       "A",
+		"ReadObject":        "B",
+		"GetObjectMetadata": "B",
+		"DeleteObject":      "",
+	} {
+		if got := operationClass(method); got != want {
+			t.Errorf("operationClass(%s) = %q, want %q", method, got, want)
+		}
+	}
+}
+
+func TestRecommendStorageClassTransitions(t *testing.T) {
+	excludeAutoclass := &golang.PreferenceItem{Key: "ExcludeAutoclass", Value: wrapperspb.String("Yes")}
+	excludeLifecycleRules := &golang.PreferenceItem{Key: "ExcludeLifecycleRules", Value: wrapperspb.String("Yes")}
+
+	t.Run("objects younger than the rule age stay in their class", func(t *testing.T) {
+		item := standardBucket(1000, 0, excludeAutoclass)
+		item.Metrics["ReceivedBytes"] = dataPoints(250*gb/float64(monthSeconds), 250*gb/float64(monthSeconds))
+		r := recommendStorageClass(item)
+		if r.Recommended == nil || len(r.Recommended.LifecycleRules) != 1 {
+			t.Fatalf("expected a lifecycle rule: %s", r.Description)
+		}
+		if rule := r.Recommended.LifecycleRules[0]; rule.StorageClass != StorageClassNearline || rule.AgeDays.GetValue() != 30 {
+			t.Errorf("lifecycle rule = %v, want a rule moving to NEARLINE after 30 days", rule)
+		}
+		moved := 1000 * (1 - 250*30/monthDays/1000)
+		if want := 1000*0.02 - moved*(0.02-0.01); math.Abs(r.Recommended.StorageCost-want) > 1e-9 {
+			t.Errorf("storage cost = %v, want %v", r.Recommended.StorageCost, want)
+		}
+	})
+
+	t.Run("moving many objects does not pay back", func(t *testing.T) {
+		item := standardBucket(1000, 0)
+		item.StorageClassMetrics[StorageClassStandard]["ObjectCount"] = dataPoints(1e9, 1e9)
+		if r := recommendStorageClass(item); r.Recommended != nil {
+			t.Errorf("expected no recommendation, got %v", r.Recommended)
+		}
+	})
+
+	t.Run("rewritten objects are billed early deletion", func(t *testing.T) {
+		item := standardBucket(1000, 0, excludeLifecycleRules, excludeAutoclass)
+		item.StorageClass = StorageClassNearline
+		item.StorageClassMetrics = map[string]map[string][]*golang2.DataPoint{
+			StorageClassNearline: {"TotalBytes": dataPoints(1000*gb, 1000*gb), "ObjectCount": dataPoints(1e6, 1e6)},
+		}
+		item.Metrics["ReceivedBytes"] = dataPoints(100*gb/float64(monthSeconds), 100*gb/float64(monthSeconds))
+		r := recommendStorageClass(item)
+		if r.Recommended == nil || r.Recommended.StorageClass != StorageClassArchive {
+			t.Fatalf("expected a move to ARCHIVE: %s", r.Description)
+		}
+		young := 1000 * (100 * 30 / monthDays / 1000)
+		want := 1e6/10000*0.50 + young*0.01*15/monthDays
+		if math.Abs(r.Recommended.TransitionCost-want) > 1e-9 {
+			t.Errorf("transition cost = %v, want %v", r.Recommended.TransitionCost, want)
+		}
+	})
+
+	t.Run("autoclass management fee", func(t *testing.T) {
+		usage := bucketUsage{sizeGb: map[string]float64{StorageClassStandard: 1000}, objects: map[string]float64{StorageClassStandard: 2e6}}
+		bucket := bucketCost("region", "", StorageClassStandard, true, usage)
+		if bucket.AutoclassCost != 5 || bucket.Cost != 1000*0.02+5 {
+			t.Errorf("autoclass cost = %v, cost = %v", bucket.AutoclassCost, bucket.Cost)
+		}
+	})
+}
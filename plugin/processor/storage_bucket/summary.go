@@ -0,0 +1,6 @@
+package storage_bucket
+
+type StorageBucketSummary struct {
+	CurrentRuntimeCost float64
+	Savings            float64
+}
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.34.1
+// 	protoc-gen-go v1.34.2
 // 	protoc        v3.12.4
 // source: plugin/proto/gcp_server.proto
 
@@ -21,22 +21,78 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type GcpComputeAccelerator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AcceleratorType string `protobuf:"bytes,1,opt,name=accelerator_type,json=acceleratorType,proto3" json:"accelerator_type,omitempty"`
+	Count           int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *GcpComputeAccelerator) Reset() {
+	*x = GcpComputeAccelerator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GcpComputeAccelerator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GcpComputeAccelerator) ProtoMessage() {}
+
+func (x *GcpComputeAccelerator) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GcpComputeAccelerator.ProtoReflect.Descriptor instead.
+func (*GcpComputeAccelerator) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GcpComputeAccelerator) GetAcceleratorType() string {
+	if x != nil {
+		return x.AcceleratorType
+	}
+	return ""
+}
+
+func (x *GcpComputeAccelerator) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
 type GcpComputeInstance struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id                string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Zone              string `protobuf:"bytes,2,opt,name=zone,proto3" json:"zone,omitempty"`
-	MachineType       string `protobuf:"bytes,3,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
-	Preemptible       bool   `protobuf:"varint,4,opt,name=preemptible,proto3" json:"preemptible,omitempty"`
-	InstanceOsLicense string `protobuf:"bytes,5,opt,name=instance_os_license,json=instanceOsLicense,proto3" json:"instance_os_license,omitempty"`
+	Id                string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Zone              string                   `protobuf:"bytes,2,opt,name=zone,proto3" json:"zone,omitempty"`
+	MachineType       string                   `protobuf:"bytes,3,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	Preemptible       bool                     `protobuf:"varint,4,opt,name=preemptible,proto3" json:"preemptible,omitempty"`
+	InstanceOsLicense string                   `protobuf:"bytes,5,opt,name=instance_os_license,json=instanceOsLicense,proto3" json:"instance_os_license,omitempty"`
+	Accelerators      []*GcpComputeAccelerator `protobuf:"bytes,6,rep,name=accelerators,proto3" json:"accelerators,omitempty"`
 }
 
 func (x *GcpComputeInstance) Reset() {
 	*x = GcpComputeInstance{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_proto_gcp_server_proto_msgTypes[0]
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -49,7 +105,7 @@ func (x *GcpComputeInstance) String() string {
 func (*GcpComputeInstance) ProtoMessage() {}
 
 func (x *GcpComputeInstance) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_gcp_server_proto_msgTypes[0]
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -62,7 +118,7 @@ func (x *GcpComputeInstance) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GcpComputeInstance.ProtoReflect.Descriptor instead.
 func (*GcpComputeInstance) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{0}
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *GcpComputeInstance) GetId() string {
@@ -100,6 +156,13 @@ func (x *GcpComputeInstance) GetInstanceOsLicense() string {
 	return ""
 }
 
+func (x *GcpComputeInstance) GetAccelerators() []*GcpComputeAccelerator {
+	if x != nil {
+		return x.Accelerators
+	}
+	return nil
+}
+
 type GcpComputeDisk struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -116,7 +179,7 @@ type GcpComputeDisk struct {
 func (x *GcpComputeDisk) Reset() {
 	*x = GcpComputeDisk{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_proto_gcp_server_proto_msgTypes[1]
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -129,7 +192,7 @@ func (x *GcpComputeDisk) String() string {
 func (*GcpComputeDisk) ProtoMessage() {}
 
 func (x *GcpComputeDisk) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_gcp_server_proto_msgTypes[1]
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -142,7 +205,7 @@ func (x *GcpComputeDisk) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GcpComputeDisk.ProtoReflect.Descriptor instead.
 func (*GcpComputeDisk) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{1}
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GcpComputeDisk) GetId() string {
@@ -200,7 +263,7 @@ type DataPoint struct {
 func (x *DataPoint) Reset() {
 	*x = DataPoint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_proto_gcp_server_proto_msgTypes[2]
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -213,7 +276,7 @@ func (x *DataPoint) String() string {
 func (*DataPoint) ProtoMessage() {}
 
 func (x *DataPoint) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_gcp_server_proto_msgTypes[2]
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -226,7 +289,7 @@ func (x *DataPoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataPoint.ProtoReflect.Descriptor instead.
 func (*DataPoint) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{2}
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *DataPoint) GetStartTime() *wrappers.Int64Value {
@@ -261,7 +324,7 @@ type Metric struct {
 func (x *Metric) Reset() {
 	*x = Metric{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_proto_gcp_server_proto_msgTypes[3]
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -274,7 +337,7 @@ func (x *Metric) String() string {
 func (*Metric) ProtoMessage() {}
 
 func (x *Metric) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_gcp_server_proto_msgTypes[3]
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -287,7 +350,7 @@ func (x *Metric) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metric.ProtoReflect.Descriptor instead.
 func (*Metric) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{3}
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Metric) GetData() []*DataPoint {
@@ -308,7 +371,7 @@ type DiskMetrics struct {
 func (x *DiskMetrics) Reset() {
 	*x = DiskMetrics{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_proto_gcp_server_proto_msgTypes[4]
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -321,7 +384,7 @@ func (x *DiskMetrics) String() string {
 func (*DiskMetrics) ProtoMessage() {}
 
 func (x *DiskMetrics) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_gcp_server_proto_msgTypes[4]
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -334,7 +397,7 @@ func (x *DiskMetrics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiskMetrics.ProtoReflect.Descriptor instead.
 func (*DiskMetrics) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{4}
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *DiskMetrics) GetMetrics() map[string]*Metric {
@@ -364,7 +427,7 @@ type GCPComputeOptimizationRequest struct {
 func (x *GCPComputeOptimizationRequest) Reset() {
 	*x = GCPComputeOptimizationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_proto_gcp_server_proto_msgTypes[5]
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -377,7 +440,7 @@ func (x *GCPComputeOptimizationRequest) String() string {
 func (*GCPComputeOptimizationRequest) ProtoMessage() {}
 
 func (x *GCPComputeOptimizationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_gcp_server_proto_msgTypes[5]
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -390,7 +453,7 @@ func (x *GCPComputeOptimizationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GCPComputeOptimizationRequest.ProtoReflect.Descriptor instead.
 func (*GCPComputeOptimizationRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{5}
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GCPComputeOptimizationRequest) GetRequestId() *wrappers.StringValue {
@@ -463,6 +526,77 @@ func (x *GCPComputeOptimizationRequest) GetRegion() string {
 	return ""
 }
 
+type GcpStorageLifecycleRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Action              string               `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	StorageClass        string               `protobuf:"bytes,2,opt,name=storage_class,json=storageClass,proto3" json:"storage_class,omitempty"`
+	AgeDays             *wrappers.Int64Value `protobuf:"bytes,3,opt,name=age_days,json=ageDays,proto3" json:"age_days,omitempty"`
+	MatchesStorageClass []string             `protobuf:"bytes,4,rep,name=matches_storage_class,json=matchesStorageClass,proto3" json:"matches_storage_class,omitempty"`
+}
+
+func (x *GcpStorageLifecycleRule) Reset() {
+	*x = GcpStorageLifecycleRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GcpStorageLifecycleRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GcpStorageLifecycleRule) ProtoMessage() {}
+
+func (x *GcpStorageLifecycleRule) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GcpStorageLifecycleRule.ProtoReflect.Descriptor instead.
+func (*GcpStorageLifecycleRule) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GcpStorageLifecycleRule) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *GcpStorageLifecycleRule) GetStorageClass() string {
+	if x != nil {
+		return x.StorageClass
+	}
+	return ""
+}
+
+func (x *GcpStorageLifecycleRule) GetAgeDays() *wrappers.Int64Value {
+	if x != nil {
+		return x.AgeDays
+	}
+	return nil
+}
+
+func (x *GcpStorageLifecycleRule) GetMatchesStorageClass() []string {
+	if x != nil {
+		return x.MatchesStorageClass
+	}
+	return nil
+}
+
 // Responses
 type Usage struct {
 	state         protoimpl.MessageState
@@ -472,12 +606,19 @@ type Usage struct {
 	Avg *wrappers.DoubleValue `protobuf:"bytes,1,opt,name=avg,proto3" json:"avg,omitempty"`
 	Max *wrappers.DoubleValue `protobuf:"bytes,2,opt,name=max,proto3" json:"max,omitempty"`
 	Min *wrappers.DoubleValue `protobuf:"bytes,3,opt,name=min,proto3" json:"min,omitempty"`
+	P50 *wrappers.DoubleValue `protobuf:"bytes,4,opt,name=p50,proto3" json:"p50,omitempty"`
+	P90 *wrappers.DoubleValue `protobuf:"bytes,5,opt,name=p90,proto3" json:"p90,omitempty"`
+	P95 *wrappers.DoubleValue `protobuf:"bytes,6,opt,name=p95,proto3" json:"p95,omitempty"`
+	P99 *wrappers.DoubleValue `protobuf:"bytes,7,opt,name=p99,proto3" json:"p99,omitempty"`
+	// statistic the datapoints were capped at before the rightsizing (e.g. p95), empty when they were not.
+	// The avg and max are the ones of the uncapped datapoints.
+	Statistic string `protobuf:"bytes,8,opt,name=statistic,proto3" json:"statistic,omitempty"`
 }
 
 func (x *Usage) Reset() {
 	*x = Usage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_proto_gcp_server_proto_msgTypes[6]
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -490,7 +631,7 @@ func (x *Usage) String() string {
 func (*Usage) ProtoMessage() {}
 
 func (x *Usage) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_gcp_server_proto_msgTypes[6]
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -503,7 +644,7 @@ func (x *Usage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Usage.ProtoReflect.Descriptor instead.
 func (*Usage) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{6}
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Usage) GetAvg() *wrappers.DoubleValue {
@@ -527,26 +668,63 @@ func (x *Usage) GetMin() *wrappers.DoubleValue {
 	return nil
 }
 
+func (x *Usage) GetP50() *wrappers.DoubleValue {
+	if x != nil {
+		return x.P50
+	}
+	return nil
+}
+
+func (x *Usage) GetP90() *wrappers.DoubleValue {
+	if x != nil {
+		return x.P90
+	}
+	return nil
+}
+
+func (x *Usage) GetP95() *wrappers.DoubleValue {
+	if x != nil {
+		return x.P95
+	}
+	return nil
+}
+
+func (x *Usage) GetP99() *wrappers.DoubleValue {
+	if x != nil {
+		return x.P99
+	}
+	return nil
+}
+
+func (x *Usage) GetStatistic() string {
+	if x != nil {
+		return x.Statistic
+	}
+	return ""
+}
+
 type RightsizingGcpComputeDisk struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Zone                 string  `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
-	Region               string  `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
-	DiskType             string  `protobuf:"bytes,3,opt,name=disk_type,json=diskType,proto3" json:"disk_type,omitempty"`
-	DiskSize             int64   `protobuf:"varint,4,opt,name=disk_size,json=diskSize,proto3" json:"disk_size,omitempty"`
-	ReadIopsLimit        int64   `protobuf:"varint,5,opt,name=read_iops_limit,json=readIopsLimit,proto3" json:"read_iops_limit,omitempty"`
-	WriteIopsLimit       int64   `protobuf:"varint,6,opt,name=write_iops_limit,json=writeIopsLimit,proto3" json:"write_iops_limit,omitempty"`
-	ReadThroughputLimit  float64 `protobuf:"fixed64,7,opt,name=read_throughput_limit,json=readThroughputLimit,proto3" json:"read_throughput_limit,omitempty"`
-	WriteThroughputLimit float64 `protobuf:"fixed64,8,opt,name=write_throughput_limit,json=writeThroughputLimit,proto3" json:"write_throughput_limit,omitempty"`
-	Cost                 float64 `protobuf:"fixed64,9,opt,name=cost,proto3" json:"cost,omitempty"`
+	Zone                  string  `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
+	Region                string  `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	DiskType              string  `protobuf:"bytes,3,opt,name=disk_type,json=diskType,proto3" json:"disk_type,omitempty"`
+	DiskSize              int64   `protobuf:"varint,4,opt,name=disk_size,json=diskSize,proto3" json:"disk_size,omitempty"`
+	ReadIopsLimit         int64   `protobuf:"varint,5,opt,name=read_iops_limit,json=readIopsLimit,proto3" json:"read_iops_limit,omitempty"`
+	WriteIopsLimit        int64   `protobuf:"varint,6,opt,name=write_iops_limit,json=writeIopsLimit,proto3" json:"write_iops_limit,omitempty"`
+	ReadThroughputLimit   float64 `protobuf:"fixed64,7,opt,name=read_throughput_limit,json=readThroughputLimit,proto3" json:"read_throughput_limit,omitempty"`
+	WriteThroughputLimit  float64 `protobuf:"fixed64,8,opt,name=write_throughput_limit,json=writeThroughputLimit,proto3" json:"write_throughput_limit,omitempty"`
+	Cost                  float64 `protobuf:"fixed64,9,opt,name=cost,proto3" json:"cost,omitempty"`
+	ProvisionedIops       int64   `protobuf:"varint,10,opt,name=provisioned_iops,json=provisionedIops,proto3" json:"provisioned_iops,omitempty"`
+	ProvisionedThroughput int64   `protobuf:"varint,11,opt,name=provisioned_throughput,json=provisionedThroughput,proto3" json:"provisioned_throughput,omitempty"`
 }
 
 func (x *RightsizingGcpComputeDisk) Reset() {
 	*x = RightsizingGcpComputeDisk{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_proto_gcp_server_proto_msgTypes[7]
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -559,7 +737,7 @@ func (x *RightsizingGcpComputeDisk) String() string {
 func (*RightsizingGcpComputeDisk) ProtoMessage() {}
 
 func (x *RightsizingGcpComputeDisk) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_gcp_server_proto_msgTypes[7]
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -572,7 +750,7 @@ func (x *RightsizingGcpComputeDisk) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RightsizingGcpComputeDisk.ProtoReflect.Descriptor instead.
 func (*RightsizingGcpComputeDisk) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{7}
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RightsizingGcpComputeDisk) GetZone() string {
@@ -638,26 +816,42 @@ func (x *RightsizingGcpComputeDisk) GetCost() float64 {
 	return 0
 }
 
+func (x *RightsizingGcpComputeDisk) GetProvisionedIops() int64 {
+	if x != nil {
+		return x.ProvisionedIops
+	}
+	return 0
+}
+
+func (x *RightsizingGcpComputeDisk) GetProvisionedThroughput() int64 {
+	if x != nil {
+		return x.ProvisionedThroughput
+	}
+	return 0
+}
+
 type RightsizingGcpComputeInstance struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Zone          string  `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
-	Region        string  `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
-	MachineType   string  `protobuf:"bytes,3,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
-	MachineFamily string  `protobuf:"bytes,4,opt,name=machine_family,json=machineFamily,proto3" json:"machine_family,omitempty"`
-	Cpu           int64   `protobuf:"varint,5,opt,name=cpu,proto3" json:"cpu,omitempty"`
-	MemoryMb      int64   `protobuf:"varint,6,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
-	Preemptible   bool    `protobuf:"varint,7,opt,name=preemptible,proto3" json:"preemptible,omitempty"`
-	Cost          float64 `protobuf:"fixed64,8,opt,name=cost,proto3" json:"cost,omitempty"`
-	OsLicenseCost float64 `protobuf:"fixed64,9,opt,name=os_license_cost,json=osLicenseCost,proto3" json:"os_license_cost,omitempty"`
+	Zone             string                   `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
+	Region           string                   `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	MachineType      string                   `protobuf:"bytes,3,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	MachineFamily    string                   `protobuf:"bytes,4,opt,name=machine_family,json=machineFamily,proto3" json:"machine_family,omitempty"`
+	Cpu              int64                    `protobuf:"varint,5,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	MemoryMb         int64                    `protobuf:"varint,6,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	Preemptible      bool                     `protobuf:"varint,7,opt,name=preemptible,proto3" json:"preemptible,omitempty"`
+	Cost             float64                  `protobuf:"fixed64,8,opt,name=cost,proto3" json:"cost,omitempty"`
+	OsLicenseCost    float64                  `protobuf:"fixed64,9,opt,name=os_license_cost,json=osLicenseCost,proto3" json:"os_license_cost,omitempty"`
+	Accelerators     []*GcpComputeAccelerator `protobuf:"bytes,10,rep,name=accelerators,proto3" json:"accelerators,omitempty"`
+	AcceleratorsCost float64                  `protobuf:"fixed64,11,opt,name=accelerators_cost,json=acceleratorsCost,proto3" json:"accelerators_cost,omitempty"`
 }
 
 func (x *RightsizingGcpComputeInstance) Reset() {
 	*x = RightsizingGcpComputeInstance{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_proto_gcp_server_proto_msgTypes[8]
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -670,7 +864,7 @@ func (x *RightsizingGcpComputeInstance) String() string {
 func (*RightsizingGcpComputeInstance) ProtoMessage() {}
 
 func (x *RightsizingGcpComputeInstance) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_gcp_server_proto_msgTypes[8]
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -683,7 +877,7 @@ func (x *RightsizingGcpComputeInstance) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RightsizingGcpComputeInstance.ProtoReflect.Descriptor instead.
 func (*RightsizingGcpComputeInstance) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{8}
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *RightsizingGcpComputeInstance) GetZone() string {
@@ -749,6 +943,20 @@ func (x *RightsizingGcpComputeInstance) GetOsLicenseCost() float64 {
 	return 0
 }
 
+func (x *RightsizingGcpComputeInstance) GetAccelerators() []*GcpComputeAccelerator {
+	if x != nil {
+		return x.Accelerators
+	}
+	return nil
+}
+
+func (x *RightsizingGcpComputeInstance) GetAcceleratorsCost() float64 {
+	if x != nil {
+		return x.AcceleratorsCost
+	}
+	return 0
+}
+
 type GcpComputeInstanceRightsizingRecommendation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -759,12 +967,14 @@ type GcpComputeInstanceRightsizingRecommendation struct {
 	Cpu         *Usage                         `protobuf:"bytes,3,opt,name=cpu,proto3" json:"cpu,omitempty"`
 	Memory      *Usage                         `protobuf:"bytes,4,opt,name=memory,proto3" json:"memory,omitempty"`
 	Description string                         `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Gpu         *Usage                         `protobuf:"bytes,6,opt,name=gpu,proto3" json:"gpu,omitempty"`
+	GpuMemory   *Usage                         `protobuf:"bytes,7,opt,name=gpu_memory,json=gpuMemory,proto3" json:"gpu_memory,omitempty"`
 }
 
 func (x *GcpComputeInstanceRightsizingRecommendation) Reset() {
 	*x = GcpComputeInstanceRightsizingRecommendation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_proto_gcp_server_proto_msgTypes[9]
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -777,7 +987,7 @@ func (x *GcpComputeInstanceRightsizingRecommendation) String() string {
 func (*GcpComputeInstanceRightsizingRecommendation) ProtoMessage() {}
 
 func (x *GcpComputeInstanceRightsizingRecommendation) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_gcp_server_proto_msgTypes[9]
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -790,7 +1000,7 @@ func (x *GcpComputeInstanceRightsizingRecommendation) ProtoReflect() protoreflec
 
 // Deprecated: Use GcpComputeInstanceRightsizingRecommendation.ProtoReflect.Descriptor instead.
 func (*GcpComputeInstanceRightsizingRecommendation) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{9}
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GcpComputeInstanceRightsizingRecommendation) GetCurrent() *RightsizingGcpComputeInstance {
@@ -828,6 +1038,20 @@ func (x *GcpComputeInstanceRightsizingRecommendation) GetDescription() string {
 	return ""
 }
 
+func (x *GcpComputeInstanceRightsizingRecommendation) GetGpu() *Usage {
+	if x != nil {
+		return x.Gpu
+	}
+	return nil
+}
+
+func (x *GcpComputeInstanceRightsizingRecommendation) GetGpuMemory() *Usage {
+	if x != nil {
+		return x.GpuMemory
+	}
+	return nil
+}
+
 type GcpComputeDiskRecommendation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -845,7 +1069,7 @@ type GcpComputeDiskRecommendation struct {
 func (x *GcpComputeDiskRecommendation) Reset() {
 	*x = GcpComputeDiskRecommendation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_proto_gcp_server_proto_msgTypes[10]
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -858,7 +1082,7 @@ func (x *GcpComputeDiskRecommendation) String() string {
 func (*GcpComputeDiskRecommendation) ProtoMessage() {}
 
 func (x *GcpComputeDiskRecommendation) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_gcp_server_proto_msgTypes[10]
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -871,7 +1095,7 @@ func (x *GcpComputeDiskRecommendation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GcpComputeDiskRecommendation.ProtoReflect.Descriptor instead.
 func (*GcpComputeDiskRecommendation) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{10}
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GcpComputeDiskRecommendation) GetCurrent() *RightsizingGcpComputeDisk {
@@ -935,7 +1159,7 @@ type GCPComputeOptimizationResponse struct {
 func (x *GCPComputeOptimizationResponse) Reset() {
 	*x = GCPComputeOptimizationResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_proto_gcp_server_proto_msgTypes[11]
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -948,7 +1172,7 @@ func (x *GCPComputeOptimizationResponse) String() string {
 func (*GCPComputeOptimizationResponse) ProtoMessage() {}
 
 func (x *GCPComputeOptimizationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_gcp_server_proto_msgTypes[11]
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -961,7 +1185,7 @@ func (x *GCPComputeOptimizationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GCPComputeOptimizationResponse.ProtoReflect.Descriptor instead.
 func (*GCPComputeOptimizationResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{11}
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GCPComputeOptimizationResponse) GetRightsizing() *GcpComputeInstanceRightsizingRecommendation {
@@ -978,52 +1202,1019 @@ func (x *GCPComputeOptimizationResponse) GetVolumesRightsizing() map[string]*Gcp
 	return nil
 }
 
-var File_plugin_proto_gcp_server_proto protoreflect.FileDescriptor
+type RightsizingGcpStorageBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_plugin_proto_gcp_server_proto_rawDesc = []byte{
-	0x0a, 0x1d, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67,
-	0x63, 0x70, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x19, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d,
-	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70,
-	0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xad, 0x01, 0x0a, 0x12, 0x47,
-	0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x12, 0x0a, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
-	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x63,
-	0x68, 0x69, 0x6e, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x65, 0x65,
-	0x6d, 0x70, 0x74, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x70,
-	0x72, 0x65, 0x65, 0x6d, 0x70, 0x74, 0x69, 0x62, 0x6c, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x69, 0x6e,
-	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6f, 0x73, 0x5f, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x4f, 0x73, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x22, 0xeb, 0x01, 0x0a, 0x0e, 0x47,
-	0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x44, 0x69, 0x73, 0x6b, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
-	0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x6f, 0x6e,
-	0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x69, 0x73,
-	0x6b, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69,
-	0x73, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73,
-	0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36,
-	0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x64, 0x69, 0x73, 0x6b, 0x53, 0x69, 0x7a, 0x65,
-	0x12, 0x46, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x5f,
-	0x69, 0x6f, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74,
-	0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69,
-	0x6f, 0x6e, 0x65, 0x64, 0x49, 0x6f, 0x70, 0x73, 0x22, 0x95, 0x01, 0x0a, 0x09, 0x44, 0x61, 0x74,
-	0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
-	0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74,
-	0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
-	0x6d, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x22, 0x42, 0x0a, 0x06, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x38, 0x0a, 0x04, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	Location         string                     `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	StorageClass     string                     `protobuf:"bytes,2,opt,name=storage_class,json=storageClass,proto3" json:"storage_class,omitempty"`
+	AutoclassEnabled bool                       `protobuf:"varint,3,opt,name=autoclass_enabled,json=autoclassEnabled,proto3" json:"autoclass_enabled,omitempty"`
+	LifecycleRules   []*GcpStorageLifecycleRule `protobuf:"bytes,4,rep,name=lifecycle_rules,json=lifecycleRules,proto3" json:"lifecycle_rules,omitempty"`
+	SizeGb           float64                    `protobuf:"fixed64,5,opt,name=size_gb,json=sizeGb,proto3" json:"size_gb,omitempty"`
+	StorageCost      float64                    `protobuf:"fixed64,6,opt,name=storage_cost,json=storageCost,proto3" json:"storage_cost,omitempty"`
+	OperationsCost   float64                    `protobuf:"fixed64,7,opt,name=operations_cost,json=operationsCost,proto3" json:"operations_cost,omitempty"`
+	RetrievalCost    float64                    `protobuf:"fixed64,8,opt,name=retrieval_cost,json=retrievalCost,proto3" json:"retrieval_cost,omitempty"`
+	Cost             float64                    `protobuf:"fixed64,9,opt,name=cost,proto3" json:"cost,omitempty"`
+	AutoclassCost    float64                    `protobuf:"fixed64,10,opt,name=autoclass_cost,json=autoclassCost,proto3" json:"autoclass_cost,omitempty"`
+	TransitionCost   float64                    `protobuf:"fixed64,11,opt,name=transition_cost,json=transitionCost,proto3" json:"transition_cost,omitempty"`
+}
+
+func (x *RightsizingGcpStorageBucket) Reset() {
+	*x = RightsizingGcpStorageBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RightsizingGcpStorageBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RightsizingGcpStorageBucket) ProtoMessage() {}
+
+func (x *RightsizingGcpStorageBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RightsizingGcpStorageBucket.ProtoReflect.Descriptor instead.
+func (*RightsizingGcpStorageBucket) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RightsizingGcpStorageBucket) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *RightsizingGcpStorageBucket) GetStorageClass() string {
+	if x != nil {
+		return x.StorageClass
+	}
+	return ""
+}
+
+func (x *RightsizingGcpStorageBucket) GetAutoclassEnabled() bool {
+	if x != nil {
+		return x.AutoclassEnabled
+	}
+	return false
+}
+
+func (x *RightsizingGcpStorageBucket) GetLifecycleRules() []*GcpStorageLifecycleRule {
+	if x != nil {
+		return x.LifecycleRules
+	}
+	return nil
+}
+
+func (x *RightsizingGcpStorageBucket) GetSizeGb() float64 {
+	if x != nil {
+		return x.SizeGb
+	}
+	return 0
+}
+
+func (x *RightsizingGcpStorageBucket) GetStorageCost() float64 {
+	if x != nil {
+		return x.StorageCost
+	}
+	return 0
+}
+
+func (x *RightsizingGcpStorageBucket) GetOperationsCost() float64 {
+	if x != nil {
+		return x.OperationsCost
+	}
+	return 0
+}
+
+func (x *RightsizingGcpStorageBucket) GetRetrievalCost() float64 {
+	if x != nil {
+		return x.RetrievalCost
+	}
+	return 0
+}
+
+func (x *RightsizingGcpStorageBucket) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *RightsizingGcpStorageBucket) GetAutoclassCost() float64 {
+	if x != nil {
+		return x.AutoclassCost
+	}
+	return 0
+}
+
+func (x *RightsizingGcpStorageBucket) GetTransitionCost() float64 {
+	if x != nil {
+		return x.TransitionCost
+	}
+	return 0
+}
+
+type GcpStorageBucketRecommendation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Current           *RightsizingGcpStorageBucket `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	Recommended       *RightsizingGcpStorageBucket `protobuf:"bytes,2,opt,name=recommended,proto3" json:"recommended,omitempty"`
+	TotalBytes        *Usage                       `protobuf:"bytes,3,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	RequestCount      *Usage                       `protobuf:"bytes,4,opt,name=request_count,json=requestCount,proto3" json:"request_count,omitempty"`
+	SentBytes         *Usage                       `protobuf:"bytes,5,opt,name=sent_bytes,json=sentBytes,proto3" json:"sent_bytes,omitempty"`
+	RetrievalCostRisk float64                      `protobuf:"fixed64,6,opt,name=retrieval_cost_risk,json=retrievalCostRisk,proto3" json:"retrieval_cost_risk,omitempty"`
+	Description       string                       `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (x *GcpStorageBucketRecommendation) Reset() {
+	*x = GcpStorageBucketRecommendation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GcpStorageBucketRecommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GcpStorageBucketRecommendation) ProtoMessage() {}
+
+func (x *GcpStorageBucketRecommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GcpStorageBucketRecommendation.ProtoReflect.Descriptor instead.
+func (*GcpStorageBucketRecommendation) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GcpStorageBucketRecommendation) GetCurrent() *RightsizingGcpStorageBucket {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *GcpStorageBucketRecommendation) GetRecommended() *RightsizingGcpStorageBucket {
+	if x != nil {
+		return x.Recommended
+	}
+	return nil
+}
+
+func (x *GcpStorageBucketRecommendation) GetTotalBytes() *Usage {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return nil
+}
+
+func (x *GcpStorageBucketRecommendation) GetRequestCount() *Usage {
+	if x != nil {
+		return x.RequestCount
+	}
+	return nil
+}
+
+func (x *GcpStorageBucketRecommendation) GetSentBytes() *Usage {
+	if x != nil {
+		return x.SentBytes
+	}
+	return nil
+}
+
+func (x *GcpStorageBucketRecommendation) GetRetrievalCostRisk() float64 {
+	if x != nil {
+		return x.RetrievalCostRisk
+	}
+	return 0
+}
+
+func (x *GcpStorageBucketRecommendation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GCPStorageBucketOptimizationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rightsizing *GcpStorageBucketRecommendation `protobuf:"bytes,1,opt,name=rightsizing,proto3" json:"rightsizing,omitempty"`
+}
+
+func (x *GCPStorageBucketOptimizationResponse) Reset() {
+	*x = GCPStorageBucketOptimizationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCPStorageBucketOptimizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCPStorageBucketOptimizationResponse) ProtoMessage() {}
+
+func (x *GCPStorageBucketOptimizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCPStorageBucketOptimizationResponse.ProtoReflect.Descriptor instead.
+func (*GCPStorageBucketOptimizationResponse) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GCPStorageBucketOptimizationResponse) GetRightsizing() *GcpStorageBucketRecommendation {
+	if x != nil {
+		return x.Rightsizing
+	}
+	return nil
+}
+
+type RightsizingGcpMemorystoreInstance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Region       string  `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	Tier         string  `protobuf:"bytes,2,opt,name=tier,proto3" json:"tier,omitempty"`
+	MemorySizeGb int64   `protobuf:"varint,3,opt,name=memory_size_gb,json=memorySizeGb,proto3" json:"memory_size_gb,omitempty"`
+	ReplicaCount int64   `protobuf:"varint,4,opt,name=replica_count,json=replicaCount,proto3" json:"replica_count,omitempty"`
+	Cost         float64 `protobuf:"fixed64,5,opt,name=cost,proto3" json:"cost,omitempty"`
+}
+
+func (x *RightsizingGcpMemorystoreInstance) Reset() {
+	*x = RightsizingGcpMemorystoreInstance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RightsizingGcpMemorystoreInstance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RightsizingGcpMemorystoreInstance) ProtoMessage() {}
+
+func (x *RightsizingGcpMemorystoreInstance) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RightsizingGcpMemorystoreInstance.ProtoReflect.Descriptor instead.
+func (*RightsizingGcpMemorystoreInstance) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RightsizingGcpMemorystoreInstance) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *RightsizingGcpMemorystoreInstance) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *RightsizingGcpMemorystoreInstance) GetMemorySizeGb() int64 {
+	if x != nil {
+		return x.MemorySizeGb
+	}
+	return 0
+}
+
+func (x *RightsizingGcpMemorystoreInstance) GetReplicaCount() int64 {
+	if x != nil {
+		return x.ReplicaCount
+	}
+	return 0
+}
+
+func (x *RightsizingGcpMemorystoreInstance) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+type GcpMemorystoreInstanceRecommendation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Current          *RightsizingGcpMemorystoreInstance `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	Recommended      *RightsizingGcpMemorystoreInstance `protobuf:"bytes,2,opt,name=recommended,proto3" json:"recommended,omitempty"`
+	MemoryUsageRatio *Usage                             `protobuf:"bytes,3,opt,name=memory_usage_ratio,json=memoryUsageRatio,proto3" json:"memory_usage_ratio,omitempty"`
+	Cpu              *Usage                             `protobuf:"bytes,4,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	ConnectedClients *Usage                             `protobuf:"bytes,5,opt,name=connected_clients,json=connectedClients,proto3" json:"connected_clients,omitempty"`
+	Description      string                             `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (x *GcpMemorystoreInstanceRecommendation) Reset() {
+	*x = GcpMemorystoreInstanceRecommendation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GcpMemorystoreInstanceRecommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GcpMemorystoreInstanceRecommendation) ProtoMessage() {}
+
+func (x *GcpMemorystoreInstanceRecommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GcpMemorystoreInstanceRecommendation.ProtoReflect.Descriptor instead.
+func (*GcpMemorystoreInstanceRecommendation) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GcpMemorystoreInstanceRecommendation) GetCurrent() *RightsizingGcpMemorystoreInstance {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *GcpMemorystoreInstanceRecommendation) GetRecommended() *RightsizingGcpMemorystoreInstance {
+	if x != nil {
+		return x.Recommended
+	}
+	return nil
+}
+
+func (x *GcpMemorystoreInstanceRecommendation) GetMemoryUsageRatio() *Usage {
+	if x != nil {
+		return x.MemoryUsageRatio
+	}
+	return nil
+}
+
+func (x *GcpMemorystoreInstanceRecommendation) GetCpu() *Usage {
+	if x != nil {
+		return x.Cpu
+	}
+	return nil
+}
+
+func (x *GcpMemorystoreInstanceRecommendation) GetConnectedClients() *Usage {
+	if x != nil {
+		return x.ConnectedClients
+	}
+	return nil
+}
+
+func (x *GcpMemorystoreInstanceRecommendation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GCPMemorystoreOptimizationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rightsizing *GcpMemorystoreInstanceRecommendation `protobuf:"bytes,1,opt,name=rightsizing,proto3" json:"rightsizing,omitempty"`
+}
+
+func (x *GCPMemorystoreOptimizationResponse) Reset() {
+	*x = GCPMemorystoreOptimizationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCPMemorystoreOptimizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCPMemorystoreOptimizationResponse) ProtoMessage() {}
+
+func (x *GCPMemorystoreOptimizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCPMemorystoreOptimizationResponse.ProtoReflect.Descriptor instead.
+func (*GCPMemorystoreOptimizationResponse) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GCPMemorystoreOptimizationResponse) GetRightsizing() *GcpMemorystoreInstanceRecommendation {
+	if x != nil {
+		return x.Rightsizing
+	}
+	return nil
+}
+
+type RightsizingGcpCloudRunService struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Region             string  `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	Cpu                float64 `protobuf:"fixed64,2,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	MemoryMb           int64   `protobuf:"varint,3,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	Concurrency        int64   `protobuf:"varint,4,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	MinInstances       int64   `protobuf:"varint,5,opt,name=min_instances,json=minInstances,proto3" json:"min_instances,omitempty"`
+	MaxInstances       int64   `protobuf:"varint,6,opt,name=max_instances,json=maxInstances,proto3" json:"max_instances,omitempty"`
+	CpuAlwaysAllocated bool    `protobuf:"varint,7,opt,name=cpu_always_allocated,json=cpuAlwaysAllocated,proto3" json:"cpu_always_allocated,omitempty"`
+	Cost               float64 `protobuf:"fixed64,8,opt,name=cost,proto3" json:"cost,omitempty"`
+}
+
+func (x *RightsizingGcpCloudRunService) Reset() {
+	*x = RightsizingGcpCloudRunService{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RightsizingGcpCloudRunService) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RightsizingGcpCloudRunService) ProtoMessage() {}
+
+func (x *RightsizingGcpCloudRunService) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RightsizingGcpCloudRunService.ProtoReflect.Descriptor instead.
+func (*RightsizingGcpCloudRunService) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RightsizingGcpCloudRunService) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *RightsizingGcpCloudRunService) GetCpu() float64 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+func (x *RightsizingGcpCloudRunService) GetMemoryMb() int64 {
+	if x != nil {
+		return x.MemoryMb
+	}
+	return 0
+}
+
+func (x *RightsizingGcpCloudRunService) GetConcurrency() int64 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *RightsizingGcpCloudRunService) GetMinInstances() int64 {
+	if x != nil {
+		return x.MinInstances
+	}
+	return 0
+}
+
+func (x *RightsizingGcpCloudRunService) GetMaxInstances() int64 {
+	if x != nil {
+		return x.MaxInstances
+	}
+	return 0
+}
+
+func (x *RightsizingGcpCloudRunService) GetCpuAlwaysAllocated() bool {
+	if x != nil {
+		return x.CpuAlwaysAllocated
+	}
+	return false
+}
+
+func (x *RightsizingGcpCloudRunService) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+type GcpCloudRunServiceRecommendation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Current       *RightsizingGcpCloudRunService `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	Recommended   *RightsizingGcpCloudRunService `protobuf:"bytes,2,opt,name=recommended,proto3" json:"recommended,omitempty"`
+	Cpu           *Usage                         `protobuf:"bytes,3,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	Memory        *Usage                         `protobuf:"bytes,4,opt,name=memory,proto3" json:"memory,omitempty"`
+	InstanceCount *Usage                         `protobuf:"bytes,5,opt,name=instance_count,json=instanceCount,proto3" json:"instance_count,omitempty"`
+	RequestCount  *Usage                         `protobuf:"bytes,6,opt,name=request_count,json=requestCount,proto3" json:"request_count,omitempty"`
+	Concurrency   *Usage                         `protobuf:"bytes,7,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	Description   string                         `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (x *GcpCloudRunServiceRecommendation) Reset() {
+	*x = GcpCloudRunServiceRecommendation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GcpCloudRunServiceRecommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GcpCloudRunServiceRecommendation) ProtoMessage() {}
+
+func (x *GcpCloudRunServiceRecommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GcpCloudRunServiceRecommendation.ProtoReflect.Descriptor instead.
+func (*GcpCloudRunServiceRecommendation) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GcpCloudRunServiceRecommendation) GetCurrent() *RightsizingGcpCloudRunService {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *GcpCloudRunServiceRecommendation) GetRecommended() *RightsizingGcpCloudRunService {
+	if x != nil {
+		return x.Recommended
+	}
+	return nil
+}
+
+func (x *GcpCloudRunServiceRecommendation) GetCpu() *Usage {
+	if x != nil {
+		return x.Cpu
+	}
+	return nil
+}
+
+func (x *GcpCloudRunServiceRecommendation) GetMemory() *Usage {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *GcpCloudRunServiceRecommendation) GetInstanceCount() *Usage {
+	if x != nil {
+		return x.InstanceCount
+	}
+	return nil
+}
+
+func (x *GcpCloudRunServiceRecommendation) GetRequestCount() *Usage {
+	if x != nil {
+		return x.RequestCount
+	}
+	return nil
+}
+
+func (x *GcpCloudRunServiceRecommendation) GetConcurrency() *Usage {
+	if x != nil {
+		return x.Concurrency
+	}
+	return nil
+}
+
+func (x *GcpCloudRunServiceRecommendation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GCPCloudRunOptimizationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rightsizing *GcpCloudRunServiceRecommendation `protobuf:"bytes,1,opt,name=rightsizing,proto3" json:"rightsizing,omitempty"`
+}
+
+func (x *GCPCloudRunOptimizationResponse) Reset() {
+	*x = GCPCloudRunOptimizationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCPCloudRunOptimizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCPCloudRunOptimizationResponse) ProtoMessage() {}
+
+func (x *GCPCloudRunOptimizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCPCloudRunOptimizationResponse.ProtoReflect.Descriptor instead.
+func (*GCPCloudRunOptimizationResponse) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GCPCloudRunOptimizationResponse) GetRightsizing() *GcpCloudRunServiceRecommendation {
+	if x != nil {
+		return x.Rightsizing
+	}
+	return nil
+}
+
+type RightsizingGcpLoadBalancer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Region             string  `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	ForwardingRuleCost float64 `protobuf:"fixed64,2,opt,name=forwarding_rule_cost,json=forwardingRuleCost,proto3" json:"forwarding_rule_cost,omitempty"`
+	DataProcessingCost float64 `protobuf:"fixed64,3,opt,name=data_processing_cost,json=dataProcessingCost,proto3" json:"data_processing_cost,omitempty"`
+	Cost               float64 `protobuf:"fixed64,4,opt,name=cost,proto3" json:"cost,omitempty"`
+}
+
+func (x *RightsizingGcpLoadBalancer) Reset() {
+	*x = RightsizingGcpLoadBalancer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RightsizingGcpLoadBalancer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RightsizingGcpLoadBalancer) ProtoMessage() {}
+
+func (x *RightsizingGcpLoadBalancer) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RightsizingGcpLoadBalancer.ProtoReflect.Descriptor instead.
+func (*RightsizingGcpLoadBalancer) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RightsizingGcpLoadBalancer) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *RightsizingGcpLoadBalancer) GetForwardingRuleCost() float64 {
+	if x != nil {
+		return x.ForwardingRuleCost
+	}
+	return 0
+}
+
+func (x *RightsizingGcpLoadBalancer) GetDataProcessingCost() float64 {
+	if x != nil {
+		return x.DataProcessingCost
+	}
+	return 0
+}
+
+func (x *RightsizingGcpLoadBalancer) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+type GcpLoadBalancerRecommendation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Current      *RightsizingGcpLoadBalancer `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	Recommended  *RightsizingGcpLoadBalancer `protobuf:"bytes,2,opt,name=recommended,proto3" json:"recommended,omitempty"`
+	RequestCount *Usage                      `protobuf:"bytes,3,opt,name=request_count,json=requestCount,proto3" json:"request_count,omitempty"`
+	IngressBytes *Usage                      `protobuf:"bytes,4,opt,name=ingress_bytes,json=ingressBytes,proto3" json:"ingress_bytes,omitempty"`
+	EgressBytes  *Usage                      `protobuf:"bytes,5,opt,name=egress_bytes,json=egressBytes,proto3" json:"egress_bytes,omitempty"`
+	Idle         bool                        `protobuf:"varint,6,opt,name=idle,proto3" json:"idle,omitempty"`
+	IdleReason   string                      `protobuf:"bytes,7,opt,name=idle_reason,json=idleReason,proto3" json:"idle_reason,omitempty"`
+	Description  string                      `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (x *GcpLoadBalancerRecommendation) Reset() {
+	*x = GcpLoadBalancerRecommendation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GcpLoadBalancerRecommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GcpLoadBalancerRecommendation) ProtoMessage() {}
+
+func (x *GcpLoadBalancerRecommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GcpLoadBalancerRecommendation.ProtoReflect.Descriptor instead.
+func (*GcpLoadBalancerRecommendation) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GcpLoadBalancerRecommendation) GetCurrent() *RightsizingGcpLoadBalancer {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *GcpLoadBalancerRecommendation) GetRecommended() *RightsizingGcpLoadBalancer {
+	if x != nil {
+		return x.Recommended
+	}
+	return nil
+}
+
+func (x *GcpLoadBalancerRecommendation) GetRequestCount() *Usage {
+	if x != nil {
+		return x.RequestCount
+	}
+	return nil
+}
+
+func (x *GcpLoadBalancerRecommendation) GetIngressBytes() *Usage {
+	if x != nil {
+		return x.IngressBytes
+	}
+	return nil
+}
+
+func (x *GcpLoadBalancerRecommendation) GetEgressBytes() *Usage {
+	if x != nil {
+		return x.EgressBytes
+	}
+	return nil
+}
+
+func (x *GcpLoadBalancerRecommendation) GetIdle() bool {
+	if x != nil {
+		return x.Idle
+	}
+	return false
+}
+
+func (x *GcpLoadBalancerRecommendation) GetIdleReason() string {
+	if x != nil {
+		return x.IdleReason
+	}
+	return ""
+}
+
+func (x *GcpLoadBalancerRecommendation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GCPLoadBalancerOptimizationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rightsizing *GcpLoadBalancerRecommendation `protobuf:"bytes,1,opt,name=rightsizing,proto3" json:"rightsizing,omitempty"`
+}
+
+func (x *GCPLoadBalancerOptimizationResponse) Reset() {
+	*x = GCPLoadBalancerOptimizationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_proto_gcp_server_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCPLoadBalancerOptimizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCPLoadBalancerOptimizationResponse) ProtoMessage() {}
+
+func (x *GCPLoadBalancerOptimizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_gcp_server_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCPLoadBalancerOptimizationResponse.ProtoReflect.Descriptor instead.
+func (*GCPLoadBalancerOptimizationResponse) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_gcp_server_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GCPLoadBalancerOptimizationResponse) GetRightsizing() *GcpLoadBalancerRecommendation {
+	if x != nil {
+		return x.Rightsizing
+	}
+	return nil
+}
+
+var File_plugin_proto_gcp_server_proto protoreflect.FileDescriptor
+
+var file_plugin_proto_gcp_server_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67,
+	0x63, 0x70, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x19, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70,
+	0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x58, 0x0a, 0x15, 0x47, 0x63,
+	0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x61,
+	0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x83, 0x02, 0x0a, 0x12, 0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70,
+	0x75, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x7a,
+	0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x12,
+	0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x65, 0x65, 0x6d, 0x70, 0x74, 0x69, 0x62, 0x6c,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x65, 0x6d, 0x70, 0x74,
+	0x69, 0x62, 0x6c, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x5f, 0x6f, 0x73, 0x5f, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4f, 0x73, 0x4c, 0x69, 0x63,
+	0x65, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x65, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x0c, 0x61, 0x63,
+	0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x22, 0xeb, 0x01, 0x0a, 0x0e, 0x47,
+	0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x44, 0x69, 0x73, 0x6b, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x6f, 0x6e,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x69, 0x73,
+	0x6b, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69,
+	0x73, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36,
+	0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x64, 0x69, 0x73, 0x6b, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x46, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x5f,
+	0x69, 0x6f, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74,
+	0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x65, 0x64, 0x49, 0x6f, 0x70, 0x73, 0x22, 0x95, 0x01, 0x0a, 0x09, 0x44, 0x61, 0x74,
+	0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74,
+	0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0x42, 0x0a, 0x06, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x38, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
 	0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
 	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x04,
 	0x64, 0x61, 0x74, 0x61, 0x22, 0xbb, 0x01, 0x0a, 0x0b, 0x44, 0x69, 0x73, 0x6b, 0x4d, 0x65, 0x74,
@@ -1109,69 +2300,332 @@ var file_plugin_proto_gcp_server_proto_rawDesc = []byte{
 	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x70, 0x6c, 0x75, 0x67,
 	0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69,
 	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
-	0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x97, 0x01, 0x0a,
-	0x05, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x03, 0x61, 0x76, 0x67, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x52, 0x03, 0x61, 0x76, 0x67, 0x12, 0x2e, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x2e, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x22, 0xd1, 0x02, 0x0a, 0x19, 0x52, 0x69, 0x67, 0x68, 0x74,
+	0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc2, 0x01, 0x0a,
+	0x17, 0x47, 0x63, 0x70, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x66, 0x65, 0x63,
+	0x79, 0x63, 0x6c, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6c, 0x61, 0x73,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x36, 0x0a, 0x08, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x61, 0x79,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x61, 0x67, 0x65, 0x44, 0x61, 0x79, 0x73, 0x12, 0x32, 0x0a,
+	0x15, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x73, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6c, 0x61, 0x73,
+	0x73, 0x22, 0xf5, 0x02, 0x0a, 0x05, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x03, 0x61,
+	0x76, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c,
+	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x61, 0x76, 0x67, 0x12, 0x2e, 0x0a, 0x03, 0x6d,
+	0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c,
+	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x2e, 0x0a, 0x03, 0x6d,
+	0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c,
+	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x2e, 0x0a, 0x03, 0x70,
+	0x35, 0x30, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c,
+	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x70, 0x35, 0x30, 0x12, 0x2e, 0x0a, 0x03, 0x70,
+	0x39, 0x30, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c,
+	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x70, 0x39, 0x30, 0x12, 0x2e, 0x0a, 0x03, 0x70,
+	0x39, 0x35, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c,
+	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x70, 0x39, 0x35, 0x12, 0x2e, 0x0a, 0x03, 0x70,
+	0x39, 0x39, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c,
+	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x70, 0x39, 0x39, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x22, 0xb3, 0x03, 0x0a, 0x19, 0x52, 0x69,
+	0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70,
+	0x75, 0x74, 0x65, 0x44, 0x69, 0x73, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67,
+	0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x73, 0x6b, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x69, 0x73, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x26, 0x0a,
+	0x0f, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x69, 0x6f, 0x70, 0x73, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6f, 0x70, 0x73,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x69,
+	0x6f, 0x70, 0x73, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0e, 0x77, 0x72, 0x69, 0x74, 0x65, 0x49, 0x6f, 0x70, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x32, 0x0a, 0x15, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70,
+	0x75, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13,
+	0x72, 0x65, 0x61, 0x64, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x12, 0x34, 0x0a, 0x16, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x74, 0x68, 0x72,
+	0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x14, 0x77, 0x72, 0x69, 0x74, 0x65, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67,
+	0x68, 0x70, 0x75, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x73,
+	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x12, 0x29, 0x0a,
+	0x10, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x5f, 0x69, 0x6f, 0x70,
+	0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x65, 0x64, 0x49, 0x6f, 0x70, 0x73, 0x12, 0x35, 0x0a, 0x16, 0x70, 0x72, 0x6f, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x5f, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70,
+	0x75, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x65, 0x64, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x22,
+	0xa5, 0x03, 0x0a, 0x1d, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47,
+	0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a,
+	0x0c, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x66, 0x61, 0x6d, 0x69,
+	0x6c, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e,
+	0x65, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x5f, 0x6d, 0x62, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x62, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x65, 0x65, 0x6d, 0x70,
+	0x74, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x70, 0x72, 0x65,
+	0x65, 0x6d, 0x70, 0x74, 0x69, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x73, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0f,
+	0x6f, 0x73, 0x5f, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x6f, 0x73, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65,
+	0x43, 0x6f, 0x73, 0x74, 0x12, 0x54, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x65, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x0c, 0x61, 0x63,
+	0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x63,
+	0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x73, 0x43, 0x6f, 0x73, 0x74, 0x22, 0xe2, 0x03, 0x0a, 0x2b, 0x47, 0x63, 0x70, 0x43,
+	0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x69,
+	0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x52, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67,
+	0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x5a, 0x0a, 0x0b, 0x72,
+	0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x38, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74,
+	0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x69, 0x67,
+	0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x12, 0x32, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70,
+	0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x38, 0x0a, 0x06, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x06, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x03, 0x67, 0x70, 0x75, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70,
+	0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x03, 0x67, 0x70, 0x75, 0x12, 0x3f, 0x0a, 0x0a, 0x67,
+	0x70, 0x75, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x09, 0x67, 0x70, 0x75, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x22, 0x80, 0x04, 0x0a,
+	0x1c, 0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x44, 0x69, 0x73, 0x6b, 0x52,
+	0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4e, 0x0a,
+	0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34,
+	0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74,
 	0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65,
-	0x44, 0x69, 0x73, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69,
-	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e,
-	0x12, 0x1b, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x73, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a,
-	0x09, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x08, 0x64, 0x69, 0x73, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65,
-	0x61, 0x64, 0x5f, 0x69, 0x6f, 0x70, 0x73, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0d, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6f, 0x70, 0x73, 0x4c, 0x69, 0x6d,
-	0x69, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x69, 0x6f, 0x70, 0x73,
-	0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x77, 0x72,
-	0x69, 0x74, 0x65, 0x49, 0x6f, 0x70, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x32, 0x0a, 0x15,
-	0x72, 0x65, 0x61, 0x64, 0x5f, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x5f,
-	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x72, 0x65, 0x61,
-	0x64, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74,
-	0x12, 0x34, 0x0a, 0x16, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67,
-	0x68, 0x70, 0x75, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x14, 0x77, 0x72, 0x69, 0x74, 0x65, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75,
-	0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x09,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x22, 0xa2, 0x02, 0x0a, 0x1d, 0x52,
+	0x44, 0x69, 0x73, 0x6b, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x56, 0x0a,
+	0x0b, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x34, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f,
+	0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52,
 	0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d,
-	0x70, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x63, 0x68,
-	0x69, 0x6e, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6d,
-	0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x46, 0x61, 0x6d, 0x69,
-	0x6c, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x03, 0x63, 0x70, 0x75, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6d,
-	0x62, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d,
-	0x62, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x65, 0x65, 0x6d, 0x70, 0x74, 0x69, 0x62, 0x6c, 0x65,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x65, 0x6d, 0x70, 0x74, 0x69,
-	0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6f, 0x73, 0x5f, 0x6c, 0x69,
-	0x63, 0x65, 0x6e, 0x73, 0x65, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x0d, 0x6f, 0x73, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x73, 0x74, 0x22,
-	0xed, 0x02, 0x0a, 0x2b, 0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x49, 0x6e,
+	0x70, 0x75, 0x74, 0x65, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x64, 0x65, 0x64, 0x12, 0x3d, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x69, 0x6f,
+	0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x72, 0x65, 0x61, 0x64,
+	0x49, 0x6f, 0x70, 0x73, 0x12, 0x3f, 0x0a, 0x0a, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x69, 0x6f,
+	0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x09, 0x77, 0x72, 0x69, 0x74,
+	0x65, 0x49, 0x6f, 0x70, 0x73, 0x12, 0x49, 0x0a, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x74, 0x68,
+	0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x0e, 0x72, 0x65, 0x61, 0x64, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74,
+	0x12, 0x4b, 0x0a, 0x10, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67,
+	0x68, 0x70, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0f, 0x77, 0x72,
+	0x69, 0x74, 0x65, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x8f, 0x03, 0x0a, 0x1e, 0x47, 0x43, 0x50, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x4f, 0x70,
+	0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x68, 0x0a, 0x0b, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e,
+	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x46, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x49, 0x6e,
 	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e,
-	0x67, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x67, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0b, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x12, 0x82, 0x01, 0x0a,
+	0x13, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x5f, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69,
+	0x7a, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x51, 0x2e, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x43, 0x50, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x52, 0x69, 0x67,
+	0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x12, 0x76,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e,
+	0x67, 0x1a, 0x7e, 0x0a, 0x17, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x52, 0x69, 0x67, 0x68,
+	0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x4d,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d,
+	0x70, 0x75, 0x74, 0x65, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0xd8, 0x03, 0x0a, 0x1b, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e,
+	0x67, 0x47, 0x63, 0x70, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a,
+	0x0d, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6c, 0x61,
+	0x73, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x75, 0x74, 0x6f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x61,
+	0x75, 0x74, 0x6f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12,
+	0x5b, 0x0a, 0x0f, 0x6c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x5f, 0x72, 0x75, 0x6c,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x63, 0x70, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c,
+	0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x0e, 0x6c, 0x69,
+	0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07,
+	0x73, 0x69, 0x7a, 0x65, 0x5f, 0x67, 0x62, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x73,
+	0x69, 0x7a, 0x65, 0x47, 0x62, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x43, 0x6f, 0x73,
+	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x61, 0x6c, 0x5f, 0x63,
+	0x6f, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x72, 0x65, 0x74, 0x72, 0x69,
+	0x65, 0x76, 0x61, 0x6c, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x73, 0x74,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e,
+	0x61, 0x75, 0x74, 0x6f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x61, 0x75, 0x74, 0x6f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x43,
+	0x6f, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x73, 0x74, 0x22, 0xe9, 0x03, 0x0a,
+	0x1e, 0x47, 0x63, 0x70, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x50, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x36, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74,
+	0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x69, 0x67,
+	0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47, 0x63, 0x70, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x12, 0x58, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67,
+	0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47, 0x63,
+	0x70, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x0b,
+	0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x12, 0x41, 0x0a, 0x0b, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74,
+	0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61,
+	0x67, 0x65, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x45,
+	0x0a, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63,
+	0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3f, 0x0a, 0x0a, 0x73, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x09, 0x73, 0x65, 0x6e,
+	0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x76, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x72, 0x69, 0x73, 0x6b, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x11, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x61, 0x6c, 0x43, 0x6f,
+	0x73, 0x74, 0x52, 0x69, 0x73, 0x6b, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x83, 0x01, 0x0a, 0x24, 0x47, 0x43, 0x50,
+	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x70, 0x74,
+	0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x5b, 0x0a, 0x0b, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67,
+	0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x63, 0x70, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x42, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0b, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x22, 0xae,
+	0x01, 0x0a, 0x21, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47, 0x63,
+	0x70, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x69, 0x65, 0x72,
+	0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f,
+	0x67, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x53, 0x69, 0x7a, 0x65, 0x47, 0x62, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x72,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x6f, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x22,
+	0xd3, 0x03, 0x0a, 0x24, 0x47, 0x63, 0x70, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x56, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e,
+	0x67, 0x47, 0x63, 0x70, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x12, 0x5e, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63,
+	0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47, 0x63, 0x70,
+	0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x12, 0x4e, 0x0a, 0x12, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65,
+	0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x10,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f,
+	0x12, 0x32, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x03, 0x63, 0x70, 0x75, 0x12, 0x4d, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x87, 0x01, 0x0a, 0x22, 0x47, 0x43, 0x50, 0x4d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x0b,
+	0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x3f, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70,
+	0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x63,
+	0x70, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0b, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x22,
+	0x98, 0x02, 0x0a, 0x1d, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47,
+	0x63, 0x70, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x75, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x70, 0x75,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x1b, 0x0a, 0x09, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6d, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x62, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63,
+	0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x69,
+	0x6e, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0c, 0x6d, 0x69, 0x6e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12,
+	0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x63, 0x70, 0x75, 0x5f, 0x61, 0x6c, 0x77, 0x61,
+	0x79, 0x73, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x12, 0x63, 0x70, 0x75, 0x41, 0x6c, 0x77, 0x61, 0x79, 0x73, 0x41, 0x6c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x22, 0xb6, 0x04, 0x0a, 0x20, 0x47,
+	0x63, 0x70, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x75, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
 	0x52, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x38, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74,
 	0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x69, 0x67,
-	0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75,
-	0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72,
+	0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47, 0x63, 0x70, 0x43, 0x6c, 0x6f, 0x75, 0x64,
+	0x52, 0x75, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72,
 	0x65, 0x6e, 0x74, 0x12, 0x5a, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64,
 	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
 	0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
 	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67,
-	0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x47, 0x63, 0x70, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x75, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69,
 	0x63, 0x65, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x12,
 	0x32, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70,
 	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a,
@@ -1179,81 +2633,96 @@ var file_plugin_proto_gcp_server_proto_rawDesc = []byte{
 	0x63, 0x70, 0x75, 0x12, 0x38, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e,
 	0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
-	0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x20, 0x0a,
-	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22,
-	0x80, 0x04, 0x0a, 0x1c, 0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x44, 0x69,
-	0x73, 0x6b, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x4e, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x34, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70,
-	0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x69,
-	0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70,
-	0x75, 0x74, 0x65, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
-	0x12, 0x56, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x47, 0x0a,
+	0x0e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63,
 	0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
-	0x31, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47, 0x63, 0x70,
-	0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x0b, 0x72, 0x65, 0x63,
-	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x12, 0x3d, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x64,
-	0x5f, 0x69, 0x6f, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c,
-	0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x72,
-	0x65, 0x61, 0x64, 0x49, 0x6f, 0x70, 0x73, 0x12, 0x3f, 0x0a, 0x0a, 0x77, 0x72, 0x69, 0x74, 0x65,
-	0x5f, 0x69, 0x6f, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c,
-	0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x09, 0x77,
-	0x72, 0x69, 0x74, 0x65, 0x49, 0x6f, 0x70, 0x73, 0x12, 0x49, 0x0a, 0x0f, 0x72, 0x65, 0x61, 0x64,
-	0x5f, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70,
-	0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73,
-	0x61, 0x67, 0x65, 0x52, 0x0e, 0x72, 0x65, 0x61, 0x64, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68,
-	0x70, 0x75, 0x74, 0x12, 0x4b, 0x0a, 0x10, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x74, 0x68, 0x72,
-	0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x45, 0x0a, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
 	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69,
 	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52,
-	0x0f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74,
-	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x22, 0x8f, 0x03, 0x0a, 0x1e, 0x47, 0x43, 0x50, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74,
-	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x68, 0x0a, 0x0b, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69,
-	0x7a, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x46, 0x2e, 0x70, 0x6c, 0x75,
-	0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x63, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74,
-	0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69,
-	0x7a, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x0b, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x12,
-	0x82, 0x01, 0x0a, 0x13, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x5f, 0x72, 0x69, 0x67, 0x68,
-	0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x51, 0x2e,
+	0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x42, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f,
+	0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x80, 0x01, 0x0a, 0x1f, 0x47, 0x43, 0x50, 0x43, 0x6c, 0x6f, 0x75, 0x64,
+	0x52, 0x75, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x0b, 0x72, 0x69, 0x67, 0x68, 0x74,
+	0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x63, 0x70, 0x43, 0x6c, 0x6f, 0x75,
+	0x64, 0x52, 0x75, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x72, 0x69, 0x67, 0x68, 0x74,
+	0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x22, 0xac, 0x01, 0x0a, 0x1a, 0x52, 0x69, 0x67, 0x68, 0x74,
+	0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47, 0x63, 0x70, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a,
+	0x14, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x75, 0x6c, 0x65,
+	0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x66, 0x6f, 0x72,
+	0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6c, 0x65, 0x43, 0x6f, 0x73, 0x74, 0x12,
+	0x30, 0x0a, 0x14, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x69,
+	0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x64,
+	0x61, 0x74, 0x61, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x04, 0x63, 0x6f, 0x73, 0x74, 0x22, 0xf3, 0x03, 0x0a, 0x1d, 0x47, 0x63, 0x70, 0x4c, 0x6f, 0x61,
+	0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4f, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67,
+	0x47, 0x63, 0x70, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x52,
+	0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x57, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e,
 	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69,
-	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x43, 0x50, 0x43, 0x6f, 0x6d,
-	0x70, 0x75, 0x74, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73,
-	0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x12, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69,
-	0x7a, 0x69, 0x6e, 0x67, 0x1a, 0x7e, 0x0a, 0x17, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x52,
-	0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x4d, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x37, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74,
-	0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x63, 0x70,
-	0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x63, 0x6f, 0x6d,
-	0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x32, 0x9e, 0x01, 0x0a, 0x0c, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x8d, 0x01, 0x0a, 0x16, 0x47, 0x43, 0x50, 0x43, 0x6f, 0x6d,
-	0x70, 0x75, 0x74, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x38, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74,
-	0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x43, 0x50,
-	0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x39, 0x2e, 0x70, 0x6c, 0x75,
-	0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x43, 0x50, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74,
-	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x61, 0x79, 0x74, 0x75, 0x2d, 0x69, 0x6f, 0x2f, 0x70, 0x6c, 0x75,
-	0x67, 0x69, 0x6e, 0x2d, 0x67, 0x63, 0x70, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2f,
-	0x67, 0x63, 0x70, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73,
+	0x69, 0x7a, 0x69, 0x6e, 0x67, 0x47, 0x63, 0x70, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x72, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x12, 0x45, 0x0a, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x45, 0x0a, 0x0d, 0x69, 0x6e, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x0c, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x43, 0x0a, 0x0c, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63,
+	0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0b, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x64, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x04, 0x69, 0x64, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x64, 0x6c, 0x65,
+	0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69,
+	0x64, 0x6c, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x81, 0x01, 0x0a, 0x23,
+	0x47, 0x43, 0x50, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x4f,
+	0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x0b, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69,
+	0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x63, 0x70, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x72, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0b, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x69, 0x7a, 0x69, 0x6e, 0x67, 0x32,
+	0x9e, 0x01, 0x0a, 0x0c, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x8d, 0x01, 0x0a, 0x16, 0x47, 0x43, 0x50, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x4f,
+	0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x38, 0x2e, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x67, 0x63, 0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x43, 0x50, 0x43, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x39, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x67, 0x63,
+	0x70, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x43, 0x50, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x4f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f,
+	0x70, 0x65, 0x6e, 0x67, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x2d, 0x67, 0x63, 0x70, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2f, 0x67, 0x63, 0x70,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1268,72 +2737,122 @@ func file_plugin_proto_gcp_server_proto_rawDescGZIP() []byte {
 	return file_plugin_proto_gcp_server_proto_rawDescData
 }
 
-var file_plugin_proto_gcp_server_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
-var file_plugin_proto_gcp_server_proto_goTypes = []interface{}{
-	(*GcpComputeInstance)(nil),                          // 0: plugingcp.optimization.v1.GcpComputeInstance
-	(*GcpComputeDisk)(nil),                              // 1: plugingcp.optimization.v1.GcpComputeDisk
-	(*DataPoint)(nil),                                   // 2: plugingcp.optimization.v1.DataPoint
-	(*Metric)(nil),                                      // 3: plugingcp.optimization.v1.Metric
-	(*DiskMetrics)(nil),                                 // 4: plugingcp.optimization.v1.DiskMetrics
-	(*GCPComputeOptimizationRequest)(nil),               // 5: plugingcp.optimization.v1.GCPComputeOptimizationRequest
-	(*Usage)(nil),                                       // 6: plugingcp.optimization.v1.Usage
-	(*RightsizingGcpComputeDisk)(nil),                   // 7: plugingcp.optimization.v1.RightsizingGcpComputeDisk
-	(*RightsizingGcpComputeInstance)(nil),               // 8: plugingcp.optimization.v1.RightsizingGcpComputeInstance
-	(*GcpComputeInstanceRightsizingRecommendation)(nil), // 9: plugingcp.optimization.v1.GcpComputeInstanceRightsizingRecommendation
-	(*GcpComputeDiskRecommendation)(nil),                // 10: plugingcp.optimization.v1.GcpComputeDiskRecommendation
-	(*GCPComputeOptimizationResponse)(nil),              // 11: plugingcp.optimization.v1.GCPComputeOptimizationResponse
-	nil,                                                 // 12: plugingcp.optimization.v1.DiskMetrics.MetricsEntry
-	nil,                                                 // 13: plugingcp.optimization.v1.GCPComputeOptimizationRequest.IdentificationEntry
-	nil,                                                 // 14: plugingcp.optimization.v1.GCPComputeOptimizationRequest.PreferencesEntry
-	nil,                                                 // 15: plugingcp.optimization.v1.GCPComputeOptimizationRequest.MetricsEntry
-	nil,                                                 // 16: plugingcp.optimization.v1.GCPComputeOptimizationRequest.DisksMetricsEntry
-	nil,                                                 // 17: plugingcp.optimization.v1.GCPComputeOptimizationResponse.VolumesRightsizingEntry
-	(*wrappers.Int64Value)(nil),                         // 18: google.protobuf.Int64Value
-	(*wrappers.StringValue)(nil),                        // 19: google.protobuf.StringValue
-	(*wrappers.DoubleValue)(nil),                        // 20: google.protobuf.DoubleValue
+var file_plugin_proto_gcp_server_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_plugin_proto_gcp_server_proto_goTypes = []any{
+	(*GcpComputeAccelerator)(nil),                       // 0: plugingcp.optimization.v1.GcpComputeAccelerator
+	(*GcpComputeInstance)(nil),                          // 1: plugingcp.optimization.v1.GcpComputeInstance
+	(*GcpComputeDisk)(nil),                              // 2: plugingcp.optimization.v1.GcpComputeDisk
+	(*DataPoint)(nil),                                   // 3: plugingcp.optimization.v1.DataPoint
+	(*Metric)(nil),                                      // 4: plugingcp.optimization.v1.Metric
+	(*DiskMetrics)(nil),                                 // 5: plugingcp.optimization.v1.DiskMetrics
+	(*GCPComputeOptimizationRequest)(nil),               // 6: plugingcp.optimization.v1.GCPComputeOptimizationRequest
+	(*GcpStorageLifecycleRule)(nil),                     // 7: plugingcp.optimization.v1.GcpStorageLifecycleRule
+	(*Usage)(nil),                                       // 8: plugingcp.optimization.v1.Usage
+	(*RightsizingGcpComputeDisk)(nil),                   // 9: plugingcp.optimization.v1.RightsizingGcpComputeDisk
+	(*RightsizingGcpComputeInstance)(nil),               // 10: plugingcp.optimization.v1.RightsizingGcpComputeInstance
+	(*GcpComputeInstanceRightsizingRecommendation)(nil), // 11: plugingcp.optimization.v1.GcpComputeInstanceRightsizingRecommendation
+	(*GcpComputeDiskRecommendation)(nil),                // 12: plugingcp.optimization.v1.GcpComputeDiskRecommendation
+	(*GCPComputeOptimizationResponse)(nil),              // 13: plugingcp.optimization.v1.GCPComputeOptimizationResponse
+	(*RightsizingGcpStorageBucket)(nil),                 // 14: plugingcp.optimization.v1.RightsizingGcpStorageBucket
+	(*GcpStorageBucketRecommendation)(nil),              // 15: plugingcp.optimization.v1.GcpStorageBucketRecommendation
+	(*GCPStorageBucketOptimizationResponse)(nil),        // 16: plugingcp.optimization.v1.GCPStorageBucketOptimizationResponse
+	(*RightsizingGcpMemorystoreInstance)(nil),           // 17: plugingcp.optimization.v1.RightsizingGcpMemorystoreInstance
+	(*GcpMemorystoreInstanceRecommendation)(nil),        // 18: plugingcp.optimization.v1.GcpMemorystoreInstanceRecommendation
+	(*GCPMemorystoreOptimizationResponse)(nil),          // 19: plugingcp.optimization.v1.GCPMemorystoreOptimizationResponse
+	(*RightsizingGcpCloudRunService)(nil),               // 20: plugingcp.optimization.v1.RightsizingGcpCloudRunService
+	(*GcpCloudRunServiceRecommendation)(nil),            // 21: plugingcp.optimization.v1.GcpCloudRunServiceRecommendation
+	(*GCPCloudRunOptimizationResponse)(nil),             // 22: plugingcp.optimization.v1.GCPCloudRunOptimizationResponse
+	(*RightsizingGcpLoadBalancer)(nil),                  // 23: plugingcp.optimization.v1.RightsizingGcpLoadBalancer
+	(*GcpLoadBalancerRecommendation)(nil),               // 24: plugingcp.optimization.v1.GcpLoadBalancerRecommendation
+	(*GCPLoadBalancerOptimizationResponse)(nil),         // 25: plugingcp.optimization.v1.GCPLoadBalancerOptimizationResponse
+	nil,                          // 26: plugingcp.optimization.v1.DiskMetrics.MetricsEntry
+	nil,                          // 27: plugingcp.optimization.v1.GCPComputeOptimizationRequest.IdentificationEntry
+	nil,                          // 28: plugingcp.optimization.v1.GCPComputeOptimizationRequest.PreferencesEntry
+	nil,                          // 29: plugingcp.optimization.v1.GCPComputeOptimizationRequest.MetricsEntry
+	nil,                          // 30: plugingcp.optimization.v1.GCPComputeOptimizationRequest.DisksMetricsEntry
+	nil,                          // 31: plugingcp.optimization.v1.GCPComputeOptimizationResponse.VolumesRightsizingEntry
+	(*wrappers.Int64Value)(nil),  // 32: google.protobuf.Int64Value
+	(*wrappers.StringValue)(nil), // 33: google.protobuf.StringValue
+	(*wrappers.DoubleValue)(nil), // 34: google.protobuf.DoubleValue
 }
 var file_plugin_proto_gcp_server_proto_depIdxs = []int32{
-	18, // 0: plugingcp.optimization.v1.GcpComputeDisk.disk_size:type_name -> google.protobuf.Int64Value
-	18, // 1: plugingcp.optimization.v1.GcpComputeDisk.provisioned_iops:type_name -> google.protobuf.Int64Value
-	18, // 2: plugingcp.optimization.v1.DataPoint.start_time:type_name -> google.protobuf.Int64Value
-	18, // 3: plugingcp.optimization.v1.DataPoint.end_time:type_name -> google.protobuf.Int64Value
-	2,  // 4: plugingcp.optimization.v1.Metric.data:type_name -> plugingcp.optimization.v1.DataPoint
-	12, // 5: plugingcp.optimization.v1.DiskMetrics.metrics:type_name -> plugingcp.optimization.v1.DiskMetrics.MetricsEntry
-	19, // 6: plugingcp.optimization.v1.GCPComputeOptimizationRequest.request_id:type_name -> google.protobuf.StringValue
-	19, // 7: plugingcp.optimization.v1.GCPComputeOptimizationRequest.cli_version:type_name -> google.protobuf.StringValue
-	13, // 8: plugingcp.optimization.v1.GCPComputeOptimizationRequest.identification:type_name -> plugingcp.optimization.v1.GCPComputeOptimizationRequest.IdentificationEntry
-	0,  // 9: plugingcp.optimization.v1.GCPComputeOptimizationRequest.instance:type_name -> plugingcp.optimization.v1.GcpComputeInstance
-	1,  // 10: plugingcp.optimization.v1.GCPComputeOptimizationRequest.disks:type_name -> plugingcp.optimization.v1.GcpComputeDisk
-	14, // 11: plugingcp.optimization.v1.GCPComputeOptimizationRequest.preferences:type_name -> plugingcp.optimization.v1.GCPComputeOptimizationRequest.PreferencesEntry
-	15, // 12: plugingcp.optimization.v1.GCPComputeOptimizationRequest.metrics:type_name -> plugingcp.optimization.v1.GCPComputeOptimizationRequest.MetricsEntry
-	16, // 13: plugingcp.optimization.v1.GCPComputeOptimizationRequest.disks_metrics:type_name -> plugingcp.optimization.v1.GCPComputeOptimizationRequest.DisksMetricsEntry
-	20, // 14: plugingcp.optimization.v1.Usage.avg:type_name -> google.protobuf.DoubleValue
-	20, // 15: plugingcp.optimization.v1.Usage.max:type_name -> google.protobuf.DoubleValue
-	20, // 16: plugingcp.optimization.v1.Usage.min:type_name -> google.protobuf.DoubleValue
-	8,  // 17: plugingcp.optimization.v1.GcpComputeInstanceRightsizingRecommendation.current:type_name -> plugingcp.optimization.v1.RightsizingGcpComputeInstance
-	8,  // 18: plugingcp.optimization.v1.GcpComputeInstanceRightsizingRecommendation.recommended:type_name -> plugingcp.optimization.v1.RightsizingGcpComputeInstance
-	6,  // 19: plugingcp.optimization.v1.GcpComputeInstanceRightsizingRecommendation.cpu:type_name -> plugingcp.optimization.v1.Usage
-	6,  // 20: plugingcp.optimization.v1.GcpComputeInstanceRightsizingRecommendation.memory:type_name -> plugingcp.optimization.v1.Usage
-	7,  // 21: plugingcp.optimization.v1.GcpComputeDiskRecommendation.current:type_name -> plugingcp.optimization.v1.RightsizingGcpComputeDisk
-	7,  // 22: plugingcp.optimization.v1.GcpComputeDiskRecommendation.recommended:type_name -> plugingcp.optimization.v1.RightsizingGcpComputeDisk
-	6,  // 23: plugingcp.optimization.v1.GcpComputeDiskRecommendation.read_iops:type_name -> plugingcp.optimization.v1.Usage
-	6,  // 24: plugingcp.optimization.v1.GcpComputeDiskRecommendation.write_iops:type_name -> plugingcp.optimization.v1.Usage
-	6,  // 25: plugingcp.optimization.v1.GcpComputeDiskRecommendation.read_throughput:type_name -> plugingcp.optimization.v1.Usage
-	6,  // 26: plugingcp.optimization.v1.GcpComputeDiskRecommendation.write_throughput:type_name -> plugingcp.optimization.v1.Usage
-	9,  // 27: plugingcp.optimization.v1.GCPComputeOptimizationResponse.rightsizing:type_name -> plugingcp.optimization.v1.GcpComputeInstanceRightsizingRecommendation
-	17, // 28: plugingcp.optimization.v1.GCPComputeOptimizationResponse.volumes_rightsizing:type_name -> plugingcp.optimization.v1.GCPComputeOptimizationResponse.VolumesRightsizingEntry
-	3,  // 29: plugingcp.optimization.v1.DiskMetrics.MetricsEntry.value:type_name -> plugingcp.optimization.v1.Metric
-	19, // 30: plugingcp.optimization.v1.GCPComputeOptimizationRequest.PreferencesEntry.value:type_name -> google.protobuf.StringValue
-	3,  // 31: plugingcp.optimization.v1.GCPComputeOptimizationRequest.MetricsEntry.value:type_name -> plugingcp.optimization.v1.Metric
-	4,  // 32: plugingcp.optimization.v1.GCPComputeOptimizationRequest.DisksMetricsEntry.value:type_name -> plugingcp.optimization.v1.DiskMetrics
-	10, // 33: plugingcp.optimization.v1.GCPComputeOptimizationResponse.VolumesRightsizingEntry.value:type_name -> plugingcp.optimization.v1.GcpComputeDiskRecommendation
-	5,  // 34: plugingcp.optimization.v1.Optimization.GCPComputeOptimization:input_type -> plugingcp.optimization.v1.GCPComputeOptimizationRequest
-	11, // 35: plugingcp.optimization.v1.Optimization.GCPComputeOptimization:output_type -> plugingcp.optimization.v1.GCPComputeOptimizationResponse
-	35, // [35:36] is the sub-list for method output_type
-	34, // [34:35] is the sub-list for method input_type
-	34, // [34:34] is the sub-list for extension type_name
-	34, // [34:34] is the sub-list for extension extendee
-	0,  // [0:34] is the sub-list for field type_name
+	0,  // 0: plugingcp.optimization.v1.GcpComputeInstance.accelerators:type_name -> plugingcp.optimization.v1.GcpComputeAccelerator
+	32, // 1: plugingcp.optimization.v1.GcpComputeDisk.disk_size:type_name -> google.protobuf.Int64Value
+	32, // 2: plugingcp.optimization.v1.GcpComputeDisk.provisioned_iops:type_name -> google.protobuf.Int64Value
+	32, // 3: plugingcp.optimization.v1.DataPoint.start_time:type_name -> google.protobuf.Int64Value
+	32, // 4: plugingcp.optimization.v1.DataPoint.end_time:type_name -> google.protobuf.Int64Value
+	3,  // 5: plugingcp.optimization.v1.Metric.data:type_name -> plugingcp.optimization.v1.DataPoint
+	26, // 6: plugingcp.optimization.v1.DiskMetrics.metrics:type_name -> plugingcp.optimization.v1.DiskMetrics.MetricsEntry
+	33, // 7: plugingcp.optimization.v1.GCPComputeOptimizationRequest.request_id:type_name -> google.protobuf.StringValue
+	33, // 8: plugingcp.optimization.v1.GCPComputeOptimizationRequest.cli_version:type_name -> google.protobuf.StringValue
+	27, // 9: plugingcp.optimization.v1.GCPComputeOptimizationRequest.identification:type_name -> plugingcp.optimization.v1.GCPComputeOptimizationRequest.IdentificationEntry
+	1,  // 10: plugingcp.optimization.v1.GCPComputeOptimizationRequest.instance:type_name -> plugingcp.optimization.v1.GcpComputeInstance
+	2,  // 11: plugingcp.optimization.v1.GCPComputeOptimizationRequest.disks:type_name -> plugingcp.optimization.v1.GcpComputeDisk
+	28, // 12: plugingcp.optimization.v1.GCPComputeOptimizationRequest.preferences:type_name -> plugingcp.optimization.v1.GCPComputeOptimizationRequest.PreferencesEntry
+	29, // 13: plugingcp.optimization.v1.GCPComputeOptimizationRequest.metrics:type_name -> plugingcp.optimization.v1.GCPComputeOptimizationRequest.MetricsEntry
+	30, // 14: plugingcp.optimization.v1.GCPComputeOptimizationRequest.disks_metrics:type_name -> plugingcp.optimization.v1.GCPComputeOptimizationRequest.DisksMetricsEntry
+	32, // 15: plugingcp.optimization.v1.GcpStorageLifecycleRule.age_days:type_name -> google.protobuf.Int64Value
+	34, // 16: plugingcp.optimization.v1.Usage.avg:type_name -> google.protobuf.DoubleValue
+	34, // 17: plugingcp.optimization.v1.Usage.max:type_name -> google.protobuf.DoubleValue
+	34, // 18: plugingcp.optimization.v1.Usage.min:type_name -> google.protobuf.DoubleValue
+	34, // 19: plugingcp.optimization.v1.Usage.p50:type_name -> google.protobuf.DoubleValue
+	34, // 20: plugingcp.optimization.v1.Usage.p90:type_name -> google.protobuf.DoubleValue
+	34, // 21: plugingcp.optimization.v1.Usage.p95:type_name -> google.protobuf.DoubleValue
+	34, // 22: plugingcp.optimization.v1.Usage.p99:type_name -> google.protobuf.DoubleValue
+	0,  // 23: plugingcp.optimization.v1.RightsizingGcpComputeInstance.accelerators:type_name -> plugingcp.optimization.v1.GcpComputeAccelerator
+	10, // 24: plugingcp.optimization.v1.GcpComputeInstanceRightsizingRecommendation.current:type_name -> plugingcp.optimization.v1.RightsizingGcpComputeInstance
+	10, // 25: plugingcp.optimization.v1.GcpComputeInstanceRightsizingRecommendation.recommended:type_name -> plugingcp.optimization.v1.RightsizingGcpComputeInstance
+	8,  // 26: plugingcp.optimization.v1.GcpComputeInstanceRightsizingRecommendation.cpu:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 27: plugingcp.optimization.v1.GcpComputeInstanceRightsizingRecommendation.memory:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 28: plugingcp.optimization.v1.GcpComputeInstanceRightsizingRecommendation.gpu:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 29: plugingcp.optimization.v1.GcpComputeInstanceRightsizingRecommendation.gpu_memory:type_name -> plugingcp.optimization.v1.Usage
+	9,  // 30: plugingcp.optimization.v1.GcpComputeDiskRecommendation.current:type_name -> plugingcp.optimization.v1.RightsizingGcpComputeDisk
+	9,  // 31: plugingcp.optimization.v1.GcpComputeDiskRecommendation.recommended:type_name -> plugingcp.optimization.v1.RightsizingGcpComputeDisk
+	8,  // 32: plugingcp.optimization.v1.GcpComputeDiskRecommendation.read_iops:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 33: plugingcp.optimization.v1.GcpComputeDiskRecommendation.write_iops:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 34: plugingcp.optimization.v1.GcpComputeDiskRecommendation.read_throughput:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 35: plugingcp.optimization.v1.GcpComputeDiskRecommendation.write_throughput:type_name -> plugingcp.optimization.v1.Usage
+	11, // 36: plugingcp.optimization.v1.GCPComputeOptimizationResponse.rightsizing:type_name -> plugingcp.optimization.v1.GcpComputeInstanceRightsizingRecommendation
+	31, // 37: plugingcp.optimization.v1.GCPComputeOptimizationResponse.volumes_rightsizing:type_name -> plugingcp.optimization.v1.GCPComputeOptimizationResponse.VolumesRightsizingEntry
+	7,  // 38: plugingcp.optimization.v1.RightsizingGcpStorageBucket.lifecycle_rules:type_name -> plugingcp.optimization.v1.GcpStorageLifecycleRule
+	14, // 39: plugingcp.optimization.v1.GcpStorageBucketRecommendation.current:type_name -> plugingcp.optimization.v1.RightsizingGcpStorageBucket
+	14, // 40: plugingcp.optimization.v1.GcpStorageBucketRecommendation.recommended:type_name -> plugingcp.optimization.v1.RightsizingGcpStorageBucket
+	8,  // 41: plugingcp.optimization.v1.GcpStorageBucketRecommendation.total_bytes:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 42: plugingcp.optimization.v1.GcpStorageBucketRecommendation.request_count:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 43: plugingcp.optimization.v1.GcpStorageBucketRecommendation.sent_bytes:type_name -> plugingcp.optimization.v1.Usage
+	15, // 44: plugingcp.optimization.v1.GCPStorageBucketOptimizationResponse.rightsizing:type_name -> plugingcp.optimization.v1.GcpStorageBucketRecommendation
+	17, // 45: plugingcp.optimization.v1.GcpMemorystoreInstanceRecommendation.current:type_name -> plugingcp.optimization.v1.RightsizingGcpMemorystoreInstance
+	17, // 46: plugingcp.optimization.v1.GcpMemorystoreInstanceRecommendation.recommended:type_name -> plugingcp.optimization.v1.RightsizingGcpMemorystoreInstance
+	8,  // 47: plugingcp.optimization.v1.GcpMemorystoreInstanceRecommendation.memory_usage_ratio:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 48: plugingcp.optimization.v1.GcpMemorystoreInstanceRecommendation.cpu:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 49: plugingcp.optimization.v1.GcpMemorystoreInstanceRecommendation.connected_clients:type_name -> plugingcp.optimization.v1.Usage
+	18, // 50: plugingcp.optimization.v1.GCPMemorystoreOptimizationResponse.rightsizing:type_name -> plugingcp.optimization.v1.GcpMemorystoreInstanceRecommendation
+	20, // 51: plugingcp.optimization.v1.GcpCloudRunServiceRecommendation.current:type_name -> plugingcp.optimization.v1.RightsizingGcpCloudRunService
+	20, // 52: plugingcp.optimization.v1.GcpCloudRunServiceRecommendation.recommended:type_name -> plugingcp.optimization.v1.RightsizingGcpCloudRunService
+	8,  // 53: plugingcp.optimization.v1.GcpCloudRunServiceRecommendation.cpu:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 54: plugingcp.optimization.v1.GcpCloudRunServiceRecommendation.memory:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 55: plugingcp.optimization.v1.GcpCloudRunServiceRecommendation.instance_count:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 56: plugingcp.optimization.v1.GcpCloudRunServiceRecommendation.request_count:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 57: plugingcp.optimization.v1.GcpCloudRunServiceRecommendation.concurrency:type_name -> plugingcp.optimization.v1.Usage
+	21, // 58: plugingcp.optimization.v1.GCPCloudRunOptimizationResponse.rightsizing:type_name -> plugingcp.optimization.v1.GcpCloudRunServiceRecommendation
+	23, // 59: plugingcp.optimization.v1.GcpLoadBalancerRecommendation.current:type_name -> plugingcp.optimization.v1.RightsizingGcpLoadBalancer
+	23, // 60: plugingcp.optimization.v1.GcpLoadBalancerRecommendation.recommended:type_name -> plugingcp.optimization.v1.RightsizingGcpLoadBalancer
+	8,  // 61: plugingcp.optimization.v1.GcpLoadBalancerRecommendation.request_count:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 62: plugingcp.optimization.v1.GcpLoadBalancerRecommendation.ingress_bytes:type_name -> plugingcp.optimization.v1.Usage
+	8,  // 63: plugingcp.optimization.v1.GcpLoadBalancerRecommendation.egress_bytes:type_name -> plugingcp.optimization.v1.Usage
+	24, // 64: plugingcp.optimization.v1.GCPLoadBalancerOptimizationResponse.rightsizing:type_name -> plugingcp.optimization.v1.GcpLoadBalancerRecommendation
+	4,  // 65: plugingcp.optimization.v1.DiskMetrics.MetricsEntry.value:type_name -> plugingcp.optimization.v1.Metric
+	33, // 66: plugingcp.optimization.v1.GCPComputeOptimizationRequest.PreferencesEntry.value:type_name -> google.protobuf.StringValue
+	4,  // 67: plugingcp.optimization.v1.GCPComputeOptimizationRequest.MetricsEntry.value:type_name -> plugingcp.optimization.v1.Metric
+	5,  // 68: plugingcp.optimization.v1.GCPComputeOptimizationRequest.DisksMetricsEntry.value:type_name -> plugingcp.optimization.v1.DiskMetrics
+	12, // 69: plugingcp.optimization.v1.GCPComputeOptimizationResponse.VolumesRightsizingEntry.value:type_name -> plugingcp.optimization.v1.GcpComputeDiskRecommendation
+	6,  // 70: plugingcp.optimization.v1.Optimization.GCPComputeOptimization:input_type -> plugingcp.optimization.v1.GCPComputeOptimizationRequest
+	13, // 71: plugingcp.optimization.v1.Optimization.GCPComputeOptimization:output_type -> plugingcp.optimization.v1.GCPComputeOptimizationResponse
+	71, // [71:72] is the sub-list for method output_type
+	70, // [70:71] is the sub-list for method input_type
+	70, // [70:70] is the sub-list for extension type_name
+	70, // [70:70] is the sub-list for extension extendee
+	0,  // [0:70] is the sub-list for field type_name
 }
 
 func init() { file_plugin_proto_gcp_server_proto_init() }
@@ -1342,7 +2861,19 @@ func file_plugin_proto_gcp_server_proto_init() {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_plugin_proto_gcp_server_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_proto_gcp_server_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*GcpComputeAccelerator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plugin_proto_gcp_server_proto_msgTypes[1].Exporter = func(v any, i int) any {
 			switch v := v.(*GcpComputeInstance); i {
 			case 0:
 				return &v.state
@@ -1354,7 +2885,7 @@ func file_plugin_proto_gcp_server_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_proto_gcp_server_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_proto_gcp_server_proto_msgTypes[2].Exporter = func(v any, i int) any {
 			switch v := v.(*GcpComputeDisk); i {
 			case 0:
 				return &v.state
@@ -1366,7 +2897,7 @@ func file_plugin_proto_gcp_server_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_proto_gcp_server_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_proto_gcp_server_proto_msgTypes[3].Exporter = func(v any, i int) any {
 			switch v := v.(*DataPoint); i {
 			case 0:
 				return &v.state
@@ -1378,7 +2909,7 @@ func file_plugin_proto_gcp_server_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_proto_gcp_server_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_proto_gcp_server_proto_msgTypes[4].Exporter = func(v any, i int) any {
 			switch v := v.(*Metric); i {
 			case 0:
 				return &v.state
@@ -1390,7 +2921,7 @@ func file_plugin_proto_gcp_server_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_proto_gcp_server_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_proto_gcp_server_proto_msgTypes[5].Exporter = func(v any, i int) any {
 			switch v := v.(*DiskMetrics); i {
 			case 0:
 				return &v.state
@@ -1402,7 +2933,7 @@ func file_plugin_proto_gcp_server_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_proto_gcp_server_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_proto_gcp_server_proto_msgTypes[6].Exporter = func(v any, i int) any {
 			switch v := v.(*GCPComputeOptimizationRequest); i {
 			case 0:
 				return &v.state
@@ -1414,7 +2945,19 @@ func file_plugin_proto_gcp_server_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_proto_gcp_server_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_proto_gcp_server_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*GcpStorageLifecycleRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plugin_proto_gcp_server_proto_msgTypes[8].Exporter = func(v any, i int) any {
 			switch v := v.(*Usage); i {
 			case 0:
 				return &v.state
@@ -1426,7 +2969,7 @@ func file_plugin_proto_gcp_server_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_proto_gcp_server_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_proto_gcp_server_proto_msgTypes[9].Exporter = func(v any, i int) any {
 			switch v := v.(*RightsizingGcpComputeDisk); i {
 			case 0:
 				return &v.state
@@ -1438,7 +2981,7 @@ func file_plugin_proto_gcp_server_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_proto_gcp_server_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_proto_gcp_server_proto_msgTypes[10].Exporter = func(v any, i int) any {
 			switch v := v.(*RightsizingGcpComputeInstance); i {
 			case 0:
 				return &v.state
@@ -1450,7 +2993,7 @@ func file_plugin_proto_gcp_server_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_proto_gcp_server_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_proto_gcp_server_proto_msgTypes[11].Exporter = func(v any, i int) any {
 			switch v := v.(*GcpComputeInstanceRightsizingRecommendation); i {
 			case 0:
 				return &v.state
@@ -1462,7 +3005,7 @@ func file_plugin_proto_gcp_server_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_proto_gcp_server_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_proto_gcp_server_proto_msgTypes[12].Exporter = func(v any, i int) any {
 			switch v := v.(*GcpComputeDiskRecommendation); i {
 			case 0:
 				return &v.state
@@ -1474,7 +3017,7 @@ func file_plugin_proto_gcp_server_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_proto_gcp_server_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_proto_gcp_server_proto_msgTypes[13].Exporter = func(v any, i int) any {
 			switch v := v.(*GCPComputeOptimizationResponse); i {
 			case 0:
 				return &v.state
@@ -1486,6 +3029,150 @@ func file_plugin_proto_gcp_server_proto_init() {
 				return nil
 			}
 		}
+		file_plugin_proto_gcp_server_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*RightsizingGcpStorageBucket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plugin_proto_gcp_server_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*GcpStorageBucketRecommendation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plugin_proto_gcp_server_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*GCPStorageBucketOptimizationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plugin_proto_gcp_server_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*RightsizingGcpMemorystoreInstance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plugin_proto_gcp_server_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*GcpMemorystoreInstanceRecommendation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plugin_proto_gcp_server_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*GCPMemorystoreOptimizationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plugin_proto_gcp_server_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*RightsizingGcpCloudRunService); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plugin_proto_gcp_server_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*GcpCloudRunServiceRecommendation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plugin_proto_gcp_server_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*GCPCloudRunOptimizationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plugin_proto_gcp_server_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*RightsizingGcpLoadBalancer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plugin_proto_gcp_server_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*GcpLoadBalancerRecommendation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plugin_proto_gcp_server_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*GCPLoadBalancerOptimizationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1493,7 +3180,7 @@ func file_plugin_proto_gcp_server_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_plugin_proto_gcp_server_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   18,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
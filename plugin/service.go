@@ -9,13 +9,19 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/oauth"
 	"log"
+	"time"
 
 	"github.com/kaytu-io/kaytu/pkg/plugin/proto/src/golang"
 	"github.com/kaytu-io/kaytu/pkg/plugin/sdk"
 	"github.com/opengovern/plugin-gcp/plugin/gcp"
 	"github.com/opengovern/plugin-gcp/plugin/preferences"
 	"github.com/opengovern/plugin-gcp/plugin/processor"
+	"github.com/opengovern/plugin-gcp/plugin/processor/cloud_run"
 	"github.com/opengovern/plugin-gcp/plugin/processor/compute_instance"
+	"github.com/opengovern/plugin-gcp/plugin/processor/load_balancer"
+	"github.com/opengovern/plugin-gcp/plugin/processor/memorystore"
+	"github.com/opengovern/plugin-gcp/plugin/processor/shared"
+	"github.com/opengovern/plugin-gcp/plugin/processor/storage_bucket"
 	"github.com/opengovern/plugin-gcp/plugin/version"
 )
 
@@ -28,6 +34,45 @@ func NewPlugin() *GCPPlugin {
 	return &GCPPlugin{}
 }
 
+// sharedFlags are the flags of every command, for authentication, the metrics cache and the GCP API rate limits
+var sharedFlags = []*golang.Flag{
+	{
+		Name:        "profile",
+		Default:     "",
+		Description: "GCP profile for authentication",
+		Required:    false,
+	},
+	{
+		Name:        "no-cache",
+		Default:     "false",
+		Description: "Fetch all metrics again instead of reusing the ones cached by previous runs",
+		Required:    false,
+	},
+	{
+		Name:        "metrics-cache-ttl",
+		Default:     "168h",
+		Description: "How long cached metrics are reused before they are fetched again in full",
+		Required:    false,
+	},
+	{
+		Name:        "api-rate-limit",
+		Default:     "",
+		Description: "Requests per second allowed per GCP API, as a default rate and/or api=rate pairs (e.g. 10 or compute=10,monitoring=40)",
+		Required:    false,
+	},
+	{
+		Name:        "api-max-retries",
+		Default:     "5",
+		Description: "How many times a throttled GCP API call is retried before failing",
+		Required:    false,
+	},
+}
+
+// commandFlags returns the shared flags followed by the flags of the command
+func commandFlags(flags []*golang.Flag) []*golang.Flag {
+	return append(append([]*golang.Flag{}, sharedFlags...), flags...)
+}
+
 func (p *GCPPlugin) GetConfig(_ context.Context) golang.RegisterConfig {
 	return golang.RegisterConfig{
 		Name:     "kaytu-io/plugin-gcp",
@@ -37,17 +82,165 @@ func (p *GCPPlugin) GetConfig(_ context.Context) golang.RegisterConfig {
 			{
 				Name:        "compute-instance",
 				Description: "Get optimization suggestions for your Compute Engine Instances",
-				Flags: []*golang.Flag{
+				Flags: commandFlags([]*golang.Flag{
 					{
-						Name:        "profile",
+						Name:        "billing-export-table",
 						Default:     "",
-						Description: "GCP profile for authentication",
+						Description: "BigQuery detailed billing export table (project.dataset.table) to read actual costs from",
 						Required:    false,
 					},
-				},
+					{
+						Name:        "billing-export-file",
+						Default:     "",
+						Description: "Local CSV or Parquet (.parquet) dump of the detailed billing export to read actual costs from",
+						Required:    false,
+					},
+					{
+						Name:        "json-export",
+						Default:     "",
+						Description: "File to write the full recommendations to as JSON (or JSON Lines with the .jsonl extension), rewritten after every re-evaluation",
+						Required:    false,
+					},
+					{
+						Name:        "terraform-export",
+						Default:     "",
+						Description: "File to write the Terraform changes applying the recommendations to",
+						Required:    false,
+					},
+					{
+						Name:        "terraform-dir",
+						Default:     "",
+						Description: "Terraform directory to match resources in, makes terraform-export a patch against it",
+						Required:    false,
+					},
+					{
+						Name:        "terraform-allow-recreate",
+						Default:     "false",
+						Description: "Set disk type, disk shrink and preemptibility changes that recreate the resource in terraform-export, instead of leaving them as comments",
+						Required:    false,
+					},
+					{
+						Name:        "gcloud-script-dir",
+						Default:     "",
+						Description: "Directory to write a gcloud remediation script per project to",
+						Required:    false,
+					},
+					{
+						Name:        "html-export",
+						Default:     "",
+						Description: "File to write a self-contained HTML report of the recommendations to",
+						Required:    false,
+					},
+					{
+						Name:        "commitments-export",
+						Default:     "",
+						Description: "File to write the commitment purchase recommendations to as CSV",
+						Required:    false,
+					},
+					{
+						Name:        "summary-export",
+						Default:     "",
+						Description: "File to write the savings breakdowns by recommendation type, project, region, machine family and label to as CSV",
+						Required:    false,
+					},
+					{
+						Name:        "summary-label",
+						Default:     "team",
+						Description: "Instance label the savings are broken down by in the summary",
+						Required:    false,
+					},
+					{
+						Name:        "snapshot",
+						Default:     "true",
+						Description: "Store the results of the scan in the local snapshot store",
+						Required:    false,
+					},
+					{
+						Name:        "snapshot-dir",
+						Default:     "",
+						Description: "Directory of the snapshot store, defaults to ~/.kaytu/plugin-gcp/snapshots",
+						Required:    false,
+					},
+					{
+						Name:        "diff-export",
+						Default:     "",
+						Description: "File to write the diff of the scan against the previous snapshot to",
+						Required:    false,
+					},
+					{
+						Name:        "diff-base",
+						Default:     "",
+						Description: "Snapshot file to diff the scan against instead of the previous snapshot",
+						Required:    false,
+					},
+					{
+						Name:        "diff-current",
+						Default:     "",
+						Description: "Snapshot file to diff against diff-base instead of scanning, the diff is written to diff-export",
+						Required:    false,
+					},
+					{
+						Name:        "google-recommender",
+						Default:     "true",
+						Description: "Compare the recommendations with the ones of the Google Recommender API",
+						Required:    false,
+					},
+					{
+						Name:        "apply",
+						Default:     "false",
+						Description: "Apply the recommendations of the instances listed in apply-instances, needs write access to Compute Engine",
+						Required:    false,
+					},
+					{
+						Name:        "apply-instances",
+						Default:     "",
+						Description: "Comma separated names of the instances confirmed to be changed by apply",
+						Required:    false,
+					},
+					{
+						Name:        "apply-dry-run",
+						Default:     "true",
+						Description: "Only plan the changes of apply, set to false to stop and change the instances",
+						Required:    false,
+					},
+					{
+						Name:        "apply-rollback-file",
+						Default:     "gcp-rightsizing-rollback.json",
+						Description: "File the rollback record of every applied instance is appended to",
+						Required:    false,
+					},
+				}),
 				DefaultPreferences: preferences.DefaultComputeEnginePreferences,
 				LoginRequired:      true,
 			},
+			{
+				Name:               "storage-bucket",
+				Description:        "Get optimization suggestions for your Cloud Storage Buckets",
+				Flags:              commandFlags(nil),
+				DefaultPreferences: preferences.DefaultStorageBucketPreferences,
+				LoginRequired:      false,
+			},
+			{
+				Name:               "memorystore",
+				Description:        "Get optimization suggestions for your Memorystore for Redis Instances",
+				Flags:              commandFlags(nil),
+				DefaultPreferences: preferences.DefaultMemorystorePreferences,
+				LoginRequired:      false,
+			},
+			{
+				Name:               "cloud-run",
+				Description:        "Get optimization suggestions for your Cloud Run Services and Cloud Functions",
+				Flags:              commandFlags(nil),
+				DefaultPreferences: preferences.DefaultCloudRunPreferences,
+				LoginRequired:      false,
+			},
+			{
+				Name:               "load-balancer",
+				Description:        "Find idle Cloud Load Balancers and their forwarding rule costs",
+				Flags:              commandFlags(nil),
+				DefaultPreferences: preferences.DefaultLoadBalancerPreferences,
+				LoginRequired:      false,
+			},
 		},
 		OverviewChart: &golang.ChartDefinition{
 
@@ -132,13 +325,15 @@ func (p *GCPPlugin) SetStream(_ context.Context, stream *sdk.StreamController) {
 
 // StartProcess implements sdk.Processor.
 func (p *GCPPlugin) StartProcess(ctx context.Context, cmd string, flags map[string]string, kaytuAccessToken string, preferences []*golang.PreferenceItem, jobQueue *sdk.JobQueue) error {
+	if cmd == "compute-instance" && flags["diff-current"] != "" {
+		return p.diffSnapshotFiles(flags["diff-base"], flags["diff-current"], flags["diff-export"])
+	}
 
-	// scope used from https://developers.google.com/identity/protocols/oauth2/scopes#compute
-	gcpProvider := gcp.NewCompute(
-		[]string{
-			"https://www.googleapis.com/auth/compute.readonly",
-		},
-	)
+	rateLimits, err := gcp.ParseRateLimits(flags["api-rate-limit"], flags["api-max-retries"])
+	if err != nil {
+		return err
+	}
+	gcp.ConfigureRateLimits(rateLimits)
 
 	metricClient := gcp.NewCloudMonitoring(
 		[]string{
@@ -148,14 +343,21 @@ func (p *GCPPlugin) StartProcess(ctx context.Context, cmd string, flags map[stri
 
 	log.Println("Initializing clients")
 
-	err := gcpProvider.InitializeClient(ctx)
+	err = metricClient.InitializeClient(ctx)
 	if err != nil {
 		return err
 	}
 
-	err = metricClient.InitializeClient(ctx)
-	if err != nil {
-		return err
+	if flags["no-cache"] != "true" {
+		ttl, err := time.ParseDuration(flags["metrics-cache-ttl"])
+		if err != nil && flags["metrics-cache-ttl"] != "" {
+			return fmt.Errorf("invalid metrics-cache-ttl %s: %s", flags["metrics-cache-ttl"], err.Error())
+		}
+		metricClient.Cache, err = gcp.NewMetricsCache("", ttl)
+		if err != nil {
+			// metrics are fetched in full when there is no cache directory
+			log.Printf("failed to create the metrics cache: %s", err.Error())
+		}
 	}
 
 	publishOptimizationItem := func(item *golang.ChartOptimizationItem) {
@@ -209,7 +411,64 @@ func (p *GCPPlugin) StartProcess(ctx context.Context, cmd string, flags map[stri
 	}
 	client := golang2.NewOptimizationClient(conn)
 
-	if cmd == "compute-instance" {
+	switch cmd {
+	case "compute-instance":
+		// scope used from https://developers.google.com/identity/protocols/oauth2/scopes#compute
+		scope := "https://www.googleapis.com/auth/compute.readonly"
+		if flags["apply"] == "true" {
+			scope = "https://www.googleapis.com/auth/compute"
+		}
+		gcpProvider := gcp.NewCompute(
+			[]string{
+				scope,
+			},
+		)
+		err = gcpProvider.InitializeClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		var billingExport *gcp.BillingExport
+		if flags["billing-export-table"] != "" || flags["billing-export-file"] != "" {
+			// scope used from https://developers.google.com/identity/protocols/oauth2/scopes#bigquery
+			billingExport = gcp.NewBillingExport(
+				[]string{
+					"https://www.googleapis.com/auth/bigquery.readonly",
+				},
+				flags["billing-export-table"],
+				flags["billing-export-file"],
+			)
+			err = billingExport.InitializeClient(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		var recommender *gcp.Recommender
+		if flags["google-recommender"] != "false" {
+			// scope used from https://developers.google.com/identity/protocols/oauth2/scopes#recommender
+			recommender = gcp.NewRecommender(
+				[]string{
+					"https://www.googleapis.com/auth/cloud-platform",
+				},
+			)
+			err = recommender.InitializeClient(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		// scope used from https://developers.google.com/identity/protocols/oauth2/scopes#cloudbilling
+		pricing := gcp.NewPricing(
+			[]string{
+				"https://www.googleapis.com/auth/cloud-billing.readonly",
+			},
+		)
+		err = pricing.InitializeClient(ctx)
+		if err != nil {
+			return err
+		}
+
 		p.processor = compute_instance.NewComputeInstanceProcessor(
 			gcpProvider,
 			metricClient,
@@ -219,18 +478,155 @@ func (p *GCPPlugin) StartProcess(ctx context.Context, cmd string, flags map[stri
 			jobQueue,
 			client,
 			preferences,
+			billingExport,
+			recommender,
+			pricing,
+			compute_instance.ExportOptions{
+				JsonFile:               flags["json-export"],
+				TerraformFile:          flags["terraform-export"],
+				TerraformDir:           flags["terraform-dir"],
+				TerraformAllowRecreate: flags["terraform-allow-recreate"] == "true",
+				GcloudScriptDir:        flags["gcloud-script-dir"],
+				HtmlFile:               flags["html-export"],
+				CommitmentsFile:        flags["commitments-export"],
+				SummaryFile:            flags["summary-export"],
+			},
+			compute_instance.NewApplyOptions(flags["apply"], flags["apply-dry-run"], flags["apply-instances"], flags["apply-rollback-file"]),
+			flags["summary-label"],
+			compute_instance.NewSnapshotOptions(flags["snapshot"], flags["snapshot-dir"], flags["diff-export"], flags["diff-base"]),
 		)
-	} else {
+	case "storage-bucket":
+		// scope used from https://developers.google.com/identity/protocols/oauth2/scopes#storage
+		storageProvider := gcp.NewStorage(
+			[]string{
+				"https://www.googleapis.com/auth/devstorage.read_only",
+			},
+		)
+		err = storageProvider.InitializeClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		p.processor = storage_bucket.NewStorageBucketProcessor(
+			storageProvider,
+			metricClient,
+			publishOptimizationItem,
+			publishResultSummary,
+			kaytuAccessToken,
+			jobQueue,
+			preferences,
+		)
+	case "memorystore":
+		// scope used from https://developers.google.com/identity/protocols/oauth2/scopes#redis
+		memorystoreProvider := gcp.NewMemorystore(
+			[]string{
+				"https://www.googleapis.com/auth/cloud-platform",
+			},
+		)
+		err = memorystoreProvider.InitializeClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		p.processor = memorystore.NewMemorystoreProcessor(
+			memorystoreProvider,
+			metricClient,
+			publishOptimizationItem,
+			publishResultSummary,
+			kaytuAccessToken,
+			jobQueue,
+			preferences,
+		)
+	case "cloud-run":
+		// scope used from https://developers.google.com/identity/protocols/oauth2/scopes#run
+		cloudRunProvider := gcp.NewCloudRun(
+			[]string{
+				"https://www.googleapis.com/auth/cloud-platform.read-only",
+			},
+		)
+		err = cloudRunProvider.InitializeClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		p.processor = cloud_run.NewCloudRunProcessor(
+			cloudRunProvider,
+			metricClient,
+			publishOptimizationItem,
+			publishResultSummary,
+			kaytuAccessToken,
+			jobQueue,
+			preferences,
+		)
+	case "load-balancer":
+		// scope used from https://developers.google.com/identity/protocols/oauth2/scopes#compute
+		gcpProvider := gcp.NewCompute(
+			[]string{
+				"https://www.googleapis.com/auth/compute.readonly",
+			},
+		)
+		err = gcpProvider.InitializeClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		p.processor = load_balancer.NewLoadBalancerProcessor(
+			gcpProvider,
+			metricClient,
+			publishOptimizationItem,
+			publishResultSummary,
+			kaytuAccessToken,
+			jobQueue,
+			preferences,
+		)
+	default:
 		return fmt.Errorf("invalid command: %s", cmd)
 	}
 	jobQueue.SetOnFinish(func(ctx context.Context) {
 		publishNonInteractiveExport(p.processor.ExportNonInteractive())
+		if exporter, ok := p.processor.(processor.FileExporter); ok {
+			err := exporter.ExportFiles()
+			if err != nil {
+				log.Printf("failed to export files: %v", err)
+			}
+		}
+		gcp.LogThrottleStats()
 		publishResultsReady(true)
 	})
 
 	return nil
 }
 
+// diffSnapshotFiles diffs two snapshot files of the compute instances without scanning, the diff is
+// reported as the result summary and written to diffFile when it is set
+func (p *GCPPlugin) diffSnapshotFiles(basePath, currentPath, diffFile string) error {
+	if basePath == "" {
+		return fmt.Errorf("diff-current needs the diff-base snapshot to diff against")
+	}
+	diff, err := compute_instance.DiffSnapshotFiles(basePath, currentPath)
+	if err != nil {
+		return err
+	}
+	if diffFile != "" {
+		err = shared.WriteJson(diffFile, []compute_instance.SnapshotDiff{diff})
+		if err != nil {
+			return err
+		}
+	}
+
+	p.stream.Send(&golang.PluginMessage{
+		PluginMessage: &golang.PluginMessage_Summary{
+			Summary: &golang.ResultSummary{Message: diff.String()},
+		},
+	})
+	p.stream.Send(&golang.PluginMessage{
+		PluginMessage: &golang.PluginMessage_Ready{
+			Ready: &golang.ResultsReady{Ready: true},
+		},
+	})
+	return nil
+}
+
 func (p *GCPPlugin) ReEvaluate(_ context.Context, evaluate *golang.ReEvaluate) {
 	p.processor.ReEvaluate(evaluate.Id, evaluate.Preferences)
 }